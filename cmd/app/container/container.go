@@ -5,14 +5,24 @@ import (
 	"github.com/misalima/my-budget-planner-backend/internal/core/interfaces/iservice"
 	"github.com/misalima/my-budget-planner-backend/internal/core/services"
 	postgres "github.com/misalima/my-budget-planner-backend/internal/infra/postgres"
+	"os"
 )
 
 type Container struct {
-	UserManager       iservice.UserManager
-	CategoryManager   iservice.CategoryManager
-	AuthManager       iservice.AuthManager
-	CreditCardManager iservice.CreditCardManager
-	ExpenseManagers   ExpenseManagers
+	UserManager         iservice.UserManager
+	CategoryManager     iservice.CategoryManager
+	AuthManager         iservice.AuthManager
+	CreditCardManager   iservice.CreditCardManager
+	BudgetManager       iservice.BudgetManager
+	CardPaymentManager  iservice.CardPaymentManager
+	IncomeManager       iservice.IncomeManager
+	TransactionManager  iservice.TransactionManager
+	ReportManager       iservice.ReportManager
+	ExchangeRateManager iservice.ExchangeRateManager
+	ImportManager       iservice.ImportManager
+	ExportManager       iservice.ExportManager
+	CalendarManager     iservice.CalendarManager
+	ExpenseManagers     ExpenseManagers
 }
 
 type ExpenseManagers struct {
@@ -24,21 +34,47 @@ type ExpenseManagers struct {
 func NewContainer(pool *pgxpool.Pool) *Container {
 	userLoader := postgres.NewUserRepository(pool)
 	categoryLoader := postgres.NewCategoryRepository(pool)
-	authLoader := postgres.NewAuthRepository(pool)
+	authLoader := postgres.NewAuthRepository(pool, refreshTokenHashKey())
+	totpLoader := postgres.NewTOTPRepository(pool)
 	creditCardLoader := postgres.NewCreditCardRepository(pool)
 	creditCardExpenseLoader := postgres.NewCreditCardExpenseRepository(pool)
 	simpleExpenseLoader := postgres.NewSimpleExpenseRepository(pool)
 	recurringExpenseLoader := postgres.NewRecurringExpenseRepository(pool)
+	budgetLoader := postgres.NewBudgetRepository(pool)
+	cardPaymentLoader := postgres.NewCardPaymentRepository(pool)
+	incomeLoader := postgres.NewIncomeRepository(pool)
+	exchangeRateLoader := postgres.NewExchangeRateRepository(pool)
+	importLoader := postgres.NewImportRepository(pool)
+	calendarLoader := postgres.NewCalendarRepository(pool)
+	transactionLoader := postgres.NewTransactionRepository(pool)
 
 	return &Container{
-		UserManager:       services.NewUserService(userLoader),
-		CategoryManager:   services.NewCategoryService(categoryLoader),
-		AuthManager:       services.NewAuthService(authLoader, userLoader),
-		CreditCardManager: services.NewCreditCardService(creditCardLoader),
+		UserManager:         services.NewUserService(userLoader),
+		CategoryManager:     services.NewCategoryService(categoryLoader),
+		AuthManager:         services.NewAuthService(authLoader, userLoader, totpLoader),
+		CreditCardManager:   services.NewCreditCardService(creditCardLoader, creditCardExpenseLoader, cardPaymentLoader, userLoader),
+		BudgetManager:       services.NewBudgetService(budgetLoader, categoryLoader, simpleExpenseLoader, recurringExpenseLoader, creditCardExpenseLoader, userLoader, exchangeRateLoader),
+		CardPaymentManager:  services.NewCardPaymentService(cardPaymentLoader, creditCardLoader),
+		IncomeManager:       services.NewIncomeService(incomeLoader, categoryLoader, simpleExpenseLoader, recurringExpenseLoader, creditCardExpenseLoader, userLoader, exchangeRateLoader),
+		TransactionManager:  services.NewTransactionService(transactionLoader),
+		ReportManager:       services.NewReportService(userLoader, categoryLoader, simpleExpenseLoader, recurringExpenseLoader, creditCardExpenseLoader, incomeLoader, exchangeRateLoader),
+		ExchangeRateManager: services.NewExchangeRateService(exchangeRateLoader),
+		ImportManager:       services.NewImportService(importLoader, creditCardExpenseLoader, creditCardLoader, categoryLoader, userLoader),
+		ExportManager:       services.NewExportService(userLoader, categoryLoader, creditCardLoader, simpleExpenseLoader, recurringExpenseLoader, creditCardExpenseLoader, budgetLoader, incomeLoader, cardPaymentLoader),
+		CalendarManager:     services.NewCalendarService(calendarLoader, creditCardLoader, recurringExpenseLoader),
 		ExpenseManagers: ExpenseManagers{
-			CreditCardExpenseManager: services.NewCreditCardExpenseService(creditCardExpenseLoader),
-			SimpleExpenseManager:     services.NewSimpleExpenseService(simpleExpenseLoader),
-			RecurringExpenseManager:  services.NewRecurringExpenseService(recurringExpenseLoader),
+			CreditCardExpenseManager: services.NewCreditCardExpenseService(creditCardExpenseLoader, creditCardLoader, userLoader, exchangeRateLoader),
+			SimpleExpenseManager:     services.NewSimpleExpenseService(simpleExpenseLoader, userLoader, exchangeRateLoader),
+			RecurringExpenseManager:  services.NewRecurringExpenseService(recurringExpenseLoader, userLoader, exchangeRateLoader),
 		},
 	}
 }
+
+// refreshTokenHashKey is the key refresh tokens are hashed with before being stored:
+// MBP_REFRESH_TOKEN_KEY, or JWT_SECRET when it is not set. Changing it logs every user out.
+func refreshTokenHashKey() []byte {
+	if key := os.Getenv("MBP_REFRESH_TOKEN_KEY"); key != "" {
+		return []byte(key)
+	}
+	return []byte(os.Getenv("JWT_SECRET"))
+}
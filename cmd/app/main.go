@@ -1,8 +1,10 @@
 package main
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/joho/godotenv"
 	"github.com/labstack/echo/v4"
 	"github.com/labstack/echo/v4/middleware"
@@ -10,6 +12,8 @@ import (
 	"github.com/misalima/my-budget-planner-backend/internal/api/http/handlers"
 	"github.com/misalima/my-budget-planner-backend/internal/api/http/router"
 	"github.com/misalima/my-budget-planner-backend/internal/infra/postgres"
+	"github.com/misalima/my-budget-planner-backend/internal/scheduler"
+	"log"
 	"os"
 )
 
@@ -60,8 +64,16 @@ func main() {
 
 	ctn := container.NewContainer(pool)
 
+	if err := loadExchangeRates(ctn); err != nil {
+		e.Logger.Fatal(err)
+	}
+
 	setUpHandlers(e, ctn)
 
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	setUpScheduler(pool, ctn).Start(ctx)
+
 	e.Logger.Fatal(e.Start(":8000"))
 
 }
@@ -84,6 +96,27 @@ func loadEnv() (string, error) {
 	return connStr, nil
 }
 
+// loadExchangeRates imports the exchange rate CSV named by MBP_EXCHANGE_RATES_CSV, if any.
+func loadExchangeRates(container *container.Container) error {
+	path := os.Getenv("MBP_EXCHANGE_RATES_CSV")
+	if path == "" {
+		return nil
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("failed to open exchange rates file: %w", err)
+	}
+	defer file.Close()
+
+	count, err := container.ExchangeRateManager.ImportExchangeRatesCSV(context.Background(), file)
+	if err != nil {
+		return fmt.Errorf("failed to import exchange rates from %s: %w", path, err)
+	}
+	log.Printf("Loaded %d exchange rates from %s", count, path)
+	return nil
+}
+
 func setUpHandlers(e *echo.Echo, container *container.Container) {
 
 	userHandler := handlers.NewUserHandler(container.UserManager)
@@ -93,6 +126,25 @@ func setUpHandlers(e *echo.Echo, container *container.Container) {
 	simpleExpenseHandler := handlers.NewSimpleExpenseHandler(container.ExpenseManagers.SimpleExpenseManager)
 	recurringExpenseHandler := handlers.NewRecurringExpenseHandler(container.ExpenseManagers.RecurringExpenseManager)
 	creditCardExpenseHandler := handlers.NewCreditCardExpenseHandler(container.ExpenseManagers.CreditCardExpenseManager)
+	budgetHandler := handlers.NewBudgetHandler(container.BudgetManager)
+	cardPaymentHandler := handlers.NewCardPaymentHandler(container.CardPaymentManager)
+	incomeHandler := handlers.NewIncomeHandler(container.IncomeManager)
+	transactionHandler := handlers.NewTransactionHandler(container.TransactionManager)
+	reportHandler := handlers.NewReportHandler(container.ReportManager)
+	exchangeRateHandler := handlers.NewExchangeRateHandler(container.ExchangeRateManager)
+	importHandler := handlers.NewImportHandler(container.ImportManager)
+	exportHandler := handlers.NewExportHandler(container.ExportManager)
+	calendarHandler := handlers.NewCalendarHandler(container.CalendarManager)
+
+	router.LoadRoutes(e, userHandler, authHandler, categoryHandler, creditCardHandler, simpleExpenseHandler, recurringExpenseHandler, creditCardExpenseHandler, budgetHandler, cardPaymentHandler, incomeHandler, transactionHandler, reportHandler, exchangeRateHandler, importHandler, exportHandler, calendarHandler)
+}
 
-	router.LoadRoutes(e, userHandler, authHandler, categoryHandler, creditCardHandler, simpleExpenseHandler, recurringExpenseHandler, creditCardExpenseHandler)
+func setUpScheduler(pool *pgxpool.Pool, container *container.Container) *scheduler.Scheduler {
+	s := scheduler.New(postgres.NewAdvisoryLocker(pool), postgres.NewSchedulerRunRepository(pool))
+	s.Add(scheduler.RecurringExpenseGenerationJob(container.UserManager, container.ExpenseManagers.RecurringExpenseManager))
+	s.Add(scheduler.RefreshTokenCleanupJob(container.AuthManager))
+	if path := os.Getenv("MBP_EXCHANGE_RATES_CSV"); path != "" {
+		s.Add(scheduler.ExchangeRateReloadJob(container.ExchangeRateManager, path))
+	}
+	return s
 }
@@ -0,0 +1,21 @@
+ALTER TABLE budgets
+    ADD COLUMN IF NOT EXISTS start_date date NOT NULL DEFAULT date_trunc('month', now())::date,
+    ADD COLUMN IF NOT EXISTS end_date date NOT NULL DEFAULT (date_trunc('month', now()) + interval '1 month - 1 day')::date,
+    ADD COLUMN IF NOT EXISTS created_at timestamp with time zone NOT NULL DEFAULT now(),
+    ADD COLUMN IF NOT EXISTS updated_at timestamp with time zone NOT NULL DEFAULT now();
+
+ALTER TABLE budgets
+    ALTER COLUMN start_date DROP DEFAULT,
+    ALTER COLUMN end_date DROP DEFAULT;
+
+CREATE INDEX IF NOT EXISTS idx_budgets_user_id ON budgets (user_id);
+
+---- create above / drop below ----
+
+DROP INDEX IF EXISTS idx_budgets_user_id;
+
+ALTER TABLE budgets
+    DROP COLUMN IF EXISTS start_date,
+    DROP COLUMN IF EXISTS end_date,
+    DROP COLUMN IF EXISTS created_at,
+    DROP COLUMN IF EXISTS updated_at;
@@ -0,0 +1,24 @@
+ALTER TABLE budgets
+    ADD COLUMN IF NOT EXISTS period character varying NOT NULL DEFAULT 'custom',
+    ADD COLUMN IF NOT EXISTS rollover boolean NOT NULL DEFAULT false,
+    ALTER COLUMN end_date DROP NOT NULL;
+
+CREATE TABLE IF NOT EXISTS budget_categories
+(
+    budget_id int NOT NULL,
+    category_id int NOT NULL,
+    PRIMARY KEY (budget_id, category_id),
+    CONSTRAINT fk_budget_id FOREIGN KEY (budget_id) REFERENCES budgets ("ID") ON DELETE CASCADE,
+    CONSTRAINT fk_category_id FOREIGN KEY (category_id) REFERENCES categories ("ID") ON DELETE CASCADE
+);
+
+---- create above / drop below ----
+
+DROP TABLE IF EXISTS budget_categories;
+
+UPDATE budgets SET end_date = start_date WHERE end_date IS NULL;
+
+ALTER TABLE budgets
+    DROP COLUMN IF EXISTS period,
+    DROP COLUMN IF EXISTS rollover,
+    ALTER COLUMN end_date SET NOT NULL;
@@ -0,0 +1,16 @@
+ALTER TABLE recurring_expense
+    ADD COLUMN IF NOT EXISTS template_id uuid DEFAULT NULL,
+    ADD CONSTRAINT fk_template_id FOREIGN KEY (template_id) REFERENCES recurring_expense ("ID") ON DELETE CASCADE;
+
+-- One generated occurrence per template and date, so generation can be re-run safely.
+CREATE UNIQUE INDEX IF NOT EXISTS uq_recurring_expense_template_date ON recurring_expense (template_id, date) WHERE template_id IS NOT NULL;
+
+---- create above / drop below ----
+
+DROP INDEX IF EXISTS uq_recurring_expense_template_date;
+
+DELETE FROM recurring_expense WHERE template_id IS NOT NULL;
+
+ALTER TABLE recurring_expense
+    DROP CONSTRAINT IF EXISTS fk_template_id,
+    DROP COLUMN IF EXISTS template_id;
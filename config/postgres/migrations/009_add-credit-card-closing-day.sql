@@ -0,0 +1,21 @@
+ALTER TABLE credit_cards
+    ADD COLUMN IF NOT EXISTS closing_day integer;
+
+-- Cards created before closing days existed are assumed to close a week before the due date.
+UPDATE credit_cards
+SET closing_day = CASE WHEN due_date > 7 THEN due_date - 7 ELSE due_date + 21 END
+WHERE closing_day IS NULL;
+
+ALTER TABLE credit_cards
+    ALTER COLUMN closing_day SET NOT NULL,
+    ADD CONSTRAINT chk_closing_day CHECK (closing_day BETWEEN 1 AND 31);
+
+CREATE INDEX IF NOT EXISTS idx_credit_card_expense_card_id_date ON credit_card_expense (card_id, date);
+
+---- create above / drop below ----
+
+DROP INDEX IF EXISTS idx_credit_card_expense_card_id_date;
+
+ALTER TABLE credit_cards
+    DROP CONSTRAINT IF EXISTS chk_closing_day,
+    DROP COLUMN IF EXISTS closing_day;
@@ -0,0 +1,22 @@
+CREATE TABLE IF NOT EXISTS card_payments
+(
+    "ID" uuid PRIMARY KEY NOT NULL DEFAULT gen_random_uuid(),
+    user_id uuid NOT NULL,
+    card_id uuid NOT NULL,
+    statement_month date NOT NULL,
+    amount double precision NOT NULL,
+    paid_at date NOT NULL,
+    description character varying(255),
+    created_at timestamp with time zone NOT NULL DEFAULT now(),
+    updated_at timestamp with time zone NOT NULL DEFAULT now(),
+    CONSTRAINT fk_card_payments_user_id FOREIGN KEY (user_id) REFERENCES users ("ID") ON DELETE CASCADE,
+    CONSTRAINT fk_card_payments_card_id FOREIGN KEY (card_id) REFERENCES credit_cards ("ID") ON DELETE CASCADE,
+    CONSTRAINT chk_card_payments_amount CHECK (amount > 0)
+);
+
+-- statement_month is always the first day of the month in which the paid statement is due.
+CREATE INDEX IF NOT EXISTS idx_card_payments_card_id_statement_month ON card_payments (card_id, statement_month);
+
+---- create above / drop below ----
+
+DROP TABLE IF EXISTS card_payments;
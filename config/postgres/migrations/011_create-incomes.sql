@@ -0,0 +1,40 @@
+ALTER TABLE categories
+    ADD COLUMN IF NOT EXISTS kind character varying NOT NULL DEFAULT 'expense',
+    ADD CONSTRAINT chk_category_kind CHECK (kind IN ('expense', 'income'));
+
+INSERT INTO categories (category_name, kind)
+VALUES ('Salary', 'income'),
+       ('Freelance', 'income'),
+       ('Refunds', 'income'),
+       ('Investments', 'income'),
+       ('Other income', 'income');
+
+-- A NULL frequency marks a one-off income received on date; otherwise date is the
+-- first occurrence of a recurring income that repeats until end_date, if any.
+CREATE TABLE IF NOT EXISTS incomes
+(
+    "ID" uuid PRIMARY KEY NOT NULL DEFAULT gen_random_uuid(),
+    user_id uuid NOT NULL,
+    category_id int NOT NULL,
+    amount double precision NOT NULL,
+    description character varying(255),
+    date date NOT NULL,
+    frequency character varying,
+    end_date date,
+    created_at timestamp with time zone NOT NULL DEFAULT now(),
+    updated_at timestamp with time zone NOT NULL DEFAULT now(),
+    CONSTRAINT fk_incomes_user_id FOREIGN KEY (user_id) REFERENCES users ("ID") ON DELETE CASCADE,
+    CONSTRAINT fk_incomes_category_id FOREIGN KEY (category_id) REFERENCES categories ("ID") ON DELETE RESTRICT
+);
+
+CREATE INDEX IF NOT EXISTS idx_incomes_user_id_date ON incomes (user_id, date);
+
+---- create above / drop below ----
+
+DROP TABLE IF EXISTS incomes;
+
+DELETE FROM categories WHERE kind = 'income' AND user_id IS NULL;
+
+ALTER TABLE categories
+    DROP CONSTRAINT IF EXISTS chk_category_kind,
+    DROP COLUMN IF EXISTS kind;
@@ -0,0 +1,95 @@
+-- Amounts move from double precision to exact numeric(14,2); existing values are rounded to the cent.
+ALTER TABLE users
+    ALTER COLUMN income TYPE numeric(14, 2) USING round(income::numeric, 2),
+    ALTER COLUMN expenditure_limit TYPE numeric(14, 2) USING round(expenditure_limit::numeric, 2);
+
+ALTER TABLE credit_cards
+    ALTER COLUMN total_limit TYPE numeric(14, 2) USING round(total_limit::numeric, 2),
+    ALTER COLUMN current_limit TYPE numeric(14, 2) USING round(current_limit::numeric, 2);
+
+ALTER TABLE simple_expense
+    ALTER COLUMN amount TYPE numeric(14, 2) USING round(amount::numeric, 2);
+
+ALTER TABLE recurring_expense
+    ALTER COLUMN amount TYPE numeric(14, 2) USING round(amount::numeric, 2);
+
+ALTER TABLE credit_card_expense
+    ALTER COLUMN amount TYPE numeric(14, 2) USING round(amount::numeric, 2),
+    ALTER COLUMN installment_amount TYPE numeric(14, 2) USING round(installment_amount::numeric, 2);
+
+ALTER TABLE budgets
+    ALTER COLUMN amount TYPE numeric(14, 2) USING round(amount::numeric, 2);
+
+ALTER TABLE card_payments
+    ALTER COLUMN amount TYPE numeric(14, 2) USING round(amount::numeric, 2);
+
+ALTER TABLE incomes
+    ALTER COLUMN amount TYPE numeric(14, 2) USING round(amount::numeric, 2);
+
+-- Single payments stored without a parcel value charge their whole amount.
+UPDATE credit_card_expense
+SET installment_amount = amount
+WHERE installments_quantity <= 1 AND installment_amount = 0;
+
+-- Parcels of one purchase were inserted together, sharing card, amount, quantity and creation time.
+-- When every parcel is present and they only miss the amount by rounding, the first parcel
+-- takes the remaining cents so that the parcels add up exactly to the purchase.
+WITH purchases AS (
+    SELECT user_id, card_id, amount, installments_quantity, created_at, SUM(installment_amount) AS charged
+    FROM credit_card_expense
+    WHERE installments_quantity > 1
+    GROUP BY user_id, card_id, amount, installments_quantity, created_at
+    HAVING COUNT(*) = installments_quantity
+       AND SUM(installment_amount) <> amount
+       AND abs(SUM(installment_amount) - amount) < 0.01 * installments_quantity
+)
+UPDATE credit_card_expense e
+SET installment_amount = e.installment_amount + (p.amount - p.charged)
+FROM purchases p
+WHERE e.user_id = p.user_id
+  AND e.card_id = p.card_id
+  AND e.amount = p.amount
+  AND e.installments_quantity = p.installments_quantity
+  AND e.created_at = p.created_at
+  AND e.parcel_number = 1;
+
+-- Available limits accumulated float rounding as well, rebuild them from history.
+UPDATE credit_cards cc
+SET current_limit = cc.total_limit - COALESCE((
+        SELECT SUM(COALESCE(NULLIF(e.installment_amount, 0), e.amount))
+        FROM credit_card_expense e
+        WHERE e.card_id = cc."ID"
+    ), 0) + COALESCE((
+        SELECT SUM(p.amount)
+        FROM card_payments p
+        WHERE p.card_id = cc."ID"
+    ), 0);
+
+---- create above / drop below ----
+
+ALTER TABLE incomes
+    ALTER COLUMN amount TYPE double precision;
+
+ALTER TABLE card_payments
+    ALTER COLUMN amount TYPE double precision;
+
+ALTER TABLE budgets
+    ALTER COLUMN amount TYPE double precision;
+
+ALTER TABLE credit_card_expense
+    ALTER COLUMN amount TYPE double precision,
+    ALTER COLUMN installment_amount TYPE double precision;
+
+ALTER TABLE recurring_expense
+    ALTER COLUMN amount TYPE double precision;
+
+ALTER TABLE simple_expense
+    ALTER COLUMN amount TYPE double precision;
+
+ALTER TABLE credit_cards
+    ALTER COLUMN total_limit TYPE double precision,
+    ALTER COLUMN current_limit TYPE double precision;
+
+ALTER TABLE users
+    ALTER COLUMN income TYPE double precision,
+    ALTER COLUMN expenditure_limit TYPE double precision;
@@ -0,0 +1,66 @@
+-- Every amount already stored was entered in reais, so existing rows default to BRL.
+ALTER TABLE users
+    ADD COLUMN IF NOT EXISTS base_currency char(3) NOT NULL DEFAULT 'BRL',
+    ADD CONSTRAINT chk_users_base_currency CHECK (base_currency ~ '^[A-Z]{3}$');
+
+ALTER TABLE credit_cards
+    ADD COLUMN IF NOT EXISTS currency char(3) NOT NULL DEFAULT 'BRL',
+    ADD CONSTRAINT chk_credit_cards_currency CHECK (currency ~ '^[A-Z]{3}$');
+
+ALTER TABLE simple_expense
+    ADD COLUMN IF NOT EXISTS currency char(3) NOT NULL DEFAULT 'BRL',
+    ADD CONSTRAINT chk_simple_expense_currency CHECK (currency ~ '^[A-Z]{3}$');
+
+ALTER TABLE recurring_expense
+    ADD COLUMN IF NOT EXISTS currency char(3) NOT NULL DEFAULT 'BRL',
+    ADD CONSTRAINT chk_recurring_expense_currency CHECK (currency ~ '^[A-Z]{3}$');
+
+ALTER TABLE credit_card_expense
+    ADD COLUMN IF NOT EXISTS currency char(3) NOT NULL DEFAULT 'BRL',
+    ADD CONSTRAINT chk_credit_card_expense_currency CHECK (currency ~ '^[A-Z]{3}$');
+
+ALTER TABLE incomes
+    ADD COLUMN IF NOT EXISTS currency char(3) NOT NULL DEFAULT 'BRL',
+    ADD CONSTRAINT chk_incomes_currency CHECK (currency ~ '^[A-Z]{3}$');
+
+-- One unit of base_currency buys rate units of quote_currency on the given date.
+CREATE TABLE IF NOT EXISTS exchange_rates
+(
+    date date NOT NULL,
+    base_currency char(3) NOT NULL,
+    quote_currency char(3) NOT NULL,
+    rate numeric(20, 10) NOT NULL,
+    created_at timestamp with time zone NOT NULL DEFAULT now(),
+    updated_at timestamp with time zone NOT NULL DEFAULT now(),
+    PRIMARY KEY (base_currency, quote_currency, date),
+    CONSTRAINT chk_exchange_rates_rate CHECK (rate > 0),
+    CONSTRAINT chk_exchange_rates_pair CHECK (base_currency <> quote_currency)
+);
+
+---- create above / drop below ----
+
+DROP TABLE IF EXISTS exchange_rates;
+
+ALTER TABLE incomes
+    DROP CONSTRAINT IF EXISTS chk_incomes_currency,
+    DROP COLUMN IF EXISTS currency;
+
+ALTER TABLE credit_card_expense
+    DROP CONSTRAINT IF EXISTS chk_credit_card_expense_currency,
+    DROP COLUMN IF EXISTS currency;
+
+ALTER TABLE recurring_expense
+    DROP CONSTRAINT IF EXISTS chk_recurring_expense_currency,
+    DROP COLUMN IF EXISTS currency;
+
+ALTER TABLE simple_expense
+    DROP CONSTRAINT IF EXISTS chk_simple_expense_currency,
+    DROP COLUMN IF EXISTS currency;
+
+ALTER TABLE credit_cards
+    DROP CONSTRAINT IF EXISTS chk_credit_cards_currency,
+    DROP COLUMN IF EXISTS currency;
+
+ALTER TABLE users
+    DROP CONSTRAINT IF EXISTS chk_users_base_currency,
+    DROP COLUMN IF EXISTS base_currency;
@@ -0,0 +1,24 @@
+-- external_id identifies an entry in the bank file it was imported from (for OFX,
+-- the account and the FITID), so importing an overlapping file skips what is already stored.
+ALTER TABLE simple_expense
+    ADD COLUMN IF NOT EXISTS external_id character varying(255);
+
+ALTER TABLE incomes
+    ADD COLUMN IF NOT EXISTS external_id character varying(255);
+
+CREATE UNIQUE INDEX IF NOT EXISTS uq_simple_expense_user_id_external_id
+    ON simple_expense (user_id, external_id) WHERE external_id IS NOT NULL;
+
+CREATE UNIQUE INDEX IF NOT EXISTS uq_incomes_user_id_external_id
+    ON incomes (user_id, external_id) WHERE external_id IS NOT NULL;
+
+---- create above / drop below ----
+
+DROP INDEX IF EXISTS uq_incomes_user_id_external_id;
+DROP INDEX IF EXISTS uq_simple_expense_user_id_external_id;
+
+ALTER TABLE incomes
+    DROP COLUMN IF EXISTS external_id;
+
+ALTER TABLE simple_expense
+    DROP COLUMN IF EXISTS external_id;
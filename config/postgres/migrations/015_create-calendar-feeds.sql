@@ -0,0 +1,14 @@
+-- One iCalendar feed per user. Only the SHA-256 hash of its token is stored, and deleting
+-- the row revokes the feed without touching any JWT.
+CREATE TABLE IF NOT EXISTS calendar_feeds
+(
+    user_id uuid PRIMARY KEY NOT NULL,
+    token_hash char(64) NOT NULL,
+    created_at timestamp with time zone NOT NULL DEFAULT now(),
+    CONSTRAINT fk_user_id FOREIGN KEY (user_id) REFERENCES users ("ID") ON DELETE CASCADE,
+    CONSTRAINT uq_calendar_feeds_token_hash UNIQUE (token_hash)
+);
+
+---- create above / drop below ----
+
+DROP TABLE IF EXISTS calendar_feeds;
@@ -0,0 +1,9 @@
+-- Access tokens issued before tokens_valid_after are rejected; logging out of every
+-- device moves it forward.
+ALTER TABLE users
+    ADD COLUMN IF NOT EXISTS tokens_valid_after timestamp with time zone;
+
+---- create above / drop below ----
+
+ALTER TABLE users
+    DROP COLUMN IF EXISTS tokens_valid_after;
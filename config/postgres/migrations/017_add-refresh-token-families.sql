@@ -0,0 +1,23 @@
+-- Every login starts a family of refresh tokens. Refreshing revokes the presented token
+-- and adds its replacement to the family; revoked rows are kept until they expire so
+-- that presenting one again can be detected.
+ALTER TABLE refresh_tokens
+    ADD COLUMN IF NOT EXISTS family_id uuid NOT NULL DEFAULT gen_random_uuid(),
+    ADD COLUMN IF NOT EXISTS revoked_at timestamp with time zone;
+
+ALTER TABLE refresh_tokens
+    ALTER COLUMN family_id DROP DEFAULT;
+
+CREATE INDEX IF NOT EXISTS idx_refresh_tokens_family_id ON refresh_tokens (family_id);
+CREATE INDEX IF NOT EXISTS idx_refresh_tokens_token ON refresh_tokens (token);
+
+---- create above / drop below ----
+
+DROP INDEX IF EXISTS idx_refresh_tokens_token;
+DROP INDEX IF EXISTS idx_refresh_tokens_family_id;
+
+DELETE FROM refresh_tokens WHERE revoked_at IS NOT NULL;
+
+ALTER TABLE refresh_tokens
+    DROP COLUMN IF EXISTS revoked_at,
+    DROP COLUMN IF EXISTS family_id;
@@ -0,0 +1,31 @@
+-- Refresh tokens are now stored as an HMAC-SHA256 keyed by the server, never in plaintext.
+-- The stored plaintext tokens cannot be hashed here without the key, so they are
+-- deleted: every session has to log in again.
+DELETE FROM refresh_tokens;
+
+DROP INDEX IF EXISTS idx_refresh_tokens_token;
+
+ALTER TABLE refresh_tokens
+    DROP COLUMN IF EXISTS token,
+    ADD COLUMN IF NOT EXISTS token_hash char(64) NOT NULL,
+    ADD CONSTRAINT uq_refresh_tokens_token_hash UNIQUE (token_hash);
+
+-- The user foreign key had no ON DELETE action, so users with sessions could not be deleted.
+ALTER TABLE refresh_tokens
+    DROP CONSTRAINT IF EXISTS refresh_tokens_user_id_fkey,
+    ADD CONSTRAINT fk_user_id FOREIGN KEY (user_id) REFERENCES users ("ID") ON DELETE CASCADE;
+
+---- create above / drop below ----
+
+DELETE FROM refresh_tokens;
+
+ALTER TABLE refresh_tokens
+    DROP CONSTRAINT IF EXISTS fk_user_id,
+    ADD CONSTRAINT refresh_tokens_user_id_fkey FOREIGN KEY (user_id) REFERENCES users ("ID");
+
+ALTER TABLE refresh_tokens
+    DROP CONSTRAINT IF EXISTS uq_refresh_tokens_token_hash,
+    DROP COLUMN IF EXISTS token_hash,
+    ADD COLUMN IF NOT EXISTS token character varying(512) NOT NULL;
+
+CREATE INDEX IF NOT EXISTS idx_refresh_tokens_token ON refresh_tokens (token);
@@ -0,0 +1,19 @@
+-- A session is a refresh token family. Each token records the client that obtained it,
+-- so that users can tell their sessions apart and revoke the ones they do not recognize.
+ALTER TABLE refresh_tokens
+    ADD COLUMN IF NOT EXISTS user_agent character varying(512) NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS ip_address character varying(45) NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS device_label character varying(100) NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS last_used_at timestamp with time zone NOT NULL DEFAULT now();
+
+CREATE INDEX IF NOT EXISTS idx_refresh_tokens_user_id ON refresh_tokens (user_id);
+
+---- create above / drop below ----
+
+DROP INDEX IF EXISTS idx_refresh_tokens_user_id;
+
+ALTER TABLE refresh_tokens
+    DROP COLUMN IF EXISTS last_used_at,
+    DROP COLUMN IF EXISTS device_label,
+    DROP COLUMN IF EXISTS ip_address,
+    DROP COLUMN IF EXISTS user_agent;
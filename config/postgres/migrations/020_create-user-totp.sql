@@ -0,0 +1,32 @@
+-- Optional TOTP (RFC 6238) second factor. The row is created when enrollment starts and
+-- the factor is only enforced once confirmed_at is set. last_used_step keeps a code from
+-- being accepted twice, and failed_attempts locks the factor after repeated wrong codes.
+CREATE TABLE IF NOT EXISTS user_totp
+(
+    user_id uuid PRIMARY KEY NOT NULL,
+    secret character varying(64) NOT NULL,
+    confirmed_at timestamp with time zone,
+    last_used_step bigint NOT NULL DEFAULT 0,
+    failed_attempts integer NOT NULL DEFAULT 0,
+    locked_until timestamp with time zone,
+    created_at timestamp with time zone NOT NULL DEFAULT now(),
+    CONSTRAINT fk_user_id FOREIGN KEY (user_id) REFERENCES users ("ID") ON DELETE CASCADE
+);
+
+-- Single-use recovery codes, of which only the SHA-256 hash is stored. They go away
+-- with the factor they recover.
+CREATE TABLE IF NOT EXISTS totp_recovery_codes
+(
+    "ID" uuid PRIMARY KEY NOT NULL DEFAULT gen_random_uuid(),
+    user_id uuid NOT NULL,
+    code_hash char(64) NOT NULL,
+    used_at timestamp with time zone,
+    created_at timestamp with time zone NOT NULL DEFAULT now(),
+    CONSTRAINT fk_user_totp FOREIGN KEY (user_id) REFERENCES user_totp (user_id) ON DELETE CASCADE,
+    CONSTRAINT uq_totp_recovery_codes_code_hash UNIQUE (user_id, code_hash)
+);
+
+---- create above / drop below ----
+
+DROP TABLE IF EXISTS totp_recovery_codes;
+DROP TABLE IF EXISTS user_totp;
@@ -0,0 +1,12 @@
+-- The last schedule slot each background job completed. Replicas whose timers fire a
+-- few seconds apart skip a slot another instance already ran.
+CREATE TABLE IF NOT EXISTS scheduler_runs
+(
+    job_name character varying(100) PRIMARY KEY NOT NULL,
+    last_slot timestamp with time zone NOT NULL,
+    finished_at timestamp with time zone NOT NULL DEFAULT now()
+);
+
+---- create above / drop below ----
+
+DROP TABLE IF EXISTS scheduler_runs;
@@ -0,0 +1,13 @@
+-- The last day a recurring expense template was generated through. Generation only adds
+-- occurrences after it, so occurrences the user deleted or moved are not generated again.
+ALTER TABLE recurring_expense
+    ADD COLUMN IF NOT EXISTS generated_through date;
+
+UPDATE recurring_expense t
+SET generated_through = (SELECT max(o.date) FROM recurring_expense o WHERE o.template_id = t."ID")
+WHERE t.template_id IS NULL;
+
+---- create above / drop below ----
+
+ALTER TABLE recurring_expense
+    DROP COLUMN IF EXISTS generated_through;
@@ -0,0 +1,16 @@
+-- The IDs (jti) of the MFA tokens that completed a login, so that each token completes
+-- only one. A row is only needed until its token expires.
+CREATE TABLE IF NOT EXISTS used_mfa_tokens
+(
+    token_id character varying(64) PRIMARY KEY NOT NULL,
+    user_id uuid NOT NULL,
+    expires_at timestamp with time zone NOT NULL,
+    created_at timestamp with time zone NOT NULL DEFAULT now(),
+    CONSTRAINT fk_used_mfa_tokens_user_id FOREIGN KEY (user_id) REFERENCES users ("ID") ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_used_mfa_tokens_user_id_expires_at ON used_mfa_tokens (user_id, expires_at);
+
+---- create above / drop below ----
+
+DROP TABLE IF EXISTS used_mfa_tokens;
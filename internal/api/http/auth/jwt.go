@@ -1,6 +1,7 @@
 package auth
 
 import (
+	"context"
 	"fmt"
 	"github.com/golang-jwt/jwt/v5"
 	"github.com/google/uuid"
@@ -11,13 +12,32 @@ import (
 	"time"
 )
 
-func GenerateAccessToken(userID uuid.UUID) (string, error) {
+// AccessTokenTTL is how long an access token is accepted after it is issued.
+const AccessTokenTTL = 60 * time.Minute
+
+// MFATokenTTL is how long a login waiting for its two-factor code can be completed.
+const MFATokenTTL = 5 * time.Minute
+
+// Every token carries its type in the "typ" claim, so that a token of one type is never
+// accepted as another, such as an MFA token as an access token.
+const (
+	accessTokenType  = "access"
+	refreshTokenType = "refresh"
+	mfaTokenType     = "mfa_pending"
+)
+
+// GenerateAccessToken issues an access token of the session, which is the family of
+// refresh tokens it was issued with. It is only accepted while the session is active.
+func GenerateAccessToken(userID uuid.UUID, sessionID uuid.UUID) (string, error) {
 	secret := os.Getenv("JWT_SECRET")
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
 		"iss":     "my-budget-planner",
 		"sub":     userID.String(),
 		"user_id": userID.String(),
-		"exp":     time.Now().Add(time.Minute * 60).Unix(),
+		"sid":     sessionID.String(),
+		"typ":     accessTokenType,
+		"iat":     time.Now().Unix(),
+		"exp":     time.Now().Add(AccessTokenTTL).Unix(),
 	})
 	accessTokenString, err := token.SignedString([]byte(secret))
 	if err != nil {
@@ -32,6 +52,9 @@ func GenerateRefreshToken(userID uuid.UUID) (string, error) {
 		"iss":     "my-budget-planner",
 		"sub":     userID.String(),
 		"user_id": userID.String(),
+		"typ":     refreshTokenType,
+		"jti":     uuid.NewString(), // tokens issued in the same second must still differ
+		"iat":     time.Now().Unix(),
 		"exp":     time.Now().Add(7 * 24 * time.Hour).Unix(), // 7 days expiration
 	})
 	refreshTokenString, err := refreshToken.SignedString([]byte(secret))
@@ -41,13 +64,137 @@ func GenerateRefreshToken(userID uuid.UUID) (string, error) {
 	return refreshTokenString, nil
 }
 
-func JWTMiddleware() echo.MiddlewareFunc {
-	return echojwt.WithConfig(echojwt.Config{
+// GenerateMFAToken issues the token a login returns instead of the token pair when the
+// user has two-factor authentication on. It only grants exchanging it, together with a
+// valid code, for the token pair.
+func GenerateMFAToken(userID uuid.UUID) (string, error) {
+	secret := os.Getenv("JWT_SECRET")
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		"iss": "my-budget-planner",
+		"sub": userID.String(),
+		"typ": mfaTokenType,
+		"jti": uuid.NewString(),
+		"iat": time.Now().Unix(),
+		"exp": time.Now().Add(MFATokenTTL).Unix(),
+	})
+	return token.SignedString([]byte(secret))
+}
+
+// ParseRefreshToken verifies the signature and expiry of a refresh token and returns the
+// user it was issued to. It does not check whether the token was revoked.
+func ParseRefreshToken(refreshToken string) (uuid.UUID, error) {
+	userID, _, err := parseToken(refreshToken, refreshTokenType)
+	return userID, err
+}
+
+// ParseMFAToken verifies an MFA token and returns the user whose login it completes,
+// the ID of the token and when it expires, so that it can be used only once.
+func ParseMFAToken(mfaToken string) (uuid.UUID, string, time.Time, error) {
+	userID, claims, err := parseToken(mfaToken, mfaTokenType)
+	if err != nil {
+		return uuid.Nil, "", time.Time{}, err
+	}
+	tokenID, _ := claims["jti"].(string)
+	if tokenID == "" {
+		return uuid.Nil, "", time.Time{}, fmt.Errorf("token has no jti claim")
+	}
+	expiresAt, err := claims.GetExpirationTime()
+	if err != nil || expiresAt == nil {
+		return uuid.Nil, "", time.Time{}, fmt.Errorf("token has no exp claim")
+	}
+	return userID, tokenID, expiresAt.Time, nil
+}
+
+// parseToken verifies the signature, issuer and expiry of the token, checks that its type
+// is one of types, and returns its subject and claims.
+func parseToken(tokenString string, types ...string) (uuid.UUID, jwt.MapClaims, error) {
+	token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
+		return []byte(os.Getenv("JWT_SECRET")), nil
+	}, jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}), jwt.WithIssuer("my-budget-planner"))
+	if err != nil {
+		return uuid.Nil, nil, err
+	}
+	if !hasTokenType(token, types...) {
+		return uuid.Nil, nil, fmt.Errorf("unexpected token type")
+	}
+	subject, err := token.Claims.GetSubject()
+	if err != nil {
+		return uuid.Nil, nil, err
+	}
+	userID, err := uuid.Parse(subject)
+	if err != nil {
+		return uuid.Nil, nil, err
+	}
+	claims, _ := token.Claims.(jwt.MapClaims)
+	return userID, claims, nil
+}
+
+// hasTokenType reports whether the "typ" claim of the token is one of types. Tokens
+// without the claim are of no type, so they are always rejected.
+func hasTokenType(token *jwt.Token, types ...string) bool {
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok {
+		return false
+	}
+	typ, _ := claims["typ"].(string)
+	if typ == "" {
+		return false
+	}
+	for _, t := range types {
+		if typ == t {
+			return true
+		}
+	}
+	return false
+}
+
+// AccessTokenValidator decides whether an access token with a valid signature is still
+// accepted, so that tokens can be revoked before they expire.
+type AccessTokenValidator interface {
+	ValidateAccessToken(ctx context.Context, userId uuid.UUID, sessionId uuid.UUID, issuedAt time.Time) error
+}
+
+func JWTMiddleware(validator AccessTokenValidator) echo.MiddlewareFunc {
+	denied := func(c echo.Context) error {
+		return c.JSON(http.StatusUnauthorized, map[string]string{"message": "Access Denied: authenticate to get access to this functionality"})
+	}
+	verify := echojwt.WithConfig(echojwt.Config{
 		SigningKey: []byte(os.Getenv("JWT_SECRET")),
 		ErrorHandler: func(c echo.Context, err error) error {
-			return c.JSON(http.StatusUnauthorized, map[string]string{"message": "Access Denied: authenticate to get access to this functionality"})
+			return denied(c)
 		},
 	})
+
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return verify(func(c echo.Context) error {
+			token, ok := c.Get("user").(*jwt.Token)
+			if !ok || !hasTokenType(token, accessTokenType) {
+				return denied(c)
+			}
+			userIDStr, err := token.Claims.GetSubject()
+			if err != nil {
+				return denied(c)
+			}
+			userID, err := uuid.Parse(userIDStr)
+			if err != nil {
+				return denied(c)
+			}
+			claims, _ := token.Claims.(jwt.MapClaims)
+			sessionIDStr, _ := claims["sid"].(string)
+			sessionID, err := uuid.Parse(sessionIDStr)
+			if err != nil {
+				return denied(c)
+			}
+			var issuedAt time.Time
+			if iat, err := token.Claims.GetIssuedAt(); err == nil && iat != nil {
+				issuedAt = iat.Time
+			}
+			if err := validator.ValidateAccessToken(c.Request().Context(), userID, sessionID, issuedAt); err != nil {
+				return denied(c)
+			}
+			return next(c)
+		})
+	}
 }
 
 func ExtractUserIDMiddleware(next echo.HandlerFunc) echo.HandlerFunc {
@@ -3,7 +3,9 @@ package handlers
 import (
 	"github.com/google/uuid"
 	"github.com/labstack/echo/v4"
+	"github.com/misalima/my-budget-planner-backend/internal/api/http/auth"
 	"github.com/misalima/my-budget-planner-backend/internal/api/http/handlers/dto"
+	"github.com/misalima/my-budget-planner-backend/internal/core/domain"
 	"github.com/misalima/my-budget-planner-backend/internal/core/interfaces/iservice"
 	"net/http"
 )
@@ -13,8 +15,9 @@ type AuthHandler struct {
 }
 
 type LoginRequest struct {
-	Email    string `json:"email"`
-	Password string `json:"password"`
+	Email       string `json:"email"`
+	Password    string `json:"password"`
+	DeviceLabel string `json:"device_label"` // opcional, nome do dispositivo exibido em GET /sessions
 }
 
 func NewAuthHandler(authService iservice.AuthManager) *AuthHandler {
@@ -23,15 +26,15 @@ func NewAuthHandler(authService iservice.AuthManager) *AuthHandler {
 
 // RefreshTokenHandler godoc
 // @Summary Atualiza o token de acesso (refresh token)
+// @Description Não exige token de acesso: o usuário é o do refresh token informado. Cada refresh devolve também um novo refresh token e invalida o informado. Reapresentar um refresh token já usado revoga toda a família de tokens da sessão.
 // @Tags Auth
 // @Accept json
 // @Produce json
-// @Security bearerAuth
 // @Param refreshToken body dto.RefreshTokenDTO true "Token de atualização" example({"token":"<refresh_token_aqui>"})
-// @Success 200 {object} map[string]string
+// @Success 200 {object} dto.TokenResponseDTO
 // @Failure 400 {object} map[string]string
 // @Failure 401 {object} map[string]string
-// @Router /auth/refresh [get]
+// @Router /auth/refresh [post]
 func (a *AuthHandler) RefreshTokenHandler(ctx echo.Context) error {
 	var req dto.RefreshTokenDTO
 
@@ -45,29 +48,30 @@ func (a *AuthHandler) RefreshTokenHandler(ctx echo.Context) error {
 		return ctx.JSON(http.StatusBadRequest, map[string]string{"error": "Token is required"})
 	}
 
-	//extract user id data from the jwt token
-	userId, ok := ctx.Get("user_id").(uuid.UUID)
-	if !ok || userId == uuid.Nil {
-		return ctx.JSON(http.StatusUnauthorized, map[string]string{"error": "Invalid user"})
-	}
-
 	//call the service
-	accessToken, err := a.AuthService.RefreshToken(userId, req.Token)
+	accessToken, refreshToken, err := a.AuthService.RefreshToken(req.Token, sessionClient(ctx, ""))
 	if err != nil {
 		return ctx.JSON(http.StatusUnauthorized, map[string]string{"error": err.Error()})
 	}
 
-	//handle the response
-	return ctx.JSON(http.StatusOK, map[string]string{"access_token": accessToken})
+	//handle the response, which must not be cached
+	ctx.Response().Header().Set(echo.HeaderCacheControl, "no-store")
+	return ctx.JSON(http.StatusOK, dto.TokenResponseDTO{
+		AccessToken:  accessToken,
+		TokenType:    "Bearer",
+		ExpiresIn:    int(auth.AccessTokenTTL.Seconds()),
+		RefreshToken: refreshToken,
+	})
 }
 
 // Login godoc
 // @Summary Realiza login do usuário
+// @Description Com a autenticação em dois fatores ativa, a resposta traz mfa_required e um mfa_token em vez dos tokens; ele deve ser trocado, junto com um código, em POST /auth/mfa.
 // @Tags Auth
 // @Accept json
 // @Produce json
 // @Param credentials body LoginRequest true "Credenciais de login" default({"email":"misael@gmail.com","password":"Misael123@"})
-// @Success 200 {object} map[string]string
+// @Success 200 {object} domain.LoginResult
 // @Failure 400 {object} map[string]string
 // @Failure 401 {object} map[string]string
 // @Router /auth/login [post]
@@ -82,10 +86,120 @@ func (a *AuthHandler) Login(ctx echo.Context) error {
 		return ctx.JSON(http.StatusBadRequest, map[string]string{"error": "Email and password are required"})
 	}
 
-	accessToken, refreshToken, err := a.AuthService.Login(req.Email, req.Password)
+	result, err := a.AuthService.Login(req.Email, req.Password, sessionClient(ctx, req.DeviceLabel))
 	if err != nil {
 		return ctx.JSON(http.StatusUnauthorized, map[string]string{"error": err.Error()})
 	}
 
-	return ctx.JSON(http.StatusOK, map[string]string{"access_token": accessToken, "refresh_token": refreshToken})
+	return ctx.JSON(http.StatusOK, result)
+}
+
+// Logout godoc
+// @Summary Encerra a sessão revogando o refresh token informado
+// @Tags Auth
+// @Accept json
+// @Param refreshToken body dto.RefreshTokenDTO true "Refresh token a revogar"
+// @Success 204
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /auth/logout [post]
+func (a *AuthHandler) Logout(ctx echo.Context) error {
+	var req dto.RefreshTokenDTO
+
+	if err := ctx.Bind(&req); err != nil {
+		return ctx.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid input"})
+	}
+	if req.Token == "" {
+		return ctx.JSON(http.StatusBadRequest, map[string]string{"error": "Token is required"})
+	}
+
+	if err := a.AuthService.DeleteRefreshToken(req.Token); err != nil {
+		return ctx.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+
+	return ctx.NoContent(http.StatusNoContent)
+}
+
+// LogoutAll godoc
+// @Summary Encerra todas as sessões do usuário
+// @Description Apaga todos os refresh tokens do usuário e invalida os tokens de acesso emitidos até este momento.
+// @Tags Auth
+// @Security bearerAuth
+// @Success 204
+// @Failure 401 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /auth/logout-all [post]
+func (a *AuthHandler) LogoutAll(ctx echo.Context) error {
+	userId, ok := ctx.Get("user_id").(uuid.UUID)
+	if !ok || userId == uuid.Nil {
+		return ctx.JSON(http.StatusUnauthorized, map[string]string{"error": "Invalid user"})
+	}
+
+	if err := a.AuthService.LogoutAll(ctx.Request().Context(), userId); err != nil {
+		return ctx.JSON(statusFromError(err), map[string]string{"error": err.Error()})
+	}
+
+	return ctx.NoContent(http.StatusNoContent)
+}
+
+// ListSessions godoc
+// @Summary Lista as sessões ativas do usuário, uma por dispositivo em que ele fez login
+// @Description Cada sessão informa o rótulo do dispositivo, o user agent e o IP do último login ou refresh.
+// @Tags Auth
+// @Produce json
+// @Security bearerAuth
+// @Success 200 {array} domain.Session
+// @Failure 401 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /sessions [get]
+func (a *AuthHandler) ListSessions(ctx echo.Context) error {
+	userId, ok := ctx.Get("user_id").(uuid.UUID)
+	if !ok || userId == uuid.Nil {
+		return ctx.JSON(http.StatusUnauthorized, map[string]string{"error": "Invalid user"})
+	}
+
+	sessions, err := a.AuthService.ListSessions(ctx.Request().Context(), userId)
+	if err != nil {
+		return ctx.JSON(statusFromError(err), map[string]string{"error": err.Error()})
+	}
+
+	return ctx.JSON(http.StatusOK, sessions)
+}
+
+// RevokeSession godoc
+// @Summary Encerra uma sessão do usuário, como a de um dispositivo perdido
+// @Description Os refresh tokens da sessão e os tokens de acesso já emitidos para ela deixam de funcionar imediatamente.
+// @Tags Auth
+// @Security bearerAuth
+// @Param id path string true "ID da sessão"
+// @Success 204
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /sessions/{id} [delete]
+func (a *AuthHandler) RevokeSession(ctx echo.Context) error {
+	userId, ok := ctx.Get("user_id").(uuid.UUID)
+	if !ok || userId == uuid.Nil {
+		return ctx.JSON(http.StatusUnauthorized, map[string]string{"error": "Invalid user"})
+	}
+	sessionId, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		return ctx.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid session ID"})
+	}
+
+	if err := a.AuthService.RevokeSession(ctx.Request().Context(), userId, sessionId); err != nil {
+		return ctx.JSON(statusFromError(err), map[string]string{"error": err.Error()})
+	}
+
+	return ctx.NoContent(http.StatusNoContent)
+}
+
+func sessionClient(ctx echo.Context, deviceLabel string) domain.SessionClient {
+	return domain.SessionClient{
+		UserAgent:   ctx.Request().UserAgent(),
+		IPAddress:   ctx.RealIP(),
+		DeviceLabel: deviceLabel,
+	}
 }
@@ -0,0 +1,279 @@
+package handlers
+
+import (
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/misalima/my-budget-planner-backend/internal/api/http/handlers/dto"
+	"github.com/misalima/my-budget-planner-backend/internal/core/interfaces/iservice"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+type BudgetHandler struct {
+	svc iservice.BudgetManager
+}
+
+func NewBudgetHandler(svc iservice.BudgetManager) *BudgetHandler {
+	return &BudgetHandler{svc: svc}
+}
+
+// CreateBudget godoc
+// @Summary Cria um novo orçamento
+// @Tags Budget
+// @Accept json
+// @Produce json
+// @Security bearerAuth
+// @Param budget body dto.BudgetDTO true "Dados do orçamento"
+// @Success 201 {object} domain.Budget
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /budgets [post]
+func (h *BudgetHandler) CreateBudget(ctx echo.Context) error {
+	var dtoReq dto.BudgetDTO
+	if err := ctx.Bind(&dtoReq); err != nil {
+		return ctx.JSON(http.StatusBadRequest, map[string]string{"error": "invalid request data"})
+	}
+	if dtoReq.Name == "" {
+		return ctx.JSON(http.StatusBadRequest, map[string]string{"error": "budget name is required"})
+	}
+	userID, ok := ctx.Get("user_id").(uuid.UUID)
+	if !ok || userID == uuid.Nil {
+		return ctx.JSON(http.StatusUnauthorized, map[string]string{"error": "invalid user id from token"})
+	}
+	budget, err := dtoReq.ToDomain(userID)
+	if err != nil {
+		return ctx.JSON(http.StatusBadRequest, map[string]string{"error": "invalid request data"})
+	}
+	created, err := h.svc.CreateBudget(ctx.Request().Context(), budget)
+	if err != nil {
+		return ctx.JSON(statusFromError(err), map[string]string{"error": err.Error()})
+	}
+	return ctx.JSON(http.StatusCreated, created)
+}
+
+// GetBudgetByID godoc
+// @Summary Busca um orçamento por ID
+// @Tags Budget
+// @Produce json
+// @Security bearerAuth
+// @Param id path int true "ID do orçamento"
+// @Success 200 {object} domain.Budget
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /budgets/{id} [get]
+func (h *BudgetHandler) GetBudgetByID(ctx echo.Context) error {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		return ctx.JSON(http.StatusBadRequest, map[string]string{"error": "invalid budget id"})
+	}
+	userID, ok := ctx.Get("user_id").(uuid.UUID)
+	if !ok || userID == uuid.Nil {
+		return ctx.JSON(http.StatusUnauthorized, map[string]string{"error": "invalid user id from token"})
+	}
+	budget, err := h.svc.GetBudgetByID(ctx.Request().Context(), id, userID)
+	if err != nil {
+		return ctx.JSON(statusFromError(err), map[string]string{"error": err.Error()})
+	}
+	return ctx.JSON(http.StatusOK, budget)
+}
+
+// ListBudgets godoc
+// @Summary Lista os orçamentos do usuário
+// @Tags Budget
+// @Produce json
+// @Security bearerAuth
+// @Success 200 {array} domain.Budget
+// @Failure 401 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /budgets [get]
+func (h *BudgetHandler) ListBudgets(ctx echo.Context) error {
+	userID, ok := ctx.Get("user_id").(uuid.UUID)
+	if !ok || userID == uuid.Nil {
+		return ctx.JSON(http.StatusUnauthorized, map[string]string{"error": "invalid user id from token"})
+	}
+	budgets, err := h.svc.ListBudgets(ctx.Request().Context(), userID)
+	if err != nil {
+		return ctx.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+	return ctx.JSON(http.StatusOK, budgets)
+}
+
+// UpdateBudget godoc
+// @Summary Atualiza um orçamento
+// @Tags Budget
+// @Accept json
+// @Produce json
+// @Security bearerAuth
+// @Param id path int true "ID do orçamento"
+// @Param budget body dto.BudgetUpdateDTO true "Dados do orçamento para atualização"
+// @Success 200 {object} domain.Budget
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /budgets/{id} [put]
+func (h *BudgetHandler) UpdateBudget(ctx echo.Context) error {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		return ctx.JSON(http.StatusBadRequest, map[string]string{"error": "invalid budget id"})
+	}
+	var dtoReq dto.BudgetUpdateDTO
+	if err := ctx.Bind(&dtoReq); err != nil {
+		return ctx.JSON(http.StatusBadRequest, map[string]string{"error": "invalid request data"})
+	}
+	userID, ok := ctx.Get("user_id").(uuid.UUID)
+	if !ok || userID == uuid.Nil {
+		return ctx.JSON(http.StatusUnauthorized, map[string]string{"error": "invalid user id from token"})
+	}
+	budget, err := h.svc.GetBudgetByID(ctx.Request().Context(), id, userID)
+	if err != nil {
+		return ctx.JSON(statusFromError(err), map[string]string{"error": err.Error()})
+	}
+	if err := dtoReq.ApplyTo(&budget); err != nil {
+		return ctx.JSON(http.StatusBadRequest, map[string]string{"error": "invalid request data"})
+	}
+	updated, err := h.svc.UpdateBudget(ctx.Request().Context(), budget)
+	if err != nil {
+		return ctx.JSON(statusFromError(err), map[string]string{"error": err.Error()})
+	}
+	return ctx.JSON(http.StatusOK, updated)
+}
+
+// DeleteBudget godoc
+// @Summary Remove um orçamento
+// @Tags Budget
+// @Produce json
+// @Security bearerAuth
+// @Param id path int true "ID do orçamento"
+// @Success 204 {object} nil
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /budgets/{id} [delete]
+func (h *BudgetHandler) DeleteBudget(ctx echo.Context) error {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		return ctx.JSON(http.StatusBadRequest, map[string]string{"error": "invalid budget id"})
+	}
+	userID, ok := ctx.Get("user_id").(uuid.UUID)
+	if !ok || userID == uuid.Nil {
+		return ctx.JSON(http.StatusUnauthorized, map[string]string{"error": "invalid user id from token"})
+	}
+	if err := h.svc.DeleteBudget(ctx.Request().Context(), id, userID); err != nil {
+		return ctx.JSON(statusFromError(err), map[string]string{"error": err.Error()})
+	}
+	return ctx.NoContent(http.StatusNoContent)
+}
+
+// GetBudgetConsumption godoc
+// @Summary Consumo de um orçamento no período que contém a data informada
+// @Tags Budget
+// @Produce json
+// @Security bearerAuth
+// @Param id path int true "ID do orçamento"
+// @Param date query string false "Data de referência (YYYY-MM-DD), padrão hoje"
+// @Success 200 {object} domain.BudgetConsumption
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 422 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /budgets/{id}/consumption [get]
+func (h *BudgetHandler) GetBudgetConsumption(ctx echo.Context) error {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		return ctx.JSON(http.StatusBadRequest, map[string]string{"error": "invalid budget id"})
+	}
+	userID, ok := ctx.Get("user_id").(uuid.UUID)
+	if !ok || userID == uuid.Nil {
+		return ctx.JSON(http.StatusUnauthorized, map[string]string{"error": "invalid user id from token"})
+	}
+	date, err := referenceDate(ctx.QueryParam("date"))
+	if err != nil {
+		return ctx.JSON(http.StatusBadRequest, map[string]string{"error": "invalid date"})
+	}
+	consumption, err := h.svc.GetBudgetConsumption(ctx.Request().Context(), id, userID, date)
+	if err != nil {
+		return ctx.JSON(statusFromError(err), map[string]string{"error": err.Error()})
+	}
+	return ctx.JSON(http.StatusOK, consumption)
+}
+
+// ListBudgetConsumptions godoc
+// @Summary Consumo dos orçamentos do usuário ativos na data informada
+// @Tags Budget
+// @Produce json
+// @Security bearerAuth
+// @Param date query string false "Data de referência (YYYY-MM-DD), padrão hoje"
+// @Success 200 {array} domain.BudgetConsumption
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 422 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /budgets/consumption [get]
+func (h *BudgetHandler) ListBudgetConsumptions(ctx echo.Context) error {
+	userID, ok := ctx.Get("user_id").(uuid.UUID)
+	if !ok || userID == uuid.Nil {
+		return ctx.JSON(http.StatusUnauthorized, map[string]string{"error": "invalid user id from token"})
+	}
+	date, err := referenceDate(ctx.QueryParam("date"))
+	if err != nil {
+		return ctx.JSON(http.StatusBadRequest, map[string]string{"error": "invalid date"})
+	}
+	consumptions, err := h.svc.ListBudgetConsumptions(ctx.Request().Context(), userID, date)
+	if err != nil {
+		return ctx.JSON(statusFromError(err), map[string]string{"error": err.Error()})
+	}
+	return ctx.JSON(http.StatusOK, consumptions)
+}
+
+// ListBudgetPeriods godoc
+// @Summary Consumo por período de um orçamento, incluindo saldo acumulado (rollover)
+// @Tags Budget
+// @Produce json
+// @Security bearerAuth
+// @Param id path int true "ID do orçamento"
+// @Param start_date query string true "Data inicial (YYYY-MM-DD)"
+// @Param end_date query string true "Data final (YYYY-MM-DD)"
+// @Success 200 {array} domain.BudgetConsumption
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 422 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /budgets/{id}/periods [get]
+func (h *BudgetHandler) ListBudgetPeriods(ctx echo.Context) error {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		return ctx.JSON(http.StatusBadRequest, map[string]string{"error": "invalid budget id"})
+	}
+	userID, ok := ctx.Get("user_id").(uuid.UUID)
+	if !ok || userID == uuid.Nil {
+		return ctx.JSON(http.StatusUnauthorized, map[string]string{"error": "invalid user id from token"})
+	}
+	startDate, err := time.Parse("2006-01-02", ctx.QueryParam("start_date"))
+	if err != nil {
+		return ctx.JSON(http.StatusBadRequest, map[string]string{"error": "invalid start date"})
+	}
+	endDate, err := time.Parse("2006-01-02", ctx.QueryParam("end_date"))
+	if err != nil {
+		return ctx.JSON(http.StatusBadRequest, map[string]string{"error": "invalid end date"})
+	}
+	periods, err := h.svc.ListBudgetPeriods(ctx.Request().Context(), id, userID, startDate, endDate)
+	if err != nil {
+		return ctx.JSON(statusFromError(err), map[string]string{"error": err.Error()})
+	}
+	return ctx.JSON(http.StatusOK, periods)
+}
+
+// referenceDate parses an optional YYYY-MM-DD query value, defaulting to today.
+func referenceDate(value string) (time.Time, error) {
+	if value == "" {
+		now := time.Now()
+		return time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC), nil
+	}
+	return time.Parse("2006-01-02", value)
+}
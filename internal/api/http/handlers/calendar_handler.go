@@ -0,0 +1,79 @@
+package handlers
+
+import (
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/misalima/my-budget-planner-backend/internal/core/interfaces/iservice"
+	"net/http"
+	"strings"
+)
+
+type CalendarHandler struct {
+	svc iservice.CalendarManager
+}
+
+func NewCalendarHandler(svc iservice.CalendarManager) *CalendarHandler {
+	return &CalendarHandler{svc: svc}
+}
+
+// CreateCalendarFeed godoc
+// @Summary Gera a URL do feed iCalendar do usuário, com vencimentos de cartões e despesas recorrentes
+// @Description A URL contém um token próprio, independente dos JWTs, e só é exibida nesta resposta. Gerar uma nova URL invalida a anterior.
+// @Tags Calendar
+// @Produce json
+// @Security bearerAuth
+// @Success 201 {object} domain.CalendarFeed
+// @Failure 401 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /calendar/feed [post]
+func (h *CalendarHandler) CreateCalendarFeed(ctx echo.Context) error {
+	userID, ok := ctx.Get("user_id").(uuid.UUID)
+	if !ok || userID == uuid.Nil {
+		return ctx.JSON(http.StatusUnauthorized, map[string]string{"error": "invalid user id from token"})
+	}
+	feed, err := h.svc.CreateCalendarFeed(ctx.Request().Context(), userID)
+	if err != nil {
+		return ctx.JSON(statusFromError(err), map[string]string{"error": err.Error()})
+	}
+	feed.URL = ctx.Scheme() + "://" + ctx.Request().Host + "/api/calendar/feed/" + feed.Token + ".ics"
+	return ctx.JSON(http.StatusCreated, feed)
+}
+
+// RevokeCalendarFeed godoc
+// @Summary Revoga a URL do feed iCalendar do usuário
+// @Tags Calendar
+// @Security bearerAuth
+// @Success 204
+// @Failure 401 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /calendar/feed [delete]
+func (h *CalendarHandler) RevokeCalendarFeed(ctx echo.Context) error {
+	userID, ok := ctx.Get("user_id").(uuid.UUID)
+	if !ok || userID == uuid.Nil {
+		return ctx.JSON(http.StatusUnauthorized, map[string]string{"error": "invalid user id from token"})
+	}
+	if err := h.svc.RevokeCalendarFeed(ctx.Request().Context(), userID); err != nil {
+		return ctx.JSON(statusFromError(err), map[string]string{"error": err.Error()})
+	}
+	return ctx.NoContent(http.StatusNoContent)
+}
+
+// GetCalendarFeed godoc
+// @Summary Feed iCalendar (.ics) com os vencimentos dos cartões e as ocorrências das despesas recorrentes
+// @Description Não usa JWT: o acesso é dado pelo token da URL gerada em POST /calendar/feed.
+// @Tags Calendar
+// @Produce text/calendar
+// @Param token path string true "Token do feed, com ou sem a extensão .ics"
+// @Success 200 {string} string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /calendar/feed/{token} [get]
+func (h *CalendarHandler) GetCalendarFeed(ctx echo.Context) error {
+	token := strings.TrimSuffix(ctx.Param("token"), ".ics")
+	feed, err := h.svc.RenderCalendarFeed(ctx.Request().Context(), token)
+	if err != nil {
+		return ctx.JSON(statusFromError(err), map[string]string{"error": err.Error()})
+	}
+	return ctx.Blob(http.StatusOK, "text/calendar; charset=utf-8", feed)
+}
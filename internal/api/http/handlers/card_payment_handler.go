@@ -0,0 +1,147 @@
+package handlers
+
+import (
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/misalima/my-budget-planner-backend/internal/api/http/handlers/dto"
+	"github.com/misalima/my-budget-planner-backend/internal/core/interfaces/iservice"
+	"net/http"
+)
+
+type CardPaymentHandler struct {
+	svc iservice.CardPaymentManager
+}
+
+func NewCardPaymentHandler(svc iservice.CardPaymentManager) *CardPaymentHandler {
+	return &CardPaymentHandler{svc: svc}
+}
+
+// CreateCardPayment godoc
+// @Summary Registra o pagamento, total ou parcial, de uma fatura do cartão
+// @Tags CardPayment
+// @Accept json
+// @Produce json
+// @Security bearerAuth
+// @Param id path string true "ID do cartão"
+// @Param payment body dto.CardPaymentDTO true "Dados do pagamento" example({"statement_month":"2025-03","amount":850.5,"paid_at":"2025-03-08"})
+// @Success 201 {object} domain.CardPayment
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /credit-cards/{id}/payments [post]
+func (h *CardPaymentHandler) CreateCardPayment(ctx echo.Context) error {
+	cardID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		return ctx.JSON(http.StatusBadRequest, map[string]string{"error": "invalid credit card id"})
+	}
+	userID, ok := ctx.Get("user_id").(uuid.UUID)
+	if !ok || userID == uuid.Nil {
+		return ctx.JSON(http.StatusUnauthorized, map[string]string{"error": "invalid user id from token"})
+	}
+	var dtoReq dto.CardPaymentDTO
+	if err := ctx.Bind(&dtoReq); err != nil {
+		return ctx.JSON(http.StatusBadRequest, map[string]string{"error": "invalid request data"})
+	}
+	payment, err := dtoReq.ToDomain(userID, cardID)
+	if err != nil {
+		return ctx.JSON(http.StatusBadRequest, map[string]string{"error": "invalid request data"})
+	}
+	created, err := h.svc.CreateCardPayment(ctx.Request().Context(), payment)
+	if err != nil {
+		return ctx.JSON(statusFromError(err), map[string]string{"error": err.Error()})
+	}
+	return ctx.JSON(http.StatusCreated, created)
+}
+
+// ListCardPayments godoc
+// @Summary Lista os pagamentos de faturas do cartão
+// @Tags CardPayment
+// @Produce json
+// @Security bearerAuth
+// @Param id path string true "ID do cartão"
+// @Success 200 {array} domain.CardPayment
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /credit-cards/{id}/payments [get]
+func (h *CardPaymentHandler) ListCardPayments(ctx echo.Context) error {
+	cardID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		return ctx.JSON(http.StatusBadRequest, map[string]string{"error": "invalid credit card id"})
+	}
+	userID, ok := ctx.Get("user_id").(uuid.UUID)
+	if !ok || userID == uuid.Nil {
+		return ctx.JSON(http.StatusUnauthorized, map[string]string{"error": "invalid user id from token"})
+	}
+	payments, err := h.svc.ListCardPayments(ctx.Request().Context(), cardID, userID)
+	if err != nil {
+		return ctx.JSON(statusFromError(err), map[string]string{"error": err.Error()})
+	}
+	return ctx.JSON(http.StatusOK, payments)
+}
+
+// GetCardPaymentByID godoc
+// @Summary Busca um pagamento de fatura por ID
+// @Tags CardPayment
+// @Produce json
+// @Security bearerAuth
+// @Param id path string true "ID do cartão"
+// @Param payment_id path string true "ID do pagamento"
+// @Success 200 {object} domain.CardPayment
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /credit-cards/{id}/payments/{payment_id} [get]
+func (h *CardPaymentHandler) GetCardPaymentByID(ctx echo.Context) error {
+	cardID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		return ctx.JSON(http.StatusBadRequest, map[string]string{"error": "invalid credit card id"})
+	}
+	id, err := uuid.Parse(ctx.Param("payment_id"))
+	if err != nil {
+		return ctx.JSON(http.StatusBadRequest, map[string]string{"error": "invalid payment id"})
+	}
+	userID, ok := ctx.Get("user_id").(uuid.UUID)
+	if !ok || userID == uuid.Nil {
+		return ctx.JSON(http.StatusUnauthorized, map[string]string{"error": "invalid user id from token"})
+	}
+	payment, err := h.svc.GetCardPaymentByID(ctx.Request().Context(), cardID, id, userID)
+	if err != nil {
+		return ctx.JSON(statusFromError(err), map[string]string{"error": err.Error()})
+	}
+	return ctx.JSON(http.StatusOK, payment)
+}
+
+// DeleteCardPayment godoc
+// @Summary Remove um pagamento de fatura
+// @Tags CardPayment
+// @Produce json
+// @Security bearerAuth
+// @Param id path string true "ID do cartão"
+// @Param payment_id path string true "ID do pagamento"
+// @Success 204 {object} nil
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /credit-cards/{id}/payments/{payment_id} [delete]
+func (h *CardPaymentHandler) DeleteCardPayment(ctx echo.Context) error {
+	cardID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		return ctx.JSON(http.StatusBadRequest, map[string]string{"error": "invalid credit card id"})
+	}
+	id, err := uuid.Parse(ctx.Param("payment_id"))
+	if err != nil {
+		return ctx.JSON(http.StatusBadRequest, map[string]string{"error": "invalid payment id"})
+	}
+	userID, ok := ctx.Get("user_id").(uuid.UUID)
+	if !ok || userID == uuid.Nil {
+		return ctx.JSON(http.StatusUnauthorized, map[string]string{"error": "invalid user id from token"})
+	}
+	if err := h.svc.DeleteCardPayment(ctx.Request().Context(), cardID, id, userID); err != nil {
+		return ctx.JSON(statusFromError(err), map[string]string{"error": err.Error()})
+	}
+	return ctx.NoContent(http.StatusNoContent)
+}
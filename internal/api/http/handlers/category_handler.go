@@ -1,9 +1,11 @@
 package handlers
 
 import (
+	"errors"
 	"github.com/google/uuid"
 	"github.com/labstack/echo/v4"
 	"github.com/misalima/my-budget-planner-backend/internal/api/http/handlers/dto"
+	"github.com/misalima/my-budget-planner-backend/internal/core/domain"
 	"github.com/misalima/my-budget-planner-backend/internal/core/interfaces/iservice"
 	"net/http"
 	"strconv"
@@ -23,7 +25,7 @@ func NewCategoryHandler(categoryService iservice.CategoryManager) *CategoryHandl
 // @Accept json
 // @Produce json
 // @Security bearerAuth
-// @Param category body dto.CreateCategoryDTO true "Dados da categoria" example({"name":"Alimentação"})
+// @Param category body dto.CreateCategoryDTO true "Dados da categoria" example({"name":"Alimentação","kind":"expense"})
 // @Success 201 {object} domain.Category
 // @Failure 400 {object} map[string]string
 // @Failure 500 {object} map[string]string
@@ -44,6 +46,9 @@ func (h *CategoryHandler) CreateCategory(ctx echo.Context) error {
 	category := req.ToDomain(userID)
 	err := h.categoryService.CreateCategory(category)
 	if err != nil {
+		if errors.Is(err, domain.ErrInvalidInput) {
+			return ctx.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+		}
 		ctx.Logger().Error(err)
 		return ctx.JSON(http.StatusInternalServerError, map[string]string{"error": "internal server error"})
 	}
@@ -55,6 +60,7 @@ func (h *CategoryHandler) CreateCategory(ctx echo.Context) error {
 // @Tags Category
 // @Produce json
 // @Security bearerAuth
+// @Param kind query string false "Tipo de categoria (expense ou income)"
 // @Success 200 {array} domain.Category
 // @Failure 500 {object} map[string]string
 // @Router /category [get]
@@ -68,6 +74,15 @@ func (h *CategoryHandler) GetCategoriesByUserID(ctx echo.Context) error {
 		ctx.Logger().Error(err)
 		return ctx.JSON(http.StatusInternalServerError, map[string]string{"error": "internal server error"})
 	}
+	if kind := ctx.QueryParam("kind"); kind != "" {
+		filtered := []domain.Category{}
+		for _, category := range categories {
+			if category.Kind == kind {
+				filtered = append(filtered, category)
+			}
+		}
+		categories = filtered
+	}
 	return ctx.JSON(http.StatusOK, categories)
 }
 
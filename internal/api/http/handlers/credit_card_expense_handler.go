@@ -29,6 +29,7 @@ func NewCreditCardExpenseHandler(svc iservice.CreditCardExpenseManager) *CreditC
 // @Success 201 {object} domain.CreditCardExpense
 // @Failure 400 {object} map[string]string
 // @Failure 401 {object} map[string]string
+// @Failure 404 {object} map[string]string
 // @Failure 500 {object} map[string]string
 // @Router /expenses/credit-card [post]
 func (h *CreditCardExpenseHandler) CreateCreditCardExpense(ctx echo.Context) error {
@@ -48,7 +49,7 @@ func (h *CreditCardExpenseHandler) CreateCreditCardExpense(ctx echo.Context) err
 	}
 	created, err := h.svc.CreateCreditCardExpense(ctx.Request().Context(), expense)
 	if err != nil {
-		return ctx.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+		return ctx.JSON(statusFromError(err), map[string]string{"error": err.Error()})
 	}
 	return ctx.JSON(http.StatusCreated, created)
 }
@@ -161,6 +162,7 @@ func (h *CreditCardExpenseHandler) ListCreditCardExpenses(ctx echo.Context) erro
 
 // UpdateCreditCardExpense godoc
 // @Summary Atualiza uma despesa de cartão de crédito
+// @Description Numa compra à vista, alterar amount altera também installment_amount. Os valores e a quantidade de parcelas de uma compra parcelada não podem ser alterados: a compra deve ser removida e registrada novamente.
 // @Tags CreditCardExpense
 // @Accept json
 // @Produce json
@@ -186,7 +188,7 @@ func (h *CreditCardExpenseHandler) UpdateCreditCardExpense(ctx echo.Context) err
 	}
 	updated, err := h.svc.UpdateCreditCardExpense(ctx.Request().Context(), expense)
 	if err != nil {
-		return ctx.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+		return ctx.JSON(statusFromError(err), map[string]string{"error": err.Error()})
 	}
 	return ctx.JSON(http.StatusOK, updated)
 }
@@ -225,7 +227,9 @@ func (h *CreditCardExpenseHandler) DeleteCreditCardExpense(ctx echo.Context) err
 // @Param start_date query string false "Data inicial (YYYY-MM-DD)"
 // @Param end_date query string false "Data final (YYYY-MM-DD)"
 // @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]string
 // @Failure 401 {object} map[string]string
+// @Failure 422 {object} map[string]string
 // @Router /expenses/credit-card/summary [get]
 func (h *CreditCardExpenseHandler) GetCreditCardExpenseSummary(ctx echo.Context) error {
 	userID, ok := ctx.Get("user_id").(uuid.UUID)
@@ -244,7 +248,7 @@ func (h *CreditCardExpenseHandler) GetCreditCardExpenseSummary(ctx echo.Context)
 	}
 	summary, err := h.svc.GetCreditCardExpenseSummary(ctx.Request().Context(), userID, startDate, endDate)
 	if err != nil {
-		return ctx.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+		return ctx.JSON(statusFromError(err), map[string]string{"error": err.Error()})
 	}
 	return ctx.JSON(http.StatusOK, summary)
 }
@@ -7,6 +7,7 @@ import (
 	"github.com/misalima/my-budget-planner-backend/internal/api/http/handlers/dto"
 	"github.com/misalima/my-budget-planner-backend/internal/core/interfaces/iservice"
 	"net/http"
+	"time"
 )
 
 type CreditCardHandler struct {
@@ -70,7 +71,7 @@ func (h *CreditCardHandler) GetCreditCardByID(c echo.Context) error {
 // @Accept json
 // @Produce json
 // @Security bearerAuth
-// @Param credit_card body dto.CreditCardDTO true "Dados do cartão de crédito" example({"card_name":"Nubank Platinum","total_limit":5000,"current_limit":5000,"due_date":10})
+// @Param credit_card body dto.CreditCardDTO true "Dados do cartão de crédito" example({"card_name":"Nubank Platinum","total_limit":5000,"current_limit":5000,"closing_day":3,"due_date":10})
 // @Success 201 {object} domain.CreditCard
 // @Failure 400 {object} map[string]string
 // @Failure 500 {object} map[string]string
@@ -88,7 +89,7 @@ func (h *CreditCardHandler) CreateCreditCard(c echo.Context) error {
 	}
 	cc := req.ToDomain(userID)
 	if err := h.service.Create(cc); err != nil {
-		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+		return c.JSON(statusFromError(err), map[string]string{"error": err.Error()})
 	}
 	return c.JSON(http.StatusCreated, cc)
 }
@@ -115,3 +116,68 @@ func (h *CreditCardHandler) DeleteCreditCard(c echo.Context) error {
 
 	return c.NoContent(http.StatusNoContent)
 }
+
+// GetStatement godoc
+// @Summary Busca a fatura do cartão com vencimento no mês informado
+// @Tags CreditCard
+// @Produce json
+// @Security bearerAuth
+// @Param id path string true "ID do cartão"
+// @Param month path string true "Mês de vencimento da fatura (YYYY-MM)"
+// @Success 200 {object} domain.CreditCardStatement
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /credit-cards/{id}/statements/{month} [get]
+func (h *CreditCardHandler) GetStatement(c echo.Context) error {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid credit card ID"})
+	}
+	month, err := time.Parse("2006-01", c.Param("month"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid statement month, expected YYYY-MM"})
+	}
+	userID, ok := c.Get("user_id").(uuid.UUID)
+	if !ok || userID == uuid.Nil {
+		return c.JSON(http.StatusUnauthorized, map[string]string{"error": "invalid user id from token"})
+	}
+
+	statement, err := h.service.GetStatement(c.Request().Context(), id, userID, month.Year(), month.Month())
+	if err != nil {
+		return c.JSON(statusFromError(err), map[string]string{"error": err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, statement)
+}
+
+// RecomputeLimit godoc
+// @Summary Recalcula o limite disponível do cartão a partir do histórico de parcelas
+// @Tags CreditCard
+// @Produce json
+// @Security bearerAuth
+// @Param id path string true "ID do cartão"
+// @Success 200 {object} domain.CreditCard
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /credit-cards/{id}/recompute-limit [post]
+func (h *CreditCardHandler) RecomputeLimit(c echo.Context) error {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid credit card ID"})
+	}
+	userID, ok := c.Get("user_id").(uuid.UUID)
+	if !ok || userID == uuid.Nil {
+		return c.JSON(http.StatusUnauthorized, map[string]string{"error": "invalid user id from token"})
+	}
+
+	card, err := h.service.RecomputeLimit(c.Request().Context(), id, userID)
+	if err != nil {
+		return c.JSON(statusFromError(err), map[string]string{"error": err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, card)
+}
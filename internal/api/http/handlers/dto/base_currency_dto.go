@@ -0,0 +1,6 @@
+package dto
+
+// BaseCurrencyDTO representa a moeda base do usuário, na qual resumos e relatórios são convertidos.
+type BaseCurrencyDTO struct {
+	BaseCurrency string `json:"base_currency"`
+}
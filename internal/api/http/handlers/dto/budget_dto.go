@@ -0,0 +1,99 @@
+package dto
+
+import (
+	"github.com/google/uuid"
+	"github.com/misalima/my-budget-planner-backend/internal/core/domain"
+	"time"
+)
+
+// BudgetDTO representa os dados necessários para criar um orçamento.
+type BudgetDTO struct {
+	Name        string       `json:"budget_name"`
+	Description string       `json:"description"`
+	Amount      domain.Money `json:"amount"`
+	Period      string       `json:"period"`
+	Rollover    bool         `json:"rollover"`
+	CategoryIDs []int        `json:"category_ids"`
+	StartDate   string       `json:"start_date"`
+	EndDate     string       `json:"end_date"`
+}
+
+// ToDomain converte o DTO para o domínio Budget.
+func (dto *BudgetDTO) ToDomain(userID uuid.UUID) (domain.Budget, error) {
+	startDate, err := time.Parse("2006-01-02", dto.StartDate)
+	if err != nil {
+		return domain.Budget{}, err
+	}
+	var endDate *time.Time
+	if dto.EndDate != "" {
+		parsed, err := time.Parse("2006-01-02", dto.EndDate)
+		if err != nil {
+			return domain.Budget{}, err
+		}
+		endDate = &parsed
+	}
+	return domain.Budget{
+		UserID:      userID,
+		Name:        dto.Name,
+		Description: &dto.Description,
+		Amount:      dto.Amount,
+		Period:      dto.Period,
+		Rollover:    dto.Rollover,
+		CategoryIDs: dto.CategoryIDs,
+		StartDate:   startDate,
+		EndDate:     endDate,
+	}, nil
+}
+
+// BudgetUpdateDTO representa os campos opcionais para atualizar um orçamento.
+type BudgetUpdateDTO struct {
+	Name        *string       `json:"budget_name,omitempty"`
+	Description *string       `json:"description,omitempty"`
+	Amount      *domain.Money `json:"amount,omitempty"`
+	Period      *string       `json:"period,omitempty"`
+	Rollover    *bool         `json:"rollover,omitempty"`
+	CategoryIDs *[]int        `json:"category_ids,omitempty"`
+	StartDate   *string       `json:"start_date,omitempty"`
+	EndDate     *string       `json:"end_date,omitempty"`
+}
+
+// ApplyTo aplica os campos informados sobre o orçamento atual.
+// Um end_date vazio remove a data final do orçamento.
+func (dto *BudgetUpdateDTO) ApplyTo(budget *domain.Budget) error {
+	if dto.StartDate != nil {
+		startDate, err := time.Parse("2006-01-02", *dto.StartDate)
+		if err != nil {
+			return err
+		}
+		budget.StartDate = startDate
+	}
+	if dto.EndDate != nil {
+		budget.EndDate = nil
+		if *dto.EndDate != "" {
+			endDate, err := time.Parse("2006-01-02", *dto.EndDate)
+			if err != nil {
+				return err
+			}
+			budget.EndDate = &endDate
+		}
+	}
+	if dto.Name != nil {
+		budget.Name = *dto.Name
+	}
+	if dto.Description != nil {
+		budget.Description = dto.Description
+	}
+	if dto.Amount != nil {
+		budget.Amount = *dto.Amount
+	}
+	if dto.Period != nil {
+		budget.Period = *dto.Period
+	}
+	if dto.Rollover != nil {
+		budget.Rollover = *dto.Rollover
+	}
+	if dto.CategoryIDs != nil {
+		budget.CategoryIDs = *dto.CategoryIDs
+	}
+	return nil
+}
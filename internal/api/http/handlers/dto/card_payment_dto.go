@@ -0,0 +1,41 @@
+package dto
+
+import (
+	"github.com/google/uuid"
+	"github.com/misalima/my-budget-planner-backend/internal/core/domain"
+	"time"
+)
+
+// CardPaymentDTO representa os dados necessários para registrar o pagamento de uma fatura.
+type CardPaymentDTO struct {
+	StatementMonth string       `json:"statement_month"`
+	Amount         domain.Money `json:"amount"`
+	PaidAt         string       `json:"paid_at"`
+	Description    *string      `json:"description"`
+}
+
+// ToDomain converte o DTO para o domínio CardPayment.
+// statement_month (YYYY-MM) e paid_at (YYYY-MM-DD) são opcionais.
+func (dto *CardPaymentDTO) ToDomain(userID uuid.UUID, cardID uuid.UUID) (domain.CardPayment, error) {
+	payment := domain.CardPayment{
+		UserID:      userID,
+		CardID:      cardID,
+		Amount:      dto.Amount,
+		Description: dto.Description,
+	}
+	if dto.StatementMonth != "" {
+		month, err := time.Parse("2006-01", dto.StatementMonth)
+		if err != nil {
+			return domain.CardPayment{}, err
+		}
+		payment.StatementMonth = month
+	}
+	if dto.PaidAt != "" {
+		paidAt, err := time.Parse("2006-01-02", dto.PaidAt)
+		if err != nil {
+			return domain.CardPayment{}, err
+		}
+		payment.PaidAt = paidAt
+	}
+	return payment, nil
+}
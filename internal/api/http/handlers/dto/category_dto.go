@@ -8,12 +8,14 @@ import (
 // CreateCategoryDTO representa os dados necessários para criar uma categoria.
 type CreateCategoryDTO struct {
 	Name string `json:"name"`
+	Kind string `json:"kind"`
 }
 
 // ToDomain converte o DTO para o domínio Category.
 func (dto *CreateCategoryDTO) ToDomain(userID uuid.UUID) *domain.Category {
 	return &domain.Category{
 		Name:   dto.Name,
+		Kind:   dto.Kind,
 		UserID: userID,
 	}
 }
@@ -2,9 +2,10 @@ package dto
 
 // CreateUserDTO representa os dados necessários para criar um novo usuário.
 type CreateUserDTO struct {
-	Username  string `json:"username"`
-	FirstName string `json:"first_name"`
-	LastName  string `json:"last_name"`
-	Email     string `json:"email"`
-	Password  string `json:"password"`
+	Username     string `json:"username"`
+	FirstName    string `json:"first_name"`
+	LastName     string `json:"last_name"`
+	Email        string `json:"email"`
+	Password     string `json:"password"`
+	BaseCurrency string `json:"base_currency"`
 }
@@ -7,10 +7,12 @@ import (
 
 // CreditCardDTO representa os dados necessários para criar um cartão de crédito.
 type CreditCardDTO struct {
-	CardName     string  `json:"card_name"`
-	TotalLimit   float64 `json:"total_limit"`
-	CurrentLimit float64 `json:"current_limit"`
-	DueDate      int     `json:"due_date"`
+	CardName     string       `json:"card_name"`
+	TotalLimit   domain.Money `json:"total_limit"`
+	CurrentLimit domain.Money `json:"current_limit"`
+	Currency     string       `json:"currency"`
+	ClosingDay   int          `json:"closing_day"`
+	DueDate      int          `json:"due_date"`
 }
 
 // ToDomain converte o DTO para o domínio CreditCard.
@@ -21,6 +23,8 @@ func (dto *CreditCardDTO) ToDomain(userID uuid.UUID) *domain.CreditCard {
 		CardName:     dto.CardName,
 		TotalLimit:   dto.TotalLimit,
 		CurrentLimit: dto.CurrentLimit,
+		Currency:     dto.Currency,
+		ClosingDay:   dto.ClosingDay,
 		DueDate:      dto.DueDate,
 	}
 }
@@ -7,14 +7,15 @@ import (
 )
 
 type CreditCardExpenseDTO struct {
-	UserID               string  `json:"user_id,omitempty"`
-	CategoryID           int     `json:"category_id"`
-	Amount               float64 `json:"amount"`
-	Description          string  `json:"description"`
-	Date                 string  `json:"date"`
-	CardID               string  `json:"card_id"`
-	InstallmentAmount    float64 `json:"installment_amount"`
-	InstallmentsQuantity int     `json:"installments_quantity"`
+	UserID               string       `json:"user_id,omitempty"`
+	CategoryID           int          `json:"category_id"`
+	Amount               domain.Money `json:"amount"`
+	Currency             string       `json:"currency"`
+	Description          string       `json:"description"`
+	Date                 string       `json:"date"`
+	CardID               string       `json:"card_id"`
+	InstallmentAmount    domain.Money `json:"installment_amount"`
+	InstallmentsQuantity int          `json:"installments_quantity"`
 }
 
 func (dto *CreditCardExpenseDTO) ToDomain() (domain.CreditCardExpense, error) {
@@ -34,6 +35,7 @@ func (dto *CreditCardExpenseDTO) ToDomain() (domain.CreditCardExpense, error) {
 		UserID:               userID,
 		CategoryID:           dto.CategoryID,
 		Amount:               dto.Amount,
+		Currency:             dto.Currency,
 		Description:          &dto.Description,
 		Date:                 date,
 		CardID:               cardID,
@@ -7,15 +7,16 @@ import (
 )
 
 type CreditCardExpenseUpdateDTO struct {
-	ID                   string   `json:"id"`
-	CategoryID           *int     `json:"category_id,omitempty"`
-	Amount               *float64 `json:"amount,omitempty"`
-	Description          *string  `json:"description,omitempty"`
-	Date                 *string  `json:"date,omitempty"`
-	CardID               *string  `json:"card_id,omitempty"`
-	InstallmentAmount    *float64 `json:"installment_amount,omitempty"`
-	InstallmentsQuantity *int     `json:"installments_quantity,omitempty"`
-	ParcelNumber         *int     `json:"parcel_number,omitempty"`
+	ID                   string        `json:"id"`
+	CategoryID           *int          `json:"category_id,omitempty"`
+	Amount               *domain.Money `json:"amount,omitempty"`
+	Currency             *string       `json:"currency,omitempty"`
+	Description          *string       `json:"description,omitempty"`
+	Date                 *string       `json:"date,omitempty"`
+	CardID               *string       `json:"card_id,omitempty"`
+	InstallmentAmount    *domain.Money `json:"installment_amount,omitempty"`
+	InstallmentsQuantity *int          `json:"installments_quantity,omitempty"`
+	ParcelNumber         *int          `json:"parcel_number,omitempty"`
 }
 
 func (dto *CreditCardExpenseUpdateDTO) ToDomain(userID uuid.UUID) (domain.CreditCardExpense, error) {
@@ -34,11 +35,12 @@ func (dto *CreditCardExpenseUpdateDTO) ToDomain(userID uuid.UUID) (domain.Credit
 		ID:                   id,
 		UserID:               userID,
 		CategoryID:           GetIntValue(dto.CategoryID),
-		Amount:               GetFloatValue(dto.Amount),
+		Amount:               GetMoneyValue(dto.Amount),
+		Currency:             GetStringValue(dto.Currency),
 		Description:          dto.Description,
 		Date:                 date,
 		CardID:               GetUUIDValue(dto.CardID),
-		InstallmentAmount:    GetFloatValue(dto.InstallmentAmount),
+		InstallmentAmount:    GetMoneyValue(dto.InstallmentAmount),
 		InstallmentsQuantity: GetIntValue(dto.InstallmentsQuantity),
 		ParcelNumber:         GetIntValue(dto.ParcelNumber),
 	}, nil
@@ -2,6 +2,7 @@ package dto
 
 import (
 	"github.com/google/uuid"
+	"github.com/misalima/my-budget-planner-backend/internal/core/domain"
 )
 
 func GetIntValue(ptr *int) int {
@@ -18,6 +19,13 @@ func GetFloatValue(ptr *float64) float64 {
 	return 0
 }
 
+func GetMoneyValue(ptr *domain.Money) domain.Money {
+	if ptr != nil {
+		return *ptr
+	}
+	return 0
+}
+
 func GetUUIDValue(ptr *string) uuid.UUID {
 	if ptr == nil || *ptr == "" {
 		return uuid.Nil
@@ -28,3 +36,10 @@ func GetUUIDValue(ptr *string) uuid.UUID {
 	}
 	return u
 }
+
+func GetStringValue(ptr *string) string {
+	if ptr != nil {
+		return *ptr
+	}
+	return ""
+}
@@ -0,0 +1,99 @@
+package dto
+
+import (
+	"github.com/google/uuid"
+	"github.com/misalima/my-budget-planner-backend/internal/core/domain"
+	"time"
+)
+
+// IncomeDTO representa os dados necessários para registrar uma receita.
+// Sem frequency a receita é única; com frequency ela se repete a partir de date até end_date.
+// Sem currency, a receita é registrada na moeda base do usuário.
+type IncomeDTO struct {
+	CategoryID  int          `json:"category_id"`
+	Amount      domain.Money `json:"amount"`
+	Currency    string       `json:"currency"`
+	Description *string      `json:"description"`
+	Date        string       `json:"date"`
+	Frequency   string       `json:"frequency"`
+	EndDate     string       `json:"end_date"`
+}
+
+// ToDomain converte o DTO para o domínio Income.
+func (dto *IncomeDTO) ToDomain(userID uuid.UUID) (domain.Income, error) {
+	date, err := time.Parse("2006-01-02", dto.Date)
+	if err != nil {
+		return domain.Income{}, err
+	}
+	income := domain.Income{
+		UserID:      userID,
+		CategoryID:  dto.CategoryID,
+		Amount:      dto.Amount,
+		Currency:    dto.Currency,
+		Description: dto.Description,
+		Date:        date,
+	}
+	if dto.Frequency != "" {
+		income.Frequency = &dto.Frequency
+	}
+	if dto.EndDate != "" {
+		endDate, err := time.Parse("2006-01-02", dto.EndDate)
+		if err != nil {
+			return domain.Income{}, err
+		}
+		income.EndDate = &endDate
+	}
+	return income, nil
+}
+
+// IncomeUpdateDTO representa os campos opcionais para atualizar uma receita.
+type IncomeUpdateDTO struct {
+	CategoryID  *int          `json:"category_id,omitempty"`
+	Amount      *domain.Money `json:"amount,omitempty"`
+	Currency    *string       `json:"currency,omitempty"`
+	Description *string       `json:"description,omitempty"`
+	Date        *string       `json:"date,omitempty"`
+	Frequency   *string       `json:"frequency,omitempty"`
+	EndDate     *string       `json:"end_date,omitempty"`
+}
+
+// ApplyTo aplica os campos informados sobre a receita atual.
+// Uma frequency vazia torna a receita única e um end_date vazio remove a data final.
+func (dto *IncomeUpdateDTO) ApplyTo(income *domain.Income) error {
+	if dto.Date != nil {
+		date, err := time.Parse("2006-01-02", *dto.Date)
+		if err != nil {
+			return err
+		}
+		income.Date = date
+	}
+	if dto.EndDate != nil {
+		income.EndDate = nil
+		if *dto.EndDate != "" {
+			endDate, err := time.Parse("2006-01-02", *dto.EndDate)
+			if err != nil {
+				return err
+			}
+			income.EndDate = &endDate
+		}
+	}
+	if dto.Frequency != nil {
+		income.Frequency = nil
+		if *dto.Frequency != "" {
+			income.Frequency = dto.Frequency
+		}
+	}
+	if dto.CategoryID != nil {
+		income.CategoryID = *dto.CategoryID
+	}
+	if dto.Amount != nil {
+		income.Amount = *dto.Amount
+	}
+	if dto.Currency != nil {
+		income.Currency = *dto.Currency
+	}
+	if dto.Description != nil {
+		income.Description = dto.Description
+	}
+	return nil
+}
@@ -7,15 +7,16 @@ import (
 )
 
 type RecurringExpenseDTO struct {
-	UserID      string  `json:"user_id"`
-	CategoryID  int     `json:"category_id"`
-	Amount      float64 `json:"amount"`
-	Description string  `json:"description"`
-	Date        string  `json:"date"`
-	CardID      string  `json:"card_id"`
-	StartDate   string  `json:"start_date"`
-	EndDate     string  `json:"end_date"`
-	Frequency   string  `json:"frequency"`
+	UserID      string       `json:"user_id"`
+	CategoryID  int          `json:"category_id"`
+	Amount      domain.Money `json:"amount"`
+	Currency    string       `json:"currency"`
+	Description string       `json:"description"`
+	Date        string       `json:"date"`
+	CardID      string       `json:"card_id"`
+	StartDate   string       `json:"start_date"`
+	EndDate     string       `json:"end_date"`
+	Frequency   string       `json:"frequency"`
 }
 
 func (dto *RecurringExpenseDTO) ToDomain() (domain.RecurringExpense, error) {
@@ -49,6 +50,7 @@ func (dto *RecurringExpenseDTO) ToDomain() (domain.RecurringExpense, error) {
 		UserID:      userID,
 		CategoryID:  dto.CategoryID,
 		Amount:      dto.Amount,
+		Currency:    dto.Currency,
 		Description: &dto.Description,
 		Date:        date,
 		CardID:      cardID,
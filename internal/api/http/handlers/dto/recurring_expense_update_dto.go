@@ -7,15 +7,16 @@ import (
 )
 
 type RecurringExpenseUpdateDTO struct {
-	ID          string   `json:"id"`
-	CategoryID  *int     `json:"category_id,omitempty"`
-	Amount      *float64 `json:"amount,omitempty"`
-	Description *string  `json:"description,omitempty"`
-	Date        *string  `json:"date,omitempty"`
-	CardID      *string  `json:"card_id,omitempty"`
-	StartDate   *string  `json:"start_date,omitempty"`
-	EndDate     *string  `json:"end_date,omitempty"`
-	Frequency   *string  `json:"frequency,omitempty"`
+	ID          string        `json:"id"`
+	CategoryID  *int          `json:"category_id,omitempty"`
+	Amount      *domain.Money `json:"amount,omitempty"`
+	Currency    *string       `json:"currency,omitempty"`
+	Description *string       `json:"description,omitempty"`
+	Date        *string       `json:"date,omitempty"`
+	CardID      *string       `json:"card_id,omitempty"`
+	StartDate   *string       `json:"start_date,omitempty"`
+	EndDate     *string       `json:"end_date,omitempty"`
+	Frequency   *string       `json:"frequency,omitempty"`
 }
 
 func (dto *RecurringExpenseUpdateDTO) ToDomain(userID uuid.UUID) (domain.RecurringExpense, error) {
@@ -60,7 +61,8 @@ func (dto *RecurringExpenseUpdateDTO) ToDomain(userID uuid.UUID) (domain.Recurri
 		ID:          id,
 		UserID:      userID,
 		CategoryID:  GetIntValue(dto.CategoryID),
-		Amount:      GetFloatValue(dto.Amount),
+		Amount:      GetMoneyValue(dto.Amount),
+		Currency:    GetStringValue(dto.Currency),
 		Description: dto.Description,
 		Date:        date,
 		CardID:      cardIDPtr,
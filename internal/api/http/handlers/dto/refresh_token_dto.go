@@ -4,3 +4,11 @@ package dto
 type RefreshTokenDTO struct {
 	Token string `json:"token"`
 }
+
+// TokenResponseDTO representa a resposta de um refresh, no formato de resposta de token do OAuth2.
+type TokenResponseDTO struct {
+	AccessToken  string `json:"access_token"`
+	TokenType    string `json:"token_type"`
+	ExpiresIn    int    `json:"expires_in"` // segundos até o token de acesso expirar
+	RefreshToken string `json:"refresh_token"`
+}
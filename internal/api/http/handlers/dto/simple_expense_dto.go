@@ -8,11 +8,12 @@ import (
 )
 
 type SimpleExpenseDTO struct {
-	UserID      string  `json:"user_id"`
-	CategoryID  int     `json:"category_id"`
-	Amount      float64 `json:"amount"`
-	Description string  `json:"description"`
-	Date        string  `json:"date"`
+	UserID      string       `json:"user_id"`
+	CategoryID  int          `json:"category_id"`
+	Amount      domain.Money `json:"amount"`
+	Currency    string       `json:"currency"`
+	Description string       `json:"description"`
+	Date        string       `json:"date"`
 }
 
 func (dto *SimpleExpenseDTO) ToDomain() (domain.SimpleExpense, error) {
@@ -28,17 +29,19 @@ func (dto *SimpleExpenseDTO) ToDomain() (domain.SimpleExpense, error) {
 		UserID:      userID,
 		CategoryID:  dto.CategoryID,
 		Amount:      dto.Amount,
+		Currency:    dto.Currency,
 		Description: &dto.Description,
 		Date:        date,
 	}, nil
 }
 
 type SimpleExpenseUpdateDTO struct {
-	ID          string   `json:"id"`
-	CategoryID  *int     `json:"category_id,omitempty"`
-	Amount      *float64 `json:"amount,omitempty"`
-	Description *string  `json:"description,omitempty"`
-	Date        *string  `json:"date,omitempty"`
+	ID          string        `json:"id"`
+	CategoryID  *int          `json:"category_id,omitempty"`
+	Amount      *domain.Money `json:"amount,omitempty"`
+	Currency    *string       `json:"currency,omitempty"`
+	Description *string       `json:"description,omitempty"`
+	Date        *string       `json:"date,omitempty"`
 }
 
 func (dto *SimpleExpenseUpdateDTO) ToDomain(userID uuid.UUID) (domain.SimpleExpense, error) {
@@ -57,7 +60,8 @@ func (dto *SimpleExpenseUpdateDTO) ToDomain(userID uuid.UUID) (domain.SimpleExpe
 		ID:          id,
 		UserID:      userID,
 		CategoryID:  GetIntValue(dto.CategoryID),
-		Amount:      GetFloatValue(dto.Amount),
+		Amount:      GetMoneyValue(dto.Amount),
+		Currency:    GetStringValue(dto.Currency),
 		Description: dto.Description,
 		Date:        date,
 	}, nil
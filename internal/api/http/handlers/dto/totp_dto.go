@@ -0,0 +1,18 @@
+package dto
+
+// TOTPCodeDTO representa um código de seis dígitos do aplicativo autenticador ou um código de recuperação.
+type TOTPCodeDTO struct {
+	Code string `json:"code"`
+}
+
+// MFALoginDTO representa o segundo passo do login quando a autenticação em dois fatores está ativa.
+type MFALoginDTO struct {
+	MFAToken    string `json:"mfa_token"`
+	Code        string `json:"code"`
+	DeviceLabel string `json:"device_label"` // opcional, nome do dispositivo exibido em GET /sessions
+}
+
+// RecoveryCodesDTO representa os códigos de recuperação, exibidos uma única vez ao ativar a autenticação em dois fatores.
+type RecoveryCodesDTO struct {
+	RecoveryCodes []string `json:"recovery_codes"`
+}
@@ -0,0 +1,27 @@
+package handlers
+
+import (
+	"errors"
+	"github.com/misalima/my-budget-planner-backend/internal/core/domain"
+	"net/http"
+)
+
+// statusFromError maps the domain sentinel errors to their HTTP status code.
+func statusFromError(err error) int {
+	switch {
+	case errors.Is(err, domain.ErrNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, domain.ErrInvalidInput):
+		return http.StatusBadRequest
+	case errors.Is(err, domain.ErrInvalidTOTPCode):
+		return http.StatusUnauthorized
+	case errors.Is(err, domain.ErrTOTPAlreadyEnabled):
+		return http.StatusConflict
+	case errors.Is(err, domain.ErrTOTPLocked):
+		return http.StatusTooManyRequests
+	case errors.Is(err, domain.ErrMissingExchangeRate):
+		return http.StatusUnprocessableEntity
+	default:
+		return http.StatusInternalServerError
+	}
+}
@@ -0,0 +1,71 @@
+package handlers
+
+import (
+	"github.com/labstack/echo/v4"
+	"github.com/misalima/my-budget-planner-backend/internal/core/domain"
+	"github.com/misalima/my-budget-planner-backend/internal/core/interfaces/irepository"
+	"github.com/misalima/my-budget-planner-backend/internal/core/interfaces/iservice"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+type ExchangeRateHandler struct {
+	svc iservice.ExchangeRateManager
+}
+
+func NewExchangeRateHandler(svc iservice.ExchangeRateManager) *ExchangeRateHandler {
+	return &ExchangeRateHandler{svc: svc}
+}
+
+// ListExchangeRates godoc
+// @Summary Lista as cotações carregadas, usadas para converter valores para a moeda base do usuário
+// @Tags ExchangeRate
+// @Produce json
+// @Security bearerAuth
+// @Param base_currency query string false "Moeda base da cotação (ex.: USD)"
+// @Param quote_currency query string false "Moeda cotada (ex.: BRL)"
+// @Param start_date query string false "Data inicial (YYYY-MM-DD)"
+// @Param end_date query string false "Data final (YYYY-MM-DD)"
+// @Param limit query int false "Limite de resultados"
+// @Param offset query int false "Offset"
+// @Success 200 {array} domain.ExchangeRate
+// @Failure 401 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /exchange-rates [get]
+func (h *ExchangeRateHandler) ListExchangeRates(ctx echo.Context) error {
+	var filters irepository.ExchangeRateFilters
+
+	if v := domain.NormalizeCurrency(ctx.QueryParam("base_currency")); v != "" {
+		filters.BaseCurrency = &v
+	}
+	if v := domain.NormalizeCurrency(ctx.QueryParam("quote_currency")); v != "" {
+		filters.QuoteCurrency = &v
+	}
+	if v := ctx.QueryParam("start_date"); v != "" {
+		if t, err := time.Parse("2006-01-02", v); err == nil {
+			filters.StartDate = &t
+		}
+	}
+	if v := ctx.QueryParam("end_date"); v != "" {
+		if t, err := time.Parse("2006-01-02", v); err == nil {
+			filters.EndDate = &t
+		}
+	}
+	if v := ctx.QueryParam("limit"); v != "" {
+		if l, err := strconv.Atoi(v); err == nil {
+			filters.Limit = &l
+		}
+	}
+	if v := ctx.QueryParam("offset"); v != "" {
+		if o, err := strconv.Atoi(v); err == nil {
+			filters.Offset = &o
+		}
+	}
+
+	rates, err := h.svc.ListExchangeRates(ctx.Request().Context(), filters)
+	if err != nil {
+		return ctx.JSON(statusFromError(err), map[string]string{"error": err.Error()})
+	}
+	return ctx.JSON(http.StatusOK, rates)
+}
@@ -0,0 +1,94 @@
+package handlers
+
+import (
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/misalima/my-budget-planner-backend/internal/core/domain"
+	"github.com/misalima/my-budget-planner-backend/internal/core/interfaces/iservice"
+	"net/http"
+	"time"
+)
+
+type ExportHandler struct {
+	svc iservice.ExportManager
+}
+
+func NewExportHandler(svc iservice.ExportManager) *ExportHandler {
+	return &ExportHandler{svc: svc}
+}
+
+// ExportAccount godoc
+// @Summary Exporta todos os dados da conta em um arquivo zip versionado, com arquivos JSON e CSV
+// @Description O zip contém manifest.json (formato, versão e contagem de registros), bundle.json (o pacote aceito por POST /import/archive) e, para o perfil, categorias, cartões, despesas simples, recorrentes e de cartão, orçamentos, receitas e pagamentos de fatura, um arquivo em json/ e outro em csv/. Senhas e refresh tokens não são exportados.
+// @Tags Export
+// @Produce application/zip
+// @Security bearerAuth
+// @Success 200 {file} file
+// @Failure 401 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /export [get]
+func (h *ExportHandler) ExportAccount(ctx echo.Context) error {
+	userID, ok := ctx.Get("user_id").(uuid.UUID)
+	if !ok || userID == uuid.Nil {
+		return ctx.JSON(http.StatusUnauthorized, map[string]string{"error": "invalid user id from token"})
+	}
+	export, err := h.svc.ExportAccount(ctx.Request().Context(), userID)
+	if err != nil {
+		return ctx.JSON(statusFromError(err), map[string]string{"error": err.Error()})
+	}
+
+	// The archive is streamed, so errors past this point can only cut the response short.
+	filename := fmt.Sprintf("my-budget-planner-export-%s.zip", export.Manifest.ExportedAt.Format("2006-01-02"))
+	ctx.Response().Header().Set(echo.HeaderContentType, "application/zip")
+	ctx.Response().Header().Set(echo.HeaderContentDisposition, fmt.Sprintf("attachment; filename=%q", filename))
+	ctx.Response().WriteHeader(http.StatusOK)
+	return h.svc.WriteExportArchive(ctx.Response(), export)
+}
+
+// ExportLedger godoc
+// @Summary Exporta as despesas de um período em formato de contabilidade em texto puro (ledger, hledger ou beancount)
+// @Description Categorias viram contas Expenses:*, cartões viram contas Liabilities:CreditCard:* e despesas fora do cartão saem de Assets:Checking. Despesas simples, ocorrências de despesas recorrentes e parcelas de cartão viram transações balanceadas.
+// @Tags Export
+// @Produce plain
+// @Security bearerAuth
+// @Param format query string false "Formato: ledger (padrão), hledger ou beancount"
+// @Param start_date query string true "Data inicial (YYYY-MM-DD)"
+// @Param end_date query string true "Data final (YYYY-MM-DD)"
+// @Success 200 {string} string
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /export/ledger [get]
+func (h *ExportHandler) ExportLedger(ctx echo.Context) error {
+	userID, ok := ctx.Get("user_id").(uuid.UUID)
+	if !ok || userID == uuid.Nil {
+		return ctx.JSON(http.StatusUnauthorized, map[string]string{"error": "invalid user id from token"})
+	}
+	options := domain.LedgerExportOptions{Format: ctx.QueryParam("format")}
+	if options.Format == "" {
+		options.Format = domain.LedgerFormatLedger
+	}
+	var err error
+	if options.StartDate, err = time.Parse("2006-01-02", ctx.QueryParam("start_date")); err != nil {
+		return ctx.JSON(http.StatusBadRequest, map[string]string{"error": "invalid start_date, expected YYYY-MM-DD"})
+	}
+	if options.EndDate, err = time.Parse("2006-01-02", ctx.QueryParam("end_date")); err != nil {
+		return ctx.JSON(http.StatusBadRequest, map[string]string{"error": "invalid end_date, expected YYYY-MM-DD"})
+	}
+
+	journal, err := h.svc.ExportLedger(ctx.Request().Context(), userID, options)
+	if err != nil {
+		return ctx.JSON(statusFromError(err), map[string]string{"error": err.Error()})
+	}
+	extension := "journal"
+	if options.Format == domain.LedgerFormatBeancount {
+		extension = "beancount"
+	}
+	filename := fmt.Sprintf("my-budget-planner-%s-%s.%s",
+		options.StartDate.Format("2006-01-02"), options.EndDate.Format("2006-01-02"), extension)
+	ctx.Response().Header().Set(echo.HeaderContentDisposition, fmt.Sprintf("attachment; filename=%q", filename))
+	return ctx.Blob(http.StatusOK, echo.MIMETextPlainCharsetUTF8, journal)
+}
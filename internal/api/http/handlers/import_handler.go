@@ -0,0 +1,311 @@
+package handlers
+
+import (
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/misalima/my-budget-planner-backend/internal/core/domain"
+	"github.com/misalima/my-budget-planner-backend/internal/core/interfaces/iservice"
+	"net/http"
+	"strconv"
+)
+
+type ImportHandler struct {
+	svc iservice.ImportManager
+}
+
+func NewImportHandler(svc iservice.ImportManager) *ImportHandler {
+	return &ImportHandler{svc: svc}
+}
+
+// ImportCSV godoc
+// @Summary Importa um extrato bancário em CSV como despesas simples
+// @Description Sem confirm, devolve apenas a prévia das linhas lidas e seus erros de validação. Com confirm=true e nenhuma linha inválida, cria todas as despesas em uma única transação.
+// @Tags Import
+// @Accept multipart/form-data
+// @Produce json
+// @Security bearerAuth
+// @Param file formData file true "Arquivo CSV com cabeçalho"
+// @Param date_column formData string true "Coluna com a data"
+// @Param amount_column formData string true "Coluna com o valor"
+// @Param description_column formData string true "Coluna com a descrição"
+// @Param category_column formData string false "Coluna com o nome ou ID da categoria"
+// @Param category_id formData int false "Categoria usada quando a linha não tem uma"
+// @Param date_format formData string false "YYYY-MM-DD (padrão), DD/MM/YYYY, MM/DD/YYYY, DD-MM-YYYY ou DD.MM.YYYY"
+// @Param decimal_separator formData string false "Separador decimal: . (padrão) ou ,"
+// @Param delimiter formData string false "Delimitador: , ; | ou tab. Sem ele, é detectado pelo cabeçalho"
+// @Param negative_expenses formData bool false "Valores negativos são despesas e positivos são ignorados"
+// @Param currency formData string false "Moeda dos valores (padrão: moeda base do usuário)"
+// @Param confirm formData bool false "Grava as despesas em vez de só devolver a prévia"
+// @Success 200 {object} domain.ImportPreview
+// @Success 201 {object} domain.ImportPreview
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 422 {object} domain.ImportPreview
+// @Failure 500 {object} map[string]string
+// @Router /imports/csv [post]
+func (h *ImportHandler) ImportCSV(ctx echo.Context) error {
+	userID, ok := ctx.Get("user_id").(uuid.UUID)
+	if !ok || userID == uuid.Nil {
+		return ctx.JSON(http.StatusUnauthorized, map[string]string{"error": "invalid user id from token"})
+	}
+
+	fileHeader, err := ctx.FormFile("file")
+	if err != nil {
+		return ctx.JSON(http.StatusBadRequest, map[string]string{"error": "file is required"})
+	}
+	file, err := fileHeader.Open()
+	if err != nil {
+		return ctx.JSON(http.StatusBadRequest, map[string]string{"error": "invalid file"})
+	}
+	defer file.Close()
+
+	options := domain.CSVImportOptions{
+		DateColumn:        ctx.FormValue("date_column"),
+		AmountColumn:      ctx.FormValue("amount_column"),
+		DescriptionColumn: ctx.FormValue("description_column"),
+		CategoryColumn:    ctx.FormValue("category_column"),
+		DateFormat:        ctx.FormValue("date_format"),
+		DecimalSeparator:  ctx.FormValue("decimal_separator"),
+		Delimiter:         ctx.FormValue("delimiter"),
+		Currency:          ctx.FormValue("currency"),
+	}
+	if v := ctx.FormValue("category_id"); v != "" {
+		id, err := strconv.Atoi(v)
+		if err != nil {
+			return ctx.JSON(http.StatusBadRequest, map[string]string{"error": "invalid category_id"})
+		}
+		options.DefaultCategoryID = id
+	}
+	if v := ctx.FormValue("negative_expenses"); v != "" {
+		options.NegativeExpenses, err = strconv.ParseBool(v)
+		if err != nil {
+			return ctx.JSON(http.StatusBadRequest, map[string]string{"error": "invalid negative_expenses"})
+		}
+	}
+	confirm := false
+	if v := ctx.FormValue("confirm"); v != "" {
+		confirm, err = strconv.ParseBool(v)
+		if err != nil {
+			return ctx.JSON(http.StatusBadRequest, map[string]string{"error": "invalid confirm"})
+		}
+	}
+
+	preview, err := h.svc.ImportCSV(ctx.Request().Context(), userID, file, options, confirm)
+	if err != nil {
+		return ctx.JSON(statusFromError(err), map[string]string{"error": err.Error()})
+	}
+	return ctx.JSON(previewStatus(preview, confirm), preview)
+}
+
+// ImportOFX godoc
+// @Summary Importa um arquivo OFX ou QFX do banco como despesas simples e receitas
+// @Description Débitos viram despesas simples e créditos viram receitas. Lançamentos cujo FITID já foi importado aparecem como duplicate e não são gravados de novo. Sem confirm, devolve apenas a prévia; com confirm=true e nenhuma linha inválida, grava tudo em uma única transação.
+// @Tags Import
+// @Accept multipart/form-data
+// @Produce json
+// @Security bearerAuth
+// @Param file formData file true "Arquivo OFX ou QFX (SGML ou XML)"
+// @Param expense_category_id formData int false "Categoria das despesas criadas a partir dos débitos"
+// @Param income_category_id formData int false "Categoria das receitas criadas a partir dos créditos"
+// @Param currency formData string false "Moeda dos valores (padrão: a do arquivo ou a moeda base do usuário)"
+// @Param confirm formData bool false "Grava os lançamentos em vez de só devolver a prévia"
+// @Success 200 {object} domain.ImportPreview
+// @Success 201 {object} domain.ImportPreview
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 422 {object} domain.ImportPreview
+// @Failure 500 {object} map[string]string
+// @Router /imports/ofx [post]
+func (h *ImportHandler) ImportOFX(ctx echo.Context) error {
+	userID, ok := ctx.Get("user_id").(uuid.UUID)
+	if !ok || userID == uuid.Nil {
+		return ctx.JSON(http.StatusUnauthorized, map[string]string{"error": "invalid user id from token"})
+	}
+
+	fileHeader, err := ctx.FormFile("file")
+	if err != nil {
+		return ctx.JSON(http.StatusBadRequest, map[string]string{"error": "file is required"})
+	}
+	file, err := fileHeader.Open()
+	if err != nil {
+		return ctx.JSON(http.StatusBadRequest, map[string]string{"error": "invalid file"})
+	}
+	defer file.Close()
+
+	options := domain.OFXImportOptions{Currency: ctx.FormValue("currency")}
+	if v := ctx.FormValue("expense_category_id"); v != "" {
+		id, err := strconv.Atoi(v)
+		if err != nil {
+			return ctx.JSON(http.StatusBadRequest, map[string]string{"error": "invalid expense_category_id"})
+		}
+		options.ExpenseCategoryID = id
+	}
+	if v := ctx.FormValue("income_category_id"); v != "" {
+		id, err := strconv.Atoi(v)
+		if err != nil {
+			return ctx.JSON(http.StatusBadRequest, map[string]string{"error": "invalid income_category_id"})
+		}
+		options.IncomeCategoryID = id
+	}
+	confirm := false
+	if v := ctx.FormValue("confirm"); v != "" {
+		confirm, err = strconv.ParseBool(v)
+		if err != nil {
+			return ctx.JSON(http.StatusBadRequest, map[string]string{"error": "invalid confirm"})
+		}
+	}
+
+	preview, err := h.svc.ImportOFX(ctx.Request().Context(), userID, file, options, confirm)
+	if err != nil {
+		return ctx.JSON(statusFromError(err), map[string]string{"error": err.Error()})
+	}
+	return ctx.JSON(previewStatus(preview, confirm), preview)
+}
+
+// ImportCardStatement godoc
+// @Summary Importa a fatura de um cartão de crédito (CSV ou OFX) criando as parcelas das compras
+// @Description Linhas como "LOJA Parc 03/10" são a parcela 3 de 10 (com bare_parcel_suffix=true, também "LOJA 03/10"; sem ele, essas linhas vêm com um aviso em warnings): se essa parcela já existe no cartão, a linha aparece como matched; senão, a linha cria as parcelas 3 a 10 da compra. Pagamentos e estornos são ignorados. Arquivos OFX são detectados pelo conteúdo; para CSV, informe o mapeamento das colunas. Com confirm=true e nenhuma linha inválida, grava tudo em uma única transação.
+// @Tags Import
+// @Accept multipart/form-data
+// @Produce json
+// @Security bearerAuth
+// @Param file formData file true "Fatura em CSV com cabeçalho ou em OFX"
+// @Param card_id formData string true "ID do cartão"
+// @Param category_id formData int false "Categoria usada quando a linha não tem uma"
+// @Param date_column formData string false "Coluna com a data (CSV)"
+// @Param amount_column formData string false "Coluna com o valor (CSV)"
+// @Param description_column formData string false "Coluna com a descrição (CSV)"
+// @Param category_column formData string false "Coluna com o nome ou ID da categoria (CSV)"
+// @Param date_format formData string false "YYYY-MM-DD (padrão), DD/MM/YYYY, MM/DD/YYYY, DD-MM-YYYY ou DD.MM.YYYY (CSV)"
+// @Param decimal_separator formData string false "Separador decimal: . (padrão) ou , (CSV)"
+// @Param delimiter formData string false "Delimitador: , ; | ou tab (CSV)"
+// @Param negative_expenses formData bool false "Compras aparecem com valor negativo (CSV)"
+// @Param purchase_dates formData bool false "A fatura data cada parcela com a data da compra, e não com a data da cobrança"
+// @Param bare_parcel_suffix formData bool false "Lê um sufixo n/m sem Parc, como em LOJA 03/10, como a parcela (datas como UBER 05/11 têm a mesma forma)"
+// @Param confirm formData bool false "Grava as parcelas em vez de só devolver a prévia"
+// @Success 200 {object} domain.ImportPreview
+// @Success 201 {object} domain.ImportPreview
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 422 {object} domain.ImportPreview
+// @Failure 500 {object} map[string]string
+// @Router /imports/card-statement [post]
+func (h *ImportHandler) ImportCardStatement(ctx echo.Context) error {
+	userID, ok := ctx.Get("user_id").(uuid.UUID)
+	if !ok || userID == uuid.Nil {
+		return ctx.JSON(http.StatusUnauthorized, map[string]string{"error": "invalid user id from token"})
+	}
+
+	cardID, err := uuid.Parse(ctx.FormValue("card_id"))
+	if err != nil {
+		return ctx.JSON(http.StatusBadRequest, map[string]string{"error": "invalid card_id"})
+	}
+	fileHeader, err := ctx.FormFile("file")
+	if err != nil {
+		return ctx.JSON(http.StatusBadRequest, map[string]string{"error": "file is required"})
+	}
+	file, err := fileHeader.Open()
+	if err != nil {
+		return ctx.JSON(http.StatusBadRequest, map[string]string{"error": "invalid file"})
+	}
+	defer file.Close()
+
+	options := domain.CardStatementImportOptions{
+		CardID: cardID,
+		CSV: domain.CSVImportOptions{
+			DateColumn:        ctx.FormValue("date_column"),
+			AmountColumn:      ctx.FormValue("amount_column"),
+			DescriptionColumn: ctx.FormValue("description_column"),
+			CategoryColumn:    ctx.FormValue("category_column"),
+			DateFormat:        ctx.FormValue("date_format"),
+			DecimalSeparator:  ctx.FormValue("decimal_separator"),
+			Delimiter:         ctx.FormValue("delimiter"),
+		},
+	}
+	if v := ctx.FormValue("category_id"); v != "" {
+		id, err := strconv.Atoi(v)
+		if err != nil {
+			return ctx.JSON(http.StatusBadRequest, map[string]string{"error": "invalid category_id"})
+		}
+		options.CSV.DefaultCategoryID = id
+	}
+	if v := ctx.FormValue("negative_expenses"); v != "" {
+		options.CSV.NegativeExpenses, err = strconv.ParseBool(v)
+		if err != nil {
+			return ctx.JSON(http.StatusBadRequest, map[string]string{"error": "invalid negative_expenses"})
+		}
+	}
+	if v := ctx.FormValue("purchase_dates"); v != "" {
+		options.PurchaseDates, err = strconv.ParseBool(v)
+		if err != nil {
+			return ctx.JSON(http.StatusBadRequest, map[string]string{"error": "invalid purchase_dates"})
+		}
+	}
+	if v := ctx.FormValue("bare_parcel_suffix"); v != "" {
+		options.BareParcelSuffix, err = strconv.ParseBool(v)
+		if err != nil {
+			return ctx.JSON(http.StatusBadRequest, map[string]string{"error": "invalid bare_parcel_suffix"})
+		}
+	}
+	confirm := false
+	if v := ctx.FormValue("confirm"); v != "" {
+		confirm, err = strconv.ParseBool(v)
+		if err != nil {
+			return ctx.JSON(http.StatusBadRequest, map[string]string{"error": "invalid confirm"})
+		}
+	}
+
+	preview, err := h.svc.ImportCardStatement(ctx.Request().Context(), userID, file, options, confirm)
+	if err != nil {
+		return ctx.JSON(statusFromError(err), map[string]string{"error": err.Error()})
+	}
+	return ctx.JSON(previewStatus(preview, confirm), preview)
+}
+
+// ImportArchive godoc
+// @Summary Restaura na conta do usuário um pacote JSON versionado com categorias, cartões, despesas, orçamentos, receitas e pagamentos de fatura
+// @Description O pacote é o bundle.json do zip gerado por GET /export. Todos os registros recebem novos IDs; categorias com o mesmo nome e tipo de uma categoria já disponível para o usuário são reaproveitadas, e o limite disponível de cada cartão é recalculado a partir das parcelas e pagamentos restaurados. Valores e limites menores ou iguais a zero são conflitos. Se algum registro conflitar com a conta ou com o próprio pacote, nada é gravado e os conflitos são devolvidos; senão, tudo é gravado em uma única transação.
+// @Tags Import
+// @Accept json
+// @Produce json
+// @Security bearerAuth
+// @Param bundle body domain.ArchiveBundle true "Pacote exportado"
+// @Success 201 {object} domain.ArchiveImportResult
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 409 {object} domain.ArchiveImportResult
+// @Failure 500 {object} map[string]string
+// @Router /import/archive [post]
+func (h *ImportHandler) ImportArchive(ctx echo.Context) error {
+	userID, ok := ctx.Get("user_id").(uuid.UUID)
+	if !ok || userID == uuid.Nil {
+		return ctx.JSON(http.StatusUnauthorized, map[string]string{"error": "invalid user id from token"})
+	}
+	var bundle domain.ArchiveBundle
+	if err := ctx.Bind(&bundle); err != nil {
+		return ctx.JSON(http.StatusBadRequest, map[string]string{"error": "invalid request data"})
+	}
+
+	result, err := h.svc.ImportArchive(ctx.Request().Context(), userID, bundle)
+	if err != nil {
+		return ctx.JSON(statusFromError(err), map[string]string{"error": err.Error()})
+	}
+	if !result.Restored {
+		return ctx.JSON(http.StatusConflict, result)
+	}
+	return ctx.JSON(http.StatusCreated, result)
+}
+
+// previewStatus is 201 for a stored import, 422 for a confirmed one that had invalid
+// rows and 200 for a plain preview.
+func previewStatus(preview domain.ImportPreview, confirm bool) int {
+	switch {
+	case preview.Committed:
+		return http.StatusCreated
+	case confirm:
+		return http.StatusUnprocessableEntity
+	default:
+		return http.StatusOK
+	}
+}
@@ -0,0 +1,285 @@
+package handlers
+
+import (
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/misalima/my-budget-planner-backend/internal/api/http/handlers/dto"
+	"github.com/misalima/my-budget-planner-backend/internal/core/interfaces/irepository"
+	"github.com/misalima/my-budget-planner-backend/internal/core/interfaces/iservice"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+type IncomeHandler struct {
+	svc iservice.IncomeManager
+}
+
+func NewIncomeHandler(svc iservice.IncomeManager) *IncomeHandler {
+	return &IncomeHandler{svc: svc}
+}
+
+// CreateIncome godoc
+// @Summary Registra uma nova receita, única ou recorrente
+// @Tags Income
+// @Accept json
+// @Produce json
+// @Security bearerAuth
+// @Param income body dto.IncomeDTO true "Dados da receita" example({"category_id":8,"amount":5000,"description":"Salário","date":"2025-01-05","frequency":"monthly"})
+// @Success 201 {object} domain.Income
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /incomes [post]
+func (h *IncomeHandler) CreateIncome(ctx echo.Context) error {
+	var dtoReq dto.IncomeDTO
+	if err := ctx.Bind(&dtoReq); err != nil {
+		return ctx.JSON(http.StatusBadRequest, map[string]string{"error": "invalid request data"})
+	}
+	userID, ok := ctx.Get("user_id").(uuid.UUID)
+	if !ok || userID == uuid.Nil {
+		return ctx.JSON(http.StatusUnauthorized, map[string]string{"error": "invalid user id from token"})
+	}
+	income, err := dtoReq.ToDomain(userID)
+	if err != nil {
+		return ctx.JSON(http.StatusBadRequest, map[string]string{"error": "invalid request data"})
+	}
+	created, err := h.svc.CreateIncome(ctx.Request().Context(), income)
+	if err != nil {
+		return ctx.JSON(statusFromError(err), map[string]string{"error": err.Error()})
+	}
+	return ctx.JSON(http.StatusCreated, created)
+}
+
+// GetIncomeByID godoc
+// @Summary Busca uma receita por ID
+// @Tags Income
+// @Produce json
+// @Security bearerAuth
+// @Param id path string true "ID da receita"
+// @Success 200 {object} domain.Income
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /incomes/{id} [get]
+func (h *IncomeHandler) GetIncomeByID(ctx echo.Context) error {
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		return ctx.JSON(http.StatusBadRequest, map[string]string{"error": "invalid income id"})
+	}
+	userID, ok := ctx.Get("user_id").(uuid.UUID)
+	if !ok || userID == uuid.Nil {
+		return ctx.JSON(http.StatusUnauthorized, map[string]string{"error": "invalid user id from token"})
+	}
+	income, err := h.svc.GetIncomeByID(ctx.Request().Context(), id, userID)
+	if err != nil {
+		return ctx.JSON(statusFromError(err), map[string]string{"error": err.Error()})
+	}
+	return ctx.JSON(http.StatusOK, income)
+}
+
+// ListIncomes godoc
+// @Summary Lista as receitas do usuário
+// @Tags Income
+// @Produce json
+// @Security bearerAuth
+// @Param category_id query int false "ID da categoria"
+// @Param recurring query bool false "Filtra receitas recorrentes (true) ou únicas (false)"
+// @Param start_date query string false "Data inicial (YYYY-MM-DD)"
+// @Param end_date query string false "Data final (YYYY-MM-DD)"
+// @Param min_amount query number false "Valor mínimo"
+// @Param max_amount query number false "Valor máximo"
+// @Param limit query int false "Limite de resultados"
+// @Param offset query int false "Offset"
+// @Success 200 {array} domain.Income
+// @Failure 401 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /incomes [get]
+func (h *IncomeHandler) ListIncomes(ctx echo.Context) error {
+	userID, ok := ctx.Get("user_id").(uuid.UUID)
+	if !ok || userID == uuid.Nil {
+		return ctx.JSON(http.StatusUnauthorized, map[string]string{"error": "invalid user id from token"})
+	}
+
+	filters := irepository.IncomeFilters{}
+
+	if v := ctx.QueryParam("category_id"); v != "" {
+		if id, err := strconv.Atoi(v); err == nil {
+			filters.CategoryID = &id
+		}
+	}
+	if v := ctx.QueryParam("recurring"); v != "" {
+		if recurring, err := strconv.ParseBool(v); err == nil {
+			filters.Recurring = &recurring
+		}
+	}
+	if v := ctx.QueryParam("start_date"); v != "" {
+		if t, err := time.Parse("2006-01-02", v); err == nil {
+			filters.StartDate = &t
+		}
+	}
+	if v := ctx.QueryParam("end_date"); v != "" {
+		if t, err := time.Parse("2006-01-02", v); err == nil {
+			filters.EndDate = &t
+		}
+	}
+	if v := ctx.QueryParam("min_amount"); v != "" {
+		if f, err := strconv.ParseFloat(v, 64); err == nil {
+			filters.MinAmount = &f
+		}
+	}
+	if v := ctx.QueryParam("max_amount"); v != "" {
+		if f, err := strconv.ParseFloat(v, 64); err == nil {
+			filters.MaxAmount = &f
+		}
+	}
+	if v := ctx.QueryParam("limit"); v != "" {
+		if l, err := strconv.Atoi(v); err == nil {
+			filters.Limit = &l
+		}
+	}
+	if v := ctx.QueryParam("offset"); v != "" {
+		if o, err := strconv.Atoi(v); err == nil {
+			filters.Offset = &o
+		}
+	}
+
+	incomes, err := h.svc.ListIncomes(ctx.Request().Context(), userID, filters)
+	if err != nil {
+		return ctx.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+	return ctx.JSON(http.StatusOK, incomes)
+}
+
+// UpdateIncome godoc
+// @Summary Atualiza uma receita
+// @Tags Income
+// @Accept json
+// @Produce json
+// @Security bearerAuth
+// @Param id path string true "ID da receita"
+// @Param income body dto.IncomeUpdateDTO true "Dados da receita para atualização"
+// @Success 200 {object} domain.Income
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /incomes/{id} [put]
+func (h *IncomeHandler) UpdateIncome(ctx echo.Context) error {
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		return ctx.JSON(http.StatusBadRequest, map[string]string{"error": "invalid income id"})
+	}
+	var dtoReq dto.IncomeUpdateDTO
+	if err := ctx.Bind(&dtoReq); err != nil {
+		return ctx.JSON(http.StatusBadRequest, map[string]string{"error": "invalid request data"})
+	}
+	userID, ok := ctx.Get("user_id").(uuid.UUID)
+	if !ok || userID == uuid.Nil {
+		return ctx.JSON(http.StatusUnauthorized, map[string]string{"error": "invalid user id from token"})
+	}
+	income, err := h.svc.GetIncomeByID(ctx.Request().Context(), id, userID)
+	if err != nil {
+		return ctx.JSON(statusFromError(err), map[string]string{"error": err.Error()})
+	}
+	if err := dtoReq.ApplyTo(&income); err != nil {
+		return ctx.JSON(http.StatusBadRequest, map[string]string{"error": "invalid request data"})
+	}
+	updated, err := h.svc.UpdateIncome(ctx.Request().Context(), income)
+	if err != nil {
+		return ctx.JSON(statusFromError(err), map[string]string{"error": err.Error()})
+	}
+	return ctx.JSON(http.StatusOK, updated)
+}
+
+// DeleteIncome godoc
+// @Summary Remove uma receita
+// @Tags Income
+// @Produce json
+// @Security bearerAuth
+// @Param id path string true "ID da receita"
+// @Success 204 {object} nil
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /incomes/{id} [delete]
+func (h *IncomeHandler) DeleteIncome(ctx echo.Context) error {
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		return ctx.JSON(http.StatusBadRequest, map[string]string{"error": "invalid income id"})
+	}
+	userID, ok := ctx.Get("user_id").(uuid.UUID)
+	if !ok || userID == uuid.Nil {
+		return ctx.JSON(http.StatusUnauthorized, map[string]string{"error": "invalid user id from token"})
+	}
+	if err := h.svc.DeleteIncome(ctx.Request().Context(), id, userID); err != nil {
+		return ctx.JSON(statusFromError(err), map[string]string{"error": err.Error()})
+	}
+	return ctx.NoContent(http.StatusNoContent)
+}
+
+// GetIncomeSummary godoc
+// @Summary Resumo das receitas no período
+// @Tags Income
+// @Produce json
+// @Security bearerAuth
+// @Param start_date query string true "Data inicial (YYYY-MM-DD)"
+// @Param end_date query string true "Data final (YYYY-MM-DD)"
+// @Success 200 {object} domain.IncomeSummary
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 422 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /incomes/summary [get]
+func (h *IncomeHandler) GetIncomeSummary(ctx echo.Context) error {
+	userID, ok := ctx.Get("user_id").(uuid.UUID)
+	if !ok || userID == uuid.Nil {
+		return ctx.JSON(http.StatusUnauthorized, map[string]string{"error": "invalid user id from token"})
+	}
+	startDate, err := time.Parse("2006-01-02", ctx.QueryParam("start_date"))
+	if err != nil {
+		return ctx.JSON(http.StatusBadRequest, map[string]string{"error": "invalid start date"})
+	}
+	endDate, err := time.Parse("2006-01-02", ctx.QueryParam("end_date"))
+	if err != nil {
+		return ctx.JSON(http.StatusBadRequest, map[string]string{"error": "invalid end date"})
+	}
+	summary, err := h.svc.GetIncomeSummary(ctx.Request().Context(), userID, startDate, endDate)
+	if err != nil {
+		return ctx.JSON(statusFromError(err), map[string]string{"error": err.Error()})
+	}
+	return ctx.JSON(http.StatusOK, summary)
+}
+
+// GetCashFlow godoc
+// @Summary Fluxo de caixa líquido (receitas menos despesas) no período
+// @Tags Income
+// @Produce json
+// @Security bearerAuth
+// @Param start_date query string true "Data inicial (YYYY-MM-DD)"
+// @Param end_date query string true "Data final (YYYY-MM-DD)"
+// @Success 200 {object} domain.CashFlow
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 422 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /incomes/cash-flow [get]
+func (h *IncomeHandler) GetCashFlow(ctx echo.Context) error {
+	userID, ok := ctx.Get("user_id").(uuid.UUID)
+	if !ok || userID == uuid.Nil {
+		return ctx.JSON(http.StatusUnauthorized, map[string]string{"error": "invalid user id from token"})
+	}
+	startDate, err := time.Parse("2006-01-02", ctx.QueryParam("start_date"))
+	if err != nil {
+		return ctx.JSON(http.StatusBadRequest, map[string]string{"error": "invalid start date"})
+	}
+	endDate, err := time.Parse("2006-01-02", ctx.QueryParam("end_date"))
+	if err != nil {
+		return ctx.JSON(http.StatusBadRequest, map[string]string{"error": "invalid end date"})
+	}
+	flow, err := h.svc.GetCashFlow(ctx.Request().Context(), userID, startDate, endDate)
+	if err != nil {
+		return ctx.JSON(statusFromError(err), map[string]string{"error": err.Error()})
+	}
+	return ctx.JSON(http.StatusOK, flow)
+}
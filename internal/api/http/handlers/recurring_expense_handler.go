@@ -41,7 +41,7 @@ func (h *RecurringExpenseHandler) CreateRecurringExpense(ctx echo.Context) error
 	}
 	created, err := h.svc.CreateRecurringExpense(ctx.Request().Context(), expense)
 	if err != nil {
-		return ctx.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+		return ctx.JSON(statusFromError(err), map[string]string{"error": err.Error()})
 	}
 	return ctx.JSON(http.StatusCreated, created)
 }
@@ -75,9 +75,12 @@ func (h *RecurringExpenseHandler) GetRecurringExpenseByID(ctx echo.Context) erro
 
 // ListRecurringExpenses godoc
 // @Summary Lista despesas recorrentes do usuário
+// @Description Por padrão lista apenas os modelos (despesas sem template_id); as ocorrências geradas a partir deles são listadas com generated=true ou template_id.
 // @Tags RecurringExpense
 // @Produce json
 // @Security bearerAuth
+// @Param generated query bool false "Lista as ocorrências geradas em vez dos modelos"
+// @Param template_id query string false "ID do modelo cujas ocorrências geradas serão listadas"
 // @Param category_id query int false "ID da categoria"
 // @Param card_id query string false "ID do cartão"
 // @Param frequency query string false "Frequência"
@@ -99,6 +102,17 @@ func (h *RecurringExpenseHandler) ListRecurringExpenses(ctx echo.Context) error
 
 	filters := irepository.RecurringExpenseFilters{}
 
+	if v := ctx.QueryParam("generated"); v != "" {
+		if generated, err := strconv.ParseBool(v); err == nil {
+			filters.Generated = generated
+		}
+	}
+	if v := ctx.QueryParam("template_id"); v != "" {
+		if templateID, err := uuid.Parse(v); err == nil {
+			filters.TemplateID = &templateID
+		}
+	}
+
 	if v := ctx.QueryParam("category_id"); v != "" {
 		if id, err := strconv.Atoi(v); err == nil {
 			filters.CategoryID = &id
@@ -152,6 +166,7 @@ func (h *RecurringExpenseHandler) ListRecurringExpenses(ctx echo.Context) error
 
 // UpdateRecurringExpense godoc
 // @Summary Atualiza uma despesa recorrente
+// @Description Ao atualizar um modelo, as ocorrências já geradas com data a partir de hoje são apagadas e geradas novamente com os novos dados, até a data da última delas. As ocorrências passadas são mantidas.
 // @Tags RecurringExpense
 // @Accept json
 // @Produce json
@@ -177,7 +192,7 @@ func (h *RecurringExpenseHandler) UpdateRecurringExpense(ctx echo.Context) error
 	}
 	updated, err := h.svc.UpdateRecurringExpense(ctx.Request().Context(), expense)
 	if err != nil {
-		return ctx.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+		return ctx.JSON(statusFromError(err), map[string]string{"error": err.Error()})
 	}
 	return ctx.JSON(http.StatusOK, updated)
 }
@@ -218,6 +233,7 @@ func (h *RecurringExpenseHandler) DeleteRecurringExpense(ctx echo.Context) error
 // @Success 200 {object} map[string]interface{}
 // @Failure 401 {object} map[string]string
 // @Failure 400 {object} map[string]string
+// @Failure 422 {object} map[string]string
 // @Failure 500 {object} map[string]string
 // @Router /expenses/recurring/summary [get]
 func (h *RecurringExpenseHandler) GetRecurringExpenseSummary(ctx echo.Context) error {
@@ -237,18 +253,19 @@ func (h *RecurringExpenseHandler) GetRecurringExpenseSummary(ctx echo.Context) e
 	}
 	summary, err := h.svc.GetRecurringExpenseSummary(ctx.Request().Context(), userID, startDate, endDate)
 	if err != nil {
-		return ctx.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+		return ctx.JSON(statusFromError(err), map[string]string{"error": err.Error()})
 	}
 	return ctx.JSON(http.StatusOK, summary)
 }
 
 // GenerateRecurringExpenses godoc
 // @Summary Gera despesas recorrentes para uma data alvo
+// @Description Cada modelo só gera as ocorrências posteriores à data até a qual já foi gerado (generated_through), então ocorrências removidas ou com a data alterada não voltam a ser geradas.
 // @Tags RecurringExpense
 // @Produce json
 // @Security bearerAuth
 // @Param target_date query string true "Data alvo (YYYY-MM-DD)"
-// @Success 200 {object} map[string]string
+// @Success 200 {object} map[string]interface{}
 // @Failure 401 {object} map[string]string
 // @Failure 400 {object} map[string]string
 // @Failure 500 {object} map[string]string
@@ -263,8 +280,9 @@ func (h *RecurringExpenseHandler) GenerateRecurringExpenses(ctx echo.Context) er
 	if err != nil {
 		return ctx.JSON(http.StatusBadRequest, map[string]string{"error": "invalid target date"})
 	}
-	if err := h.svc.GenerateRecurringExpenses(ctx.Request().Context(), userID, targetDate); err != nil {
+	generated, err := h.svc.GenerateRecurringExpenses(ctx.Request().Context(), userID, targetDate)
+	if err != nil {
 		return ctx.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
 	}
-	return ctx.JSON(http.StatusOK, map[string]string{"status": "generated"})
+	return ctx.JSON(http.StatusOK, map[string]interface{}{"status": "generated", "generated": generated})
 }
@@ -0,0 +1,46 @@
+package handlers
+
+import (
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/misalima/my-budget-planner-backend/internal/core/interfaces/iservice"
+	"net/http"
+	"time"
+)
+
+type ReportHandler struct {
+	svc iservice.ReportManager
+}
+
+func NewReportHandler(svc iservice.ReportManager) *ReportHandler {
+	return &ReportHandler{svc: svc}
+}
+
+// GetMonthlyReport godoc
+// @Summary Relatório financeiro mensal com totais por categoria, comparação com o mês anterior e maiores despesas
+// @Tags Report
+// @Produce json
+// @Security bearerAuth
+// @Param month query string true "Mês do relatório (YYYY-MM)"
+// @Success 200 {object} domain.MonthlyReport
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 422 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /reports/monthly [get]
+func (h *ReportHandler) GetMonthlyReport(ctx echo.Context) error {
+	userID, ok := ctx.Get("user_id").(uuid.UUID)
+	if !ok || userID == uuid.Nil {
+		return ctx.JSON(http.StatusUnauthorized, map[string]string{"error": "invalid user id from token"})
+	}
+	month, err := time.Parse("2006-01", ctx.QueryParam("month"))
+	if err != nil {
+		return ctx.JSON(http.StatusBadRequest, map[string]string{"error": "invalid month, expected YYYY-MM"})
+	}
+	report, err := h.svc.GetMonthlyReport(ctx.Request().Context(), userID, month.Year(), month.Month())
+	if err != nil {
+		return ctx.JSON(statusFromError(err), map[string]string{"error": err.Error()})
+	}
+	return ctx.JSON(http.StatusOK, report)
+}
@@ -41,7 +41,7 @@ func (h *SimpleExpenseHandler) CreateSimpleExpense(ctx echo.Context) error {
 	}
 	created, err := h.svc.CreateSimpleExpense(ctx.Request().Context(), expense)
 	if err != nil {
-		return ctx.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+		return ctx.JSON(statusFromError(err), map[string]string{"error": err.Error()})
 	}
 	return ctx.JSON(http.StatusCreated, created)
 }
@@ -170,7 +170,7 @@ func (h *SimpleExpenseHandler) UpdateSimpleExpense(ctx echo.Context) error {
 	}
 	updated, err := h.svc.UpdateSimpleExpense(ctx.Request().Context(), expense)
 	if err != nil {
-		return ctx.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+		return ctx.JSON(statusFromError(err), map[string]string{"error": err.Error()})
 	}
 	return ctx.JSON(http.StatusOK, updated)
 }
@@ -209,7 +209,9 @@ func (h *SimpleExpenseHandler) DeleteSimpleExpense(ctx echo.Context) error {
 // @Param start_date query string false "Data inicial (YYYY-MM-DD)"
 // @Param end_date query string false "Data final (YYYY-MM-DD)"
 // @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]string
 // @Failure 401 {object} map[string]string
+// @Failure 422 {object} map[string]string
 // @Router /expenses/simple/summary [get]
 func (h *SimpleExpenseHandler) GetSimpleExpenseSummary(ctx echo.Context) error {
 	userID, ok := ctx.Get("user_id").(uuid.UUID)
@@ -228,7 +230,7 @@ func (h *SimpleExpenseHandler) GetSimpleExpenseSummary(ctx echo.Context) error {
 	}
 	summary, err := h.svc.GetSimpleExpenseSummary(ctx.Request().Context(), userID, startDate, endDate)
 	if err != nil {
-		return ctx.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+		return ctx.JSON(statusFromError(err), map[string]string{"error": err.Error()})
 	}
 	return ctx.JSON(http.StatusOK, summary)
 }
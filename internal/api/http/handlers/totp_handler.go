@@ -0,0 +1,134 @@
+package handlers
+
+import (
+	"errors"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/misalima/my-budget-planner-backend/internal/api/http/handlers/dto"
+	"github.com/misalima/my-budget-planner-backend/internal/core/domain"
+	"net/http"
+)
+
+// StartTOTPEnrollment godoc
+// @Summary Inicia a ativação da autenticação em dois fatores (TOTP)
+// @Description Gera o segredo a ser cadastrado no aplicativo autenticador, também como URI otpauth para QR code. A autenticação em dois fatores só passa a valer após POST /auth/totp/confirm.
+// @Tags Auth
+// @Produce json
+// @Security bearerAuth
+// @Success 201 {object} domain.TOTPEnrollment
+// @Failure 401 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /auth/totp [post]
+func (a *AuthHandler) StartTOTPEnrollment(ctx echo.Context) error {
+	userId, ok := ctx.Get("user_id").(uuid.UUID)
+	if !ok || userId == uuid.Nil {
+		return ctx.JSON(http.StatusUnauthorized, map[string]string{"error": "Invalid user"})
+	}
+
+	enrollment, err := a.AuthService.StartTOTPEnrollment(ctx.Request().Context(), userId)
+	if err != nil {
+		return ctx.JSON(statusFromError(err), map[string]string{"error": err.Error()})
+	}
+
+	return ctx.JSON(http.StatusCreated, enrollment)
+}
+
+// ConfirmTOTP godoc
+// @Summary Ativa a autenticação em dois fatores com um código do aplicativo autenticador
+// @Description Devolve os códigos de recuperação, de uso único, que não poderão ser consultados novamente.
+// @Tags Auth
+// @Accept json
+// @Produce json
+// @Security bearerAuth
+// @Param code body dto.TOTPCodeDTO true "Código gerado pelo aplicativo autenticador"
+// @Success 200 {object} dto.RecoveryCodesDTO
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /auth/totp/confirm [post]
+func (a *AuthHandler) ConfirmTOTP(ctx echo.Context) error {
+	userId, ok := ctx.Get("user_id").(uuid.UUID)
+	if !ok || userId == uuid.Nil {
+		return ctx.JSON(http.StatusUnauthorized, map[string]string{"error": "Invalid user"})
+	}
+	var req dto.TOTPCodeDTO
+	if err := ctx.Bind(&req); err != nil {
+		return ctx.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid input"})
+	}
+	if req.Code == "" {
+		return ctx.JSON(http.StatusBadRequest, map[string]string{"error": "Code is required"})
+	}
+
+	codes, err := a.AuthService.ConfirmTOTP(ctx.Request().Context(), userId, req.Code)
+	if err != nil {
+		return ctx.JSON(statusFromError(err), map[string]string{"error": err.Error()})
+	}
+
+	return ctx.JSON(http.StatusOK, dto.RecoveryCodesDTO{RecoveryCodes: codes})
+}
+
+// DisableTOTP godoc
+// @Summary Desativa a autenticação em dois fatores
+// @Description Exige um código do aplicativo autenticador ou um código de recuperação. Também cancela uma ativação não confirmada, sem exigir código.
+// @Tags Auth
+// @Accept json
+// @Security bearerAuth
+// @Param code body dto.TOTPCodeDTO true "Código do aplicativo autenticador ou código de recuperação"
+// @Success 204
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 429 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /auth/totp [delete]
+func (a *AuthHandler) DisableTOTP(ctx echo.Context) error {
+	userId, ok := ctx.Get("user_id").(uuid.UUID)
+	if !ok || userId == uuid.Nil {
+		return ctx.JSON(http.StatusUnauthorized, map[string]string{"error": "Invalid user"})
+	}
+	var req dto.TOTPCodeDTO
+	if err := ctx.Bind(&req); err != nil {
+		return ctx.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid input"})
+	}
+
+	if err := a.AuthService.DisableTOTP(ctx.Request().Context(), userId, req.Code); err != nil {
+		return ctx.JSON(statusFromError(err), map[string]string{"error": err.Error()})
+	}
+
+	return ctx.NoContent(http.StatusNoContent)
+}
+
+// CompleteMFALogin godoc
+// @Summary Conclui o login com autenticação em dois fatores
+// @Description Troca o mfa_token devolvido por POST /auth/login, válido por 5 minutos e para um único login, e um código do aplicativo autenticador ou de recuperação pelos tokens de acesso e de atualização. Após 5 códigos inválidos seguidos, novos códigos são recusados por 15 minutos.
+// @Tags Auth
+// @Accept json
+// @Produce json
+// @Param mfa body dto.MFALoginDTO true "Token MFA e código"
+// @Success 200 {object} domain.LoginResult
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 429 {object} map[string]string
+// @Router /auth/mfa [post]
+func (a *AuthHandler) CompleteMFALogin(ctx echo.Context) error {
+	var req dto.MFALoginDTO
+	if err := ctx.Bind(&req); err != nil {
+		return ctx.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid input"})
+	}
+	if req.MFAToken == "" || req.Code == "" {
+		return ctx.JSON(http.StatusBadRequest, map[string]string{"error": "MFA token and code are required"})
+	}
+
+	result, err := a.AuthService.CompleteMFALogin(req.MFAToken, req.Code, sessionClient(ctx, req.DeviceLabel))
+	if errors.Is(err, domain.ErrTOTPLocked) {
+		return ctx.JSON(http.StatusTooManyRequests, map[string]string{"error": err.Error()})
+	}
+	if err != nil {
+		return ctx.JSON(http.StatusUnauthorized, map[string]string{"error": err.Error()})
+	}
+
+	return ctx.JSON(http.StatusOK, result)
+}
@@ -0,0 +1,111 @@
+package handlers
+
+import (
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/misalima/my-budget-planner-backend/internal/core/interfaces/irepository"
+	"github.com/misalima/my-budget-planner-backend/internal/core/interfaces/iservice"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type TransactionHandler struct {
+	svc iservice.TransactionManager
+}
+
+func NewTransactionHandler(svc iservice.TransactionManager) *TransactionHandler {
+	return &TransactionHandler{svc: svc}
+}
+
+// ListTransactions godoc
+// @Summary Lista, em um único extrato paginado, todas as despesas e receitas do usuário
+// @Tags Transaction
+// @Produce json
+// @Security bearerAuth
+// @Param type query string false "Tipos separados por vírgula: simple_expense, recurring_expense, credit_card_expense, income"
+// @Param category_id query int false "ID da categoria"
+// @Param card_id query string false "ID do cartão"
+// @Param start_date query string false "Data inicial (YYYY-MM-DD)"
+// @Param end_date query string false "Data final (YYYY-MM-DD)"
+// @Param currency query string false "Moeda (ISO 4217); obrigatória com min_amount ou max_amount"
+// @Param min_amount query number false "Valor mínimo, na moeda informada"
+// @Param max_amount query number false "Valor máximo, na moeda informada"
+// @Param sort query string false "Ordenação: date (padrão) ou amount"
+// @Param order query string false "Direção: desc (padrão) ou asc"
+// @Param limit query int false "Limite de resultados (padrão 50, máximo 200)"
+// @Param offset query int false "Offset"
+// @Success 200 {object} domain.TransactionPage
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /transactions [get]
+func (h *TransactionHandler) ListTransactions(ctx echo.Context) error {
+	userID, ok := ctx.Get("user_id").(uuid.UUID)
+	if !ok || userID == uuid.Nil {
+		return ctx.JSON(http.StatusUnauthorized, map[string]string{"error": "invalid user id from token"})
+	}
+
+	filters := irepository.TransactionFilters{
+		SortBy:    ctx.QueryParam("sort"),
+		Ascending: ctx.QueryParam("order") == "asc",
+	}
+
+	for _, v := range ctx.QueryParams()["type"] {
+		for _, t := range strings.Split(v, ",") {
+			if t = strings.TrimSpace(t); t != "" {
+				filters.Types = append(filters.Types, t)
+			}
+		}
+	}
+	if v := ctx.QueryParam("category_id"); v != "" {
+		if id, err := strconv.Atoi(v); err == nil {
+			filters.CategoryID = &id
+		}
+	}
+	if v := ctx.QueryParam("card_id"); v != "" {
+		if cardID, err := uuid.Parse(v); err == nil {
+			filters.CardID = &cardID
+		}
+	}
+	if v := ctx.QueryParam("start_date"); v != "" {
+		if t, err := time.Parse("2006-01-02", v); err == nil {
+			filters.StartDate = &t
+		}
+	}
+	if v := ctx.QueryParam("end_date"); v != "" {
+		if t, err := time.Parse("2006-01-02", v); err == nil {
+			filters.EndDate = &t
+		}
+	}
+	if v := ctx.QueryParam("currency"); v != "" {
+		filters.Currency = &v
+	}
+	if v := ctx.QueryParam("min_amount"); v != "" {
+		if f, err := strconv.ParseFloat(v, 64); err == nil {
+			filters.MinAmount = &f
+		}
+	}
+	if v := ctx.QueryParam("max_amount"); v != "" {
+		if f, err := strconv.ParseFloat(v, 64); err == nil {
+			filters.MaxAmount = &f
+		}
+	}
+	if v := ctx.QueryParam("limit"); v != "" {
+		if l, err := strconv.Atoi(v); err == nil {
+			filters.Limit = &l
+		}
+	}
+	if v := ctx.QueryParam("offset"); v != "" {
+		if o, err := strconv.Atoi(v); err == nil {
+			filters.Offset = &o
+		}
+	}
+
+	page, err := h.svc.ListTransactions(ctx.Request().Context(), userID, filters)
+	if err != nil {
+		return ctx.JSON(statusFromError(err), map[string]string{"error": err.Error()})
+	}
+	return ctx.JSON(http.StatusOK, page)
+}
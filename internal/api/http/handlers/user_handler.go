@@ -1,6 +1,7 @@
 package handlers
 
 import (
+	"github.com/google/uuid"
 	"github.com/labstack/echo/v4"
 	"github.com/misalima/my-budget-planner-backend/internal/api/http/handlers/dto"
 	"github.com/misalima/my-budget-planner-backend/internal/core/domain"
@@ -45,14 +46,44 @@ func (h *UserHandler) CreateUserHandler(ctx echo.Context) error {
 		return ctx.JSON(http.StatusBadRequest, map[string]string{"error": "All fields (username, first_name, last_name, email, and password) are required"})
 	}
 	user := domain.User{
-		Username:  req.Username,
-		FirstName: req.FirstName,
-		LastName:  req.LastName,
-		Email:     req.Email,
-		Password:  req.Password,
+		Username:     req.Username,
+		FirstName:    req.FirstName,
+		LastName:     req.LastName,
+		Email:        req.Email,
+		Password:     req.Password,
+		BaseCurrency: req.BaseCurrency,
 	}
 	if err := h.UserService.RegisterUser(&user); err != nil {
 		return ctx.JSON(http.StatusConflict, map[string]string{"error": err.Error()})
 	}
 	return ctx.JSON(http.StatusCreated, map[string]string{"message": "User created successfully"})
 }
+
+// UpdateBaseCurrency godoc
+// @Summary Altera a moeda base do usuário, usada para converter resumos, orçamentos e relatórios
+// @Tags User
+// @Accept json
+// @Produce json
+// @Security bearerAuth
+// @Param currency body dto.BaseCurrencyDTO true "Código ISO 4217 da moeda base"
+// @Success 200 {object} dto.BaseCurrencyDTO
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /users/me/base-currency [put]
+func (h *UserHandler) UpdateBaseCurrency(ctx echo.Context) error {
+	var req dto.BaseCurrencyDTO
+	if err := ctx.Bind(&req); err != nil {
+		return ctx.JSON(http.StatusBadRequest, map[string]string{"error": "invalid request data"})
+	}
+	userID, ok := ctx.Get("user_id").(uuid.UUID)
+	if !ok || userID == uuid.Nil {
+		return ctx.JSON(http.StatusUnauthorized, map[string]string{"error": "invalid user id from token"})
+	}
+	currency, err := h.UserService.UpdateBaseCurrency(ctx.Request().Context(), userID, req.BaseCurrency)
+	if err != nil {
+		return ctx.JSON(statusFromError(err), map[string]string{"error": err.Error()})
+	}
+	return ctx.JSON(http.StatusOK, dto.BaseCurrencyDTO{BaseCurrency: currency})
+}
@@ -17,19 +17,53 @@ func LoadRoutes(
 	simpleExpenseHandler *handlers.SimpleExpenseHandler,
 	recurringExpenseHandler *handlers.RecurringExpenseHandler,
 	creditCardExpenseHandler *handlers.CreditCardExpenseHandler,
+	budgetHandler *handlers.BudgetHandler,
+	cardPaymentHandler *handlers.CardPaymentHandler,
+	incomeHandler *handlers.IncomeHandler,
+	transactionHandler *handlers.TransactionHandler,
+	reportHandler *handlers.ReportHandler,
+	exchangeRateHandler *handlers.ExchangeRateHandler,
+	importHandler *handlers.ImportHandler,
+	exportHandler *handlers.ExportHandler,
+	calendarHandler *handlers.CalendarHandler,
 ) {
 	api := e.Group("/api")
+	jwtMiddleware := auth.JWTMiddleware(authHandler.AuthService)
 
 	api.GET("/health", handlers.HealthHandler)
 	api.POST("/users", userHandler.CreateUserHandler)
 
+	//current user routes
+	meGroup := api.Group("/users/me")
+	meGroup.Use(jwtMiddleware)
+	meGroup.Use(auth.ExtractUserIDMiddleware)
+	meGroup.PUT("/base-currency", userHandler.UpdateBaseCurrency)
+
 	//auth routes
 	api.POST("/auth/login", authHandler.Login)
-	api.GET("/auth/refresh", authHandler.RefreshTokenHandler)
+	api.POST("/auth/refresh", authHandler.RefreshTokenHandler)
+	api.POST("/auth/logout", authHandler.Logout)
+	api.POST("/auth/logout-all", authHandler.LogoutAll, jwtMiddleware, auth.ExtractUserIDMiddleware)
+	api.POST("/auth/mfa", authHandler.CompleteMFALogin)
+
+	//two-factor authentication routes
+	totpGroup := api.Group("/auth/totp")
+	totpGroup.Use(jwtMiddleware)
+	totpGroup.Use(auth.ExtractUserIDMiddleware)
+	totpGroup.POST("", authHandler.StartTOTPEnrollment)
+	totpGroup.POST("/confirm", authHandler.ConfirmTOTP)
+	totpGroup.DELETE("", authHandler.DisableTOTP)
+
+	//session routes
+	sessionGroup := api.Group("/sessions")
+	sessionGroup.Use(jwtMiddleware)
+	sessionGroup.Use(auth.ExtractUserIDMiddleware)
+	sessionGroup.GET("", authHandler.ListSessions)
+	sessionGroup.DELETE("/:id", authHandler.RevokeSession)
 
 	//category routes
 	categoryGroup := api.Group("/category")
-	categoryGroup.Use(auth.JWTMiddleware())
+	categoryGroup.Use(jwtMiddleware)
 	categoryGroup.Use(auth.ExtractUserIDMiddleware)
 	categoryGroup.GET("", categoryHandler.GetCategoriesByUserID)
 	categoryGroup.POST("", categoryHandler.CreateCategory)
@@ -37,15 +71,21 @@ func LoadRoutes(
 
 	//credit card routes
 	creditCardGroup := api.Group("/credit-cards")
-	creditCardGroup.Use(auth.JWTMiddleware())
+	creditCardGroup.Use(jwtMiddleware)
 	creditCardGroup.Use(auth.ExtractUserIDMiddleware)
 	creditCardGroup.GET("", creditCardHandler.GetAllCreditCards)
 	creditCardGroup.POST("", creditCardHandler.CreateCreditCard)
 	creditCardGroup.DELETE(":id", creditCardHandler.DeleteCreditCard)
+	creditCardGroup.GET("/:id/statements/:month", creditCardHandler.GetStatement)
+	creditCardGroup.POST("/:id/recompute-limit", creditCardHandler.RecomputeLimit)
+	creditCardGroup.GET("/:id/payments", cardPaymentHandler.ListCardPayments)
+	creditCardGroup.POST("/:id/payments", cardPaymentHandler.CreateCardPayment)
+	creditCardGroup.GET("/:id/payments/:payment_id", cardPaymentHandler.GetCardPaymentByID)
+	creditCardGroup.DELETE("/:id/payments/:payment_id", cardPaymentHandler.DeleteCardPayment)
 
 	// expenses routes
 	expenseGroup := api.Group("/expenses")
-	expenseGroup.Use(auth.JWTMiddleware())
+	expenseGroup.Use(jwtMiddleware)
 	expenseGroup.Use(auth.ExtractUserIDMiddleware)
 
 	// Simple expenses
@@ -76,5 +116,77 @@ func LoadRoutes(
 	creditCardExpenseGroup.DELETE(":id", creditCardExpenseHandler.DeleteCreditCardExpense)
 	creditCardExpenseGroup.GET("/summary", creditCardExpenseHandler.GetCreditCardExpenseSummary)
 
+	//budget routes
+	budgetGroup := api.Group("/budgets")
+	budgetGroup.Use(jwtMiddleware)
+	budgetGroup.Use(auth.ExtractUserIDMiddleware)
+	budgetGroup.GET("", budgetHandler.ListBudgets)
+	budgetGroup.POST("", budgetHandler.CreateBudget)
+	budgetGroup.GET("/consumption", budgetHandler.ListBudgetConsumptions)
+	budgetGroup.GET("/:id", budgetHandler.GetBudgetByID)
+	budgetGroup.PUT("/:id", budgetHandler.UpdateBudget)
+	budgetGroup.DELETE("/:id", budgetHandler.DeleteBudget)
+	budgetGroup.GET("/:id/consumption", budgetHandler.GetBudgetConsumption)
+	budgetGroup.GET("/:id/periods", budgetHandler.ListBudgetPeriods)
+
+	//income routes
+	incomeGroup := api.Group("/incomes")
+	incomeGroup.Use(jwtMiddleware)
+	incomeGroup.Use(auth.ExtractUserIDMiddleware)
+	incomeGroup.GET("", incomeHandler.ListIncomes)
+	incomeGroup.POST("", incomeHandler.CreateIncome)
+	incomeGroup.GET("/summary", incomeHandler.GetIncomeSummary)
+	incomeGroup.GET("/cash-flow", incomeHandler.GetCashFlow)
+	incomeGroup.GET("/:id", incomeHandler.GetIncomeByID)
+	incomeGroup.PUT("/:id", incomeHandler.UpdateIncome)
+	incomeGroup.DELETE("/:id", incomeHandler.DeleteIncome)
+
+	//transaction routes
+	transactionGroup := api.Group("/transactions")
+	transactionGroup.Use(jwtMiddleware)
+	transactionGroup.Use(auth.ExtractUserIDMiddleware)
+	transactionGroup.GET("", transactionHandler.ListTransactions)
+
+	//report routes
+	reportGroup := api.Group("/reports")
+	reportGroup.Use(jwtMiddleware)
+	reportGroup.Use(auth.ExtractUserIDMiddleware)
+	reportGroup.GET("/monthly", reportHandler.GetMonthlyReport)
+
+	//exchange rate routes
+	exchangeRateGroup := api.Group("/exchange-rates")
+	exchangeRateGroup.Use(jwtMiddleware)
+	exchangeRateGroup.Use(auth.ExtractUserIDMiddleware)
+	exchangeRateGroup.GET("", exchangeRateHandler.ListExchangeRates)
+
+	//import routes
+	importGroup := api.Group("/imports")
+	importGroup.Use(jwtMiddleware)
+	importGroup.Use(auth.ExtractUserIDMiddleware)
+	importGroup.POST("/csv", importHandler.ImportCSV)
+	importGroup.POST("/ofx", importHandler.ImportOFX)
+	importGroup.POST("/card-statement", importHandler.ImportCardStatement)
+
+	//archive import routes
+	archiveImportGroup := api.Group("/import")
+	archiveImportGroup.Use(jwtMiddleware)
+	archiveImportGroup.Use(auth.ExtractUserIDMiddleware)
+	archiveImportGroup.POST("/archive", importHandler.ImportArchive)
+
+	//export routes
+	exportGroup := api.Group("/export")
+	exportGroup.Use(jwtMiddleware)
+	exportGroup.Use(auth.ExtractUserIDMiddleware)
+	exportGroup.GET("", exportHandler.ExportAccount)
+	exportGroup.GET("/ledger", exportHandler.ExportLedger)
+
+	//calendar routes
+	api.GET("/calendar/feed/:token", calendarHandler.GetCalendarFeed)
+	calendarGroup := api.Group("/calendar")
+	calendarGroup.Use(jwtMiddleware)
+	calendarGroup.Use(auth.ExtractUserIDMiddleware)
+	calendarGroup.POST("/feed", calendarHandler.CreateCalendarFeed)
+	calendarGroup.DELETE("/feed", calendarHandler.RevokeCalendarFeed)
+
 	e.GET("/swagger/*", echoSwagger.WrapHandler)
 }
@@ -0,0 +1,107 @@
+package domain
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+const (
+	BudgetPeriodCustom  = "custom"
+	BudgetPeriodWeekly  = "weekly"
+	BudgetPeriodMonthly = "monthly"
+	BudgetPeriodYearly  = "yearly"
+)
+
+type Budget struct {
+	ID          int        `json:"id" db:"ID"`
+	UserID      uuid.UUID  `json:"user_id" db:"user_id"`
+	Name        string     `json:"budget_name" db:"budget_name"`
+	Description *string    `json:"description" db:"description"`
+	Amount      Money      `json:"amount" db:"amount"`
+	Period      string     `json:"period" db:"period"`
+	Rollover    bool       `json:"rollover" db:"rollover"`
+	CategoryIDs []int      `json:"category_ids"`
+	StartDate   time.Time  `json:"start_date" db:"start_date"`
+	EndDate     *time.Time `json:"end_date" db:"end_date"`
+	CreatedAt   time.Time  `json:"created_at" db:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at" db:"updated_at"`
+}
+
+// DateRange is an inclusive range of calendar dates.
+type DateRange struct {
+	Start time.Time `json:"start"`
+	End   time.Time `json:"end"`
+}
+
+func (r DateRange) Contains(date time.Time) bool {
+	return !date.Before(r.Start) && !date.After(r.End)
+}
+
+// BudgetConsumption reports how much of a budget was spent by the expenses dated inside one of its periods.
+// Budgets are kept in the user's base currency, into which every expense is converted.
+type BudgetConsumption struct {
+	Budget                  Budget    `json:"budget"`
+	PeriodStart             time.Time `json:"period_start"`
+	PeriodEnd               time.Time `json:"period_end"`
+	Currency                string    `json:"currency"`
+	CarriedOver             Money     `json:"carried_over"`
+	AvailableAmount         Money     `json:"available_amount"`
+	SimpleExpensesTotal     Money     `json:"simple_expenses_total"`
+	RecurringExpensesTotal  Money     `json:"recurring_expenses_total"`
+	CreditCardExpensesTotal Money     `json:"credit_card_expenses_total"`
+	SpentAmount             Money     `json:"spent_amount"`
+	RemainingAmount         Money     `json:"remaining_amount"`
+	PercentageUsed          float64   `json:"percentage_used"`
+}
+
+// CoversCategory reports whether expenses of the category count against the budget.
+// A budget without categories covers every category.
+func (b Budget) CoversCategory(categoryID int) bool {
+	if len(b.CategoryIDs) == 0 {
+		return true
+	}
+	for _, id := range b.CategoryIDs {
+		if id == categoryID {
+			return true
+		}
+	}
+	return false
+}
+
+// PeriodsUntil returns the consecutive periods of the budget, from StartDate up to
+// and including the one that contains until, never going past EndDate.
+func (b Budget) PeriodsUntil(until time.Time) []DateRange {
+	var periods []DateRange
+	if b.Period == BudgetPeriodCustom {
+		if b.EndDate != nil && !until.Before(b.StartDate) {
+			periods = append(periods, DateRange{Start: b.StartDate, End: *b.EndDate})
+		}
+		return periods
+	}
+	for n := 0; ; n++ {
+		start, ok := b.periodStart(n)
+		if !ok || start.After(until) || (b.EndDate != nil && start.After(*b.EndDate)) {
+			break
+		}
+		next, _ := b.periodStart(n + 1)
+		end := next.AddDate(0, 0, -1)
+		if b.EndDate != nil && end.After(*b.EndDate) {
+			end = *b.EndDate
+		}
+		periods = append(periods, DateRange{Start: start, End: end})
+	}
+	return periods
+}
+
+func (b Budget) periodStart(n int) (time.Time, bool) {
+	switch b.Period {
+	case BudgetPeriodWeekly:
+		return b.StartDate.AddDate(0, 0, 7*n), true
+	case BudgetPeriodMonthly:
+		return AddMonthsClamped(b.StartDate, n), true
+	case BudgetPeriodYearly:
+		return AddMonthsClamped(b.StartDate, 12*n), true
+	default:
+		return time.Time{}, false
+	}
+}
@@ -0,0 +1,23 @@
+package domain
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+// CalendarFeed is the token that opens the iCalendar feed of a user. Only a hash of the
+// token is stored, so Token and URL are only known when the feed is created.
+type CalendarFeed struct {
+	UserID    uuid.UUID `json:"user_id"`
+	Token     string    `json:"token,omitempty"`
+	URL       string    `json:"url,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// CalendarEvent is an all-day event of the iCalendar feed.
+type CalendarEvent struct {
+	UID         string
+	Date        time.Time
+	Summary     string
+	Description string
+}
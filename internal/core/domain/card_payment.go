@@ -0,0 +1,20 @@
+package domain
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+// CardPayment is a payment, full or partial, made towards one statement of a card.
+// StatementMonth is the first day of the month in which that statement is due.
+type CardPayment struct {
+	ID             uuid.UUID `json:"id"`
+	UserID         uuid.UUID `json:"user_id"`
+	CardID         uuid.UUID `json:"card_id"`
+	StatementMonth time.Time `json:"statement_month"`
+	Amount         Money     `json:"amount"`
+	PaidAt         time.Time `json:"paid_at"`
+	Description    *string   `json:"description"`
+	CreatedAt      time.Time `json:"created_at"`
+	UpdatedAt      time.Time `json:"updated_at"`
+}
@@ -2,8 +2,14 @@ package domain
 
 import "github.com/google/uuid"
 
+const (
+	CategoryKindExpense = "expense"
+	CategoryKindIncome  = "income"
+)
+
 type Category struct {
 	ID     int       `json:"id"`
 	Name   string    `json:"category_name"`
+	Kind   string    `json:"kind"`
 	UserID uuid.UUID `json:"user_id"`
 }
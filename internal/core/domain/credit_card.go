@@ -9,9 +9,55 @@ type CreditCard struct {
 	ID           uuid.UUID `json:"id"`
 	UserID       uuid.UUID `json:"user_id"`
 	CardName     string    `json:"card_name"`
-	TotalLimit   float64   `json:"total_limit"`
-	CurrentLimit float64   `json:"current_limit"`
+	TotalLimit   Money     `json:"total_limit"`
+	CurrentLimit Money     `json:"current_limit"`
+	Currency     string    `json:"currency"`
+	ClosingDay   int       `json:"closing_day"`
 	DueDate      int       `json:"due_date"`
 	CreatedAt    time.Time `json:"created_at"`
 	UpdatedAt    time.Time `json:"updated_at"`
 }
+
+// DefaultClosingDay is used for cards registered without a closing day: a week before the due date.
+func DefaultClosingDay(dueDay int) int {
+	if dueDay > 7 {
+		return dueDay - 7
+	}
+	return dueDay + 21
+}
+
+// StatementDates returns the billing cycle, closing date and due date of the
+// statement due in the given month. When the closing day is not before the due
+// day, the statement closes in the previous month.
+func (c CreditCard) StatementDates(year int, month time.Month) (cycle DateRange, closingDate, dueDate time.Time) {
+	dueDate = dateInMonth(year, month, c.DueDate)
+	closingMonth := month
+	if c.ClosingDay >= c.DueDate {
+		closingMonth--
+	}
+	closingDate = dateInMonth(year, closingMonth, c.ClosingDay)
+	previousClosing := dateInMonth(year, closingMonth-1, c.ClosingDay)
+	cycle = DateRange{Start: previousClosing.AddDate(0, 0, 1), End: closingDate}
+	return cycle, closingDate, dueDate
+}
+
+// StatementMonthFor returns the first day of the month in which the first
+// statement due on or after date is due.
+func (c CreditCard) StatementMonthFor(date time.Time) time.Time {
+	month := time.Date(date.Year(), date.Month(), 1, 0, 0, 0, 0, time.UTC)
+	if date.After(dateInMonth(date.Year(), date.Month(), c.DueDate)) {
+		month = month.AddDate(0, 1, 0)
+	}
+	return month
+}
+
+// dateInMonth builds a UTC date, clamping day to the length of the month. Months
+// outside 1..12 are normalized into the adjacent years.
+func dateInMonth(year int, month time.Month, day int) time.Time {
+	first := time.Date(year, month, 1, 0, 0, 0, 0, time.UTC)
+	lastDay := first.AddDate(0, 1, -1).Day()
+	if day > lastDay {
+		day = lastDay
+	}
+	return first.AddDate(0, 0, day-1)
+}
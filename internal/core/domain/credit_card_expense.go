@@ -9,11 +9,12 @@ type CreditCardExpense struct {
 	ID                   uuid.UUID `json:"id" db:"ID"`
 	UserID               uuid.UUID `json:"user_id" db:"user_id"`
 	CategoryID           int       `json:"category_id" db:"category_id"`
-	Amount               float64   `json:"amount" db:"amount"`
+	Amount               Money     `json:"amount" db:"amount"`
+	Currency             string    `json:"currency" db:"currency"`
 	Description          *string   `json:"description" db:"description"`
 	Date                 time.Time `json:"date" db:"date"`
 	CardID               uuid.UUID `json:"card_id" db:"card_id"`
-	InstallmentAmount    float64   `json:"installment_amount" db:"installment_amount"`
+	InstallmentAmount    Money     `json:"installment_amount" db:"installment_amount"`
 	InstallmentsQuantity int       `json:"installments_quantity" db:"installments_quantity"`
 	ParcelNumber         int       `json:"parcel_number" db:"parcel_number"`
 	CreatedAt            time.Time `json:"created_at" db:"created_at"`
@@ -21,10 +22,20 @@ type CreditCardExpense struct {
 }
 
 type CreditCardExpenseSummary struct {
-	TotalAmount          float64
+	Currency             string
+	TotalAmount          Money
 	TotalCount           int
-	AverageAmount        float64
-	ByCard               map[uuid.UUID]float64
-	ByCategory           map[int]float64
-	ByInstallmentsNumber map[int]float64
+	AverageAmount        Money
+	ByCard               map[uuid.UUID]Money
+	ByCategory           map[int]Money
+	ByInstallmentsNumber map[int]Money
+}
+
+// InstallmentValue is the amount this row charges to the card: the parcel value
+// for installment purchases, or the full amount for single payments.
+func (e CreditCardExpense) InstallmentValue() Money {
+	if e.InstallmentAmount != 0 {
+		return e.InstallmentAmount
+	}
+	return e.Amount
 }
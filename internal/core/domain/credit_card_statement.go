@@ -0,0 +1,35 @@
+package domain
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+const (
+	StatementStatusOpen   = "open"
+	StatementStatusClosed = "closed"
+	StatementStatusPaid   = "paid"
+	// StatementStatusPartiallyPaid marks a closed statement that received payments
+	// but still has an outstanding balance, which is carried into the next one.
+	StatementStatusPartiallyPaid = "partially_paid"
+)
+
+// CreditCardStatement is the bill (fatura) of a card for one billing cycle.
+// TotalDue is the balance left unpaid by earlier statements plus the installments
+// of the cycle, minus the payments made towards this statement. Every amount is in
+// the currency of the card.
+type CreditCardStatement struct {
+	CardID          uuid.UUID           `json:"card_id"`
+	Currency        string              `json:"currency"`
+	Reference       string              `json:"reference"`
+	PeriodStart     time.Time           `json:"period_start"`
+	ClosingDate     time.Time           `json:"closing_date"`
+	DueDate         time.Time           `json:"due_date"`
+	Installments    []CreditCardExpense `json:"installments"`
+	Payments        []CardPayment       `json:"payments"`
+	PreviousBalance Money               `json:"previous_balance"`
+	PurchasesTotal  Money               `json:"purchases_total"`
+	PaymentsTotal   Money               `json:"payments_total"`
+	TotalDue        Money               `json:"total_due"`
+	Status          string              `json:"status"`
+}
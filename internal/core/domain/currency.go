@@ -0,0 +1,40 @@
+package domain
+
+import (
+	"strings"
+	"time"
+)
+
+// DefaultCurrency is the base currency of users that never chose one, and the
+// currency of every amount registered before currencies existed.
+const DefaultCurrency = "BRL"
+
+// ExchangeRate says how many units of QuoteCurrency one unit of BaseCurrency
+// bought on Date.
+type ExchangeRate struct {
+	Date          time.Time `json:"date"`
+	BaseCurrency  string    `json:"base_currency"`
+	QuoteCurrency string    `json:"quote_currency"`
+	Rate          float64   `json:"rate"`
+	CreatedAt     time.Time `json:"created_at"`
+	UpdatedAt     time.Time `json:"updated_at"`
+}
+
+// NormalizeCurrency trims and upper-cases a currency code, so "usd " becomes "USD".
+func NormalizeCurrency(code string) string {
+	return strings.ToUpper(strings.TrimSpace(code))
+}
+
+// IsValidCurrency reports whether code looks like an ISO 4217 code: three
+// upper-case letters.
+func IsValidCurrency(code string) bool {
+	if len(code) != 3 {
+		return false
+	}
+	for i := 0; i < len(code); i++ {
+		if code[i] < 'A' || code[i] > 'Z' {
+			return false
+		}
+	}
+	return true
+}
@@ -3,3 +3,24 @@ package domain
 import "errors"
 
 var ErrNotFound = errors.New("resource not found")
+var ErrInvalidInput = errors.New("invalid input")
+
+// ErrRefreshTokenReused is returned when a refresh token that was already used or
+// revoked is presented again.
+var ErrRefreshTokenReused = errors.New("refresh token reuse detected")
+
+// ErrInvalidTOTPCode is returned when a two-factor code is wrong, was already used or
+// the recovery code does not exist.
+var ErrInvalidTOTPCode = errors.New("invalid two-factor code")
+var ErrTOTPAlreadyEnabled = errors.New("two-factor authentication is already enabled")
+
+// ErrTOTPLocked is returned while two-factor codes are refused after too many wrong ones.
+var ErrTOTPLocked = errors.New("too many invalid two-factor codes, try again later")
+
+// ErrMFATokenUsed is returned when an MFA token that already completed a login is
+// presented again.
+var ErrMFATokenUsed = errors.New("mfa token was already used")
+
+// ErrMissingExchangeRate is returned when an amount has to be converted into a currency
+// and no rate between the two is known on or before its date.
+var ErrMissingExchangeRate = errors.New("missing exchange rate")
@@ -0,0 +1,111 @@
+package domain
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+const (
+	// ExportFormat names the archive produced by an account export.
+	ExportFormat = "my-budget-planner-export"
+	// ExportFormatVersion is bumped whenever the archive layout or the fields of its
+	// files change, so that an import knows how to read an archive.
+	ExportFormatVersion = 2
+)
+
+// ExportManifest describes an export archive: its format version, when and from
+// which account it was made, and how many records each of its files holds.
+type ExportManifest struct {
+	Format     string         `json:"format"`
+	Version    int            `json:"version"`
+	ExportedAt time.Time      `json:"exported_at"`
+	UserID     uuid.UUID      `json:"user_id"`
+	Counts     map[string]int `json:"counts"`
+}
+
+// ExportProfile is the user profile as exported, without the password hash.
+type ExportProfile struct {
+	ID               uuid.UUID `json:"id"`
+	Username         string    `json:"username"`
+	FirstName        string    `json:"first_name"`
+	LastName         string    `json:"last_name"`
+	Email            string    `json:"email"`
+	ProfilePicture   string    `json:"profile_picture"`
+	Income           Money     `json:"income"`
+	ExpenditureLimit Money     `json:"expenditure_limit"`
+	BaseCurrency     string    `json:"base_currency"`
+	CreatedAt        time.Time `json:"created_at"`
+	UpdatedAt        time.Time `json:"updated_at"`
+}
+
+// AccountExport is everything stored for one user. Categories holds the user's own
+// categories and the shared ones, which have no user ID.
+type AccountExport struct {
+	Manifest           ExportManifest
+	Profile            ExportProfile
+	Categories         []Category
+	CreditCards        []CreditCard
+	SimpleExpenses     []SimpleExpense
+	RecurringExpenses  []RecurringExpense
+	CreditCardExpenses []CreditCardExpense
+	Budgets            []Budget
+	Incomes            []Income
+	CardPayments       []CardPayment
+}
+
+// ArchiveBundle is the portable JSON bundle an archive import restores into an account.
+// Export archives carry one as bundle.json. Records refer to categories and cards by
+// their IDs in the bundle, which the import remaps. Budgets, incomes and card payments
+// were added in version 2.
+type ArchiveBundle struct {
+	Format             string              `json:"format"`
+	Version            int                 `json:"version"`
+	Categories         []Category          `json:"categories"`
+	CreditCards        []CreditCard        `json:"credit_cards"`
+	SimpleExpenses     []SimpleExpense     `json:"simple_expenses"`
+	RecurringExpenses  []RecurringExpense  `json:"recurring_expenses"`
+	CreditCardExpenses []CreditCardExpense `json:"credit_card_expenses"`
+	Budgets            []Budget            `json:"budgets"`
+	Incomes            []Income            `json:"incomes"`
+	CardPayments       []CardPayment       `json:"card_payments"`
+}
+
+// Bundle returns the part of the export an archive import restores.
+func (e AccountExport) Bundle() ArchiveBundle {
+	return ArchiveBundle{
+		Format:             e.Manifest.Format,
+		Version:            e.Manifest.Version,
+		Categories:         e.Categories,
+		CreditCards:        e.CreditCards,
+		SimpleExpenses:     e.SimpleExpenses,
+		RecurringExpenses:  e.RecurringExpenses,
+		CreditCardExpenses: e.CreditCardExpenses,
+		Budgets:            e.Budgets,
+		Incomes:            e.Incomes,
+		CardPayments:       e.CardPayments,
+	}
+}
+
+// Plain-text accounting formats a ledger export can be written in. Ledger and hledger
+// read the same journal.
+const (
+	LedgerFormatLedger    = "ledger"
+	LedgerFormatHledger   = "hledger"
+	LedgerFormatBeancount = "beancount"
+)
+
+func IsValidLedgerFormat(format string) bool {
+	switch format {
+	case LedgerFormatLedger, LedgerFormatHledger, LedgerFormatBeancount:
+		return true
+	default:
+		return false
+	}
+}
+
+// LedgerExportOptions selects the format and the dates, both inclusive, of a ledger export.
+type LedgerExportOptions struct {
+	Format    string
+	StartDate time.Time
+	EndDate   time.Time
+}
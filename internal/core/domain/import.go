@@ -0,0 +1,138 @@
+package domain
+
+import "github.com/google/uuid"
+
+const (
+	ImportRowValid   = "valid"
+	ImportRowInvalid = "invalid"
+	ImportRowSkipped = "skipped"
+	// ImportRowDuplicate marks an entry that was already imported from another file.
+	ImportRowDuplicate = "duplicate"
+	// ImportRowMatched marks a card statement line that is a parcel already stored.
+	ImportRowMatched = "matched"
+)
+
+// CSVImportOptions says how to read a bank statement CSV. The column fields hold
+// header names of the file; DateColumn, AmountColumn and DescriptionColumn are required.
+type CSVImportOptions struct {
+	DateColumn        string
+	AmountColumn      string
+	DescriptionColumn string
+	CategoryColumn    string
+	// DefaultCategoryID is used for rows without a category column or with an empty one.
+	DefaultCategoryID int
+	// DateFormat is one of YYYY-MM-DD (default), DD/MM/YYYY, MM/DD/YYYY, DD-MM-YYYY or DD.MM.YYYY.
+	DateFormat string
+	// DecimalSeparator is "." (default) or ",". The other one is read as a thousands separator.
+	DecimalSeparator string
+	// Delimiter is ",", ";", "|" or "tab". When empty it is guessed from the header.
+	Delimiter string
+	// NegativeExpenses reads negative amounts as expenses and skips positive ones,
+	// as bank statements list debits and credits together.
+	NegativeExpenses bool
+	// Currency of every amount in the file. Defaults to the user's base currency.
+	Currency string
+}
+
+// OFXImportOptions says how to store the entries of an OFX or QFX file. Debits become
+// simple expenses of ExpenseCategoryID and credits become incomes of IncomeCategoryID.
+type OFXImportOptions struct {
+	ExpenseCategoryID int
+	IncomeCategoryID  int
+	// Currency overrides the currency declared by the file, which otherwise defaults
+	// to the user's base currency.
+	Currency string
+}
+
+// CardStatementImportOptions says how to read the statement of one credit card. A CSV
+// statement is described by CSV; OFX statements need only CSV.DefaultCategoryID.
+// CSV.NegativeExpenses reads negative amounts as purchases, as OFX files always do.
+type CardStatementImportOptions struct {
+	CardID uuid.UUID
+	CSV    CSVImportOptions
+	// PurchaseDates tells that the statement dates every parcel with the date of the
+	// purchase, instead of the date the parcel is charged.
+	PurchaseDates bool
+	// BareParcelSuffix reads a bare "n/m" suffix, as in "STORE 03/10", as the parcel
+	// notation. Otherwise only suffixes such as "Parc 03/10" are, since dates look the same.
+	BareParcelSuffix bool
+}
+
+// ImportRow is one entry of an imported file with the expense or income it becomes,
+// or the reasons it cannot be imported. Line is the line of a CSV file, or the
+// position of the entry in other files.
+type ImportRow struct {
+	Line    int            `json:"line"`
+	Status  string         `json:"status"`
+	Expense *SimpleExpense `json:"expense,omitempty"`
+	Income  *Income        `json:"income,omitempty"`
+	// CardExpenses are the parcels a card statement line creates, from the one it
+	// lists to the last, or the parcel it matched.
+	CardExpenses []CreditCardExpense `json:"card_expenses,omitempty"`
+	Errors       []string            `json:"errors,omitempty"`
+	// Warnings tell how an importable row was read when the file is ambiguous about it.
+	Warnings []string `json:"warnings,omitempty"`
+}
+
+// ImportPreview lists every row of an imported file. Committed tells whether the
+// valid rows were stored; until then nothing is written.
+type ImportPreview struct {
+	Rows           []ImportRow `json:"rows"`
+	ValidCount     int         `json:"valid_count"`
+	InvalidCount   int         `json:"invalid_count"`
+	SkippedCount   int         `json:"skipped_count"`
+	DuplicateCount int         `json:"duplicate_count"`
+	MatchedCount   int         `json:"matched_count"`
+	Committed      bool        `json:"committed"`
+}
+
+// Add appends a row and counts it by status.
+func (p *ImportPreview) Add(row ImportRow) {
+	p.Rows = append(p.Rows, row)
+	switch row.Status {
+	case ImportRowValid:
+		p.ValidCount++
+	case ImportRowInvalid:
+		p.InvalidCount++
+	case ImportRowSkipped:
+		p.SkippedCount++
+	case ImportRowDuplicate:
+		p.DuplicateCount++
+	case ImportRowMatched:
+		p.MatchedCount++
+	}
+}
+
+// ArchiveConflict is a record of an archive bundle that cannot be restored as it is.
+// Kind names the list of the bundle it comes from, such as "credit_cards", and ID is
+// its ID in the bundle.
+type ArchiveConflict struct {
+	Kind   string `json:"kind"`
+	ID     string `json:"id"`
+	Reason string `json:"reason"`
+}
+
+// ArchiveRestore is an archive bundle ready to be stored for UserID. Every UUID is
+// already remapped to a new one. Category IDs are still the bundle's: CategoryIDs maps
+// them to categories of the account, and NewCategories are created with the rest.
+// Budgets get new IDs when they are stored.
+type ArchiveRestore struct {
+	UserID             uuid.UUID
+	CategoryIDs        map[int]int
+	NewCategories      []Category
+	CreditCards        []CreditCard
+	SimpleExpenses     []SimpleExpense
+	RecurringExpenses  []RecurringExpense
+	CreditCardExpenses []CreditCardExpense
+	Budgets            []Budget
+	Incomes            []Income
+	CardPayments       []CardPayment
+}
+
+// ArchiveImportResult tells whether a bundle was restored. A bundle with conflicts is
+// not restored at all; Counts then holds how many records it would have restored.
+type ArchiveImportResult struct {
+	Restored  bool              `json:"restored"`
+	Counts    map[string]int    `json:"counts"`
+	Conflicts []ArchiveConflict `json:"conflicts"`
+}
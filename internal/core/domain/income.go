@@ -0,0 +1,63 @@
+package domain
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+// Income is money received by the user. Without a frequency it is a one-off income
+// received on Date; with one, Date is the first occurrence of a recurring income
+// that repeats until EndDate, when set.
+type Income struct {
+	ID          uuid.UUID  `json:"id" db:"ID"`
+	UserID      uuid.UUID  `json:"user_id" db:"user_id"`
+	CategoryID  int        `json:"category_id" db:"category_id"`
+	Amount      Money      `json:"amount" db:"amount"`
+	Currency    string     `json:"currency" db:"currency"`
+	Description *string    `json:"description" db:"description"`
+	Date        time.Time  `json:"date" db:"date"`
+	Frequency   *string    `json:"frequency" db:"frequency"`
+	EndDate     *time.Time `json:"end_date" db:"end_date"`
+	ExternalID  *string    `json:"external_id,omitempty" db:"external_id"`
+	CreatedAt   time.Time  `json:"created_at" db:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at" db:"updated_at"`
+}
+
+type IncomeSummary struct {
+	Currency       string        `json:"currency"`
+	TotalAmount    Money         `json:"total_amount"`
+	TotalCount     int           `json:"total_count"`
+	AverageAmount  Money         `json:"average_amount"`
+	OneOffTotal    Money         `json:"one_off_total"`
+	RecurringTotal Money         `json:"recurring_total"`
+	ByCategory     map[int]Money `json:"by_category"`
+}
+
+// CashFlow is the money that came in and went out of the user's accounts in a date range,
+// in the user's base currency.
+type CashFlow struct {
+	StartDate               time.Time `json:"start_date"`
+	EndDate                 time.Time `json:"end_date"`
+	Currency                string    `json:"currency"`
+	IncomeTotal             Money     `json:"income_total"`
+	SimpleExpensesTotal     Money     `json:"simple_expenses_total"`
+	RecurringExpensesTotal  Money     `json:"recurring_expenses_total"`
+	CreditCardExpensesTotal Money     `json:"credit_card_expenses_total"`
+	ExpensesTotal           Money     `json:"expenses_total"`
+	NetCashFlow             Money     `json:"net_cash_flow"`
+}
+
+func (i Income) IsRecurring() bool {
+	return i.Frequency != nil
+}
+
+// OccurrencesBetween returns the dates inside [from, to] on which the income is received.
+func (i Income) OccurrencesBetween(from, to time.Time) []time.Time {
+	if !i.IsRecurring() {
+		if i.Date.Before(from) || i.Date.After(to) {
+			return nil
+		}
+		return []time.Time{i.Date}
+	}
+	return occurrencesBetween(i.Date, i.EndDate, *i.Frequency, from, to)
+}
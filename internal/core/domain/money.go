@@ -0,0 +1,177 @@
+package domain
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Money is an amount in minor units (cents). It is stored as numeric(14,2) and
+// serialized in JSON as a decimal number with two places, so 1234 cents is 12.34.
+type Money int64
+
+// NewMoneyFromFloat converts a float amount to Money, rounding half away from zero
+// to the nearest cent.
+func NewMoneyFromFloat(amount float64) Money {
+	return Money(math.Round(amount * 100))
+}
+
+// maxMoneyDigits is the number of integer digits numeric(14,2) holds.
+const maxMoneyDigits = 12
+
+// maxMoney is the largest amount numeric(14,2) holds.
+const maxMoney Money = 99999999999999
+
+// ParseMoney parses a decimal amount such as "12.34" or "-0.5" exactly. Digits
+// beyond the second decimal place are rounded half away from zero. Amounts that
+// do not fit in numeric(14,2) are rejected.
+func ParseMoney(value string) (Money, error) {
+	input := strings.TrimSpace(value)
+	invalid := fmt.Errorf("invalid money amount %q", input)
+	if strings.ContainsAny(input, "eE") {
+		f, err := strconv.ParseFloat(input, 64)
+		if err != nil || math.IsNaN(f) || math.Abs(f) > maxMoney.Float64() {
+			return 0, invalid
+		}
+		return NewMoneyFromFloat(f), nil
+	}
+	digits := input
+	negative := false
+	if digits != "" && (digits[0] == '-' || digits[0] == '+') {
+		negative = digits[0] == '-'
+		digits = digits[1:]
+	}
+	whole, fraction, _ := strings.Cut(digits, ".")
+	if whole == "" && fraction == "" {
+		return 0, invalid
+	}
+	var units int64
+	for i := 0; i < len(whole); i++ {
+		digit := whole[i]
+		if digit < '0' || digit > '9' {
+			return 0, invalid
+		}
+		units = units*10 + int64(digit-'0')
+		if units != 0 && len(whole)-i > maxMoneyDigits {
+			return 0, invalid
+		}
+	}
+	var cents int64
+	for i := 0; i < len(fraction); i++ {
+		digit := fraction[i]
+		if digit < '0' || digit > '9' {
+			return 0, invalid
+		}
+		switch {
+		case i < 2:
+			cents = cents*10 + int64(digit-'0')
+		case i == 2 && digit >= '5':
+			cents++
+		}
+	}
+	if len(fraction) == 1 {
+		cents *= 10
+	}
+	m := Money(units*100 + cents)
+	if m > maxMoney {
+		return 0, invalid
+	}
+	if negative {
+		m = -m
+	}
+	return m, nil
+}
+
+// Float64 returns the amount in major units, for ratios and percentages only.
+func (m Money) Float64() float64 {
+	return float64(m) / 100
+}
+
+func (m Money) String() string {
+	sign := ""
+	cents := int64(m)
+	if cents < 0 {
+		sign = "-"
+		cents = -cents
+	}
+	return fmt.Sprintf("%s%d.%02d", sign, cents/100, cents%100)
+}
+
+// Split divides the amount into n parts that add up exactly to it. The cents that
+// do not divide evenly go to the first part.
+func (m Money) Split(n int) []Money {
+	if n <= 0 {
+		return nil
+	}
+	parts := make([]Money, n)
+	share := m / Money(n)
+	for i := range parts {
+		parts[i] = share
+	}
+	parts[0] += m - share*Money(n)
+	return parts
+}
+
+// DivideBy returns the amount divided by n, rounded half away from zero to the cent.
+func (m Money) DivideBy(n int) Money {
+	if n == 0 {
+		return 0
+	}
+	return Money(math.Round(float64(m) / float64(n)))
+}
+
+// Convert returns the amount multiplied by an exchange rate, rounded half away
+// from zero to the cent.
+func (m Money) Convert(rate float64) Money {
+	return Money(math.Round(float64(m) * rate))
+}
+
+func (m Money) MarshalJSON() ([]byte, error) {
+	return []byte(m.String()), nil
+}
+
+func (m *Money) UnmarshalJSON(data []byte) error {
+	text := strings.Trim(string(data), `"`)
+	if text == "null" || text == "" {
+		*m = 0
+		return nil
+	}
+	parsed, err := ParseMoney(text)
+	if err != nil {
+		return err
+	}
+	*m = parsed
+	return nil
+}
+
+// Scan reads numeric columns, which pgx hands over as their text representation.
+func (m *Money) Scan(src interface{}) error {
+	switch v := src.(type) {
+	case nil:
+		*m = 0
+		return nil
+	case string:
+		parsed, err := ParseMoney(v)
+		if err != nil {
+			return err
+		}
+		*m = parsed
+		return nil
+	case []byte:
+		return m.Scan(string(v))
+	case float64:
+		*m = NewMoneyFromFloat(v)
+		return nil
+	case int64:
+		*m = Money(v * 100)
+		return nil
+	default:
+		return fmt.Errorf("cannot scan %T into Money", src)
+	}
+}
+
+func (m Money) Value() (driver.Value, error) {
+	return m.String(), nil
+}
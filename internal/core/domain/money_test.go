@@ -0,0 +1,121 @@
+package domain
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseMoney(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    Money
+		wantErr bool
+	}{
+		{input: "12.34", want: 1234},
+		{input: "12", want: 1200},
+		{input: "12.3", want: 1230},
+		{input: ".5", want: 50},
+		{input: "-0.5", want: -50},
+		{input: "+7.01", want: 701},
+		{input: "  3.10 ", want: 310},
+		{input: "1.005", want: 101},
+		{input: "1.004", want: 100},
+		{input: "0.995", want: 100},
+		{input: "1.2e2", want: 12000},
+		{input: "999999999999.99", want: 99999999999999},
+		{input: "-999999999999.99", want: -99999999999999},
+		{input: "000000000000001.00", want: 100},
+		{input: "1000000000000", wantErr: true},
+		{input: "999999999999.995", wantErr: true},
+		{input: "1e13", wantErr: true},
+		{input: "99999999999999999999999", wantErr: true},
+		{input: "+-5", wantErr: true},
+		{input: "-+5", wantErr: true},
+		{input: "--5", wantErr: true},
+		{input: "5-", wantErr: true},
+		{input: "1.-5", wantErr: true},
+		{input: "", wantErr: true},
+		{input: "-", wantErr: true},
+		{input: ".", wantErr: true},
+		{input: "1,50", wantErr: true},
+		{input: "abc", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := ParseMoney(tt.input)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("ParseMoney(%q) = %v, want an error", tt.input, got)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("ParseMoney(%q) returned error: %v", tt.input, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ParseMoney(%q) = %d, want %d", tt.input, got, tt.want)
+		}
+	}
+}
+
+func TestMoneySplit(t *testing.T) {
+	tests := []struct {
+		amount Money
+		n      int
+		want   []Money
+	}{
+		{amount: 1000, n: 3, want: []Money{334, 333, 333}},
+		{amount: 1000, n: 4, want: []Money{250, 250, 250, 250}},
+		{amount: 1, n: 3, want: []Money{1, 0, 0}},
+		{amount: 999, n: 1, want: []Money{999}},
+		{amount: -1000, n: 3, want: []Money{-334, -333, -333}},
+		{amount: 1000, n: 0, want: nil},
+	}
+	for _, tt := range tests {
+		got := tt.amount.Split(tt.n)
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("Money(%d).Split(%d) = %v, want %v", tt.amount, tt.n, got, tt.want)
+		}
+		var total Money
+		for _, part := range got {
+			total += part
+		}
+		if tt.n > 0 && total != tt.amount {
+			t.Errorf("Money(%d).Split(%d) adds up to %d", tt.amount, tt.n, total)
+		}
+	}
+}
+
+func TestMoneyScan(t *testing.T) {
+	tests := []struct {
+		src     interface{}
+		want    Money
+		wantErr bool
+	}{
+		{src: nil, want: 0},
+		{src: "12.34", want: 1234},
+		{src: "-0.01", want: -1},
+		{src: []byte("5.50"), want: 550},
+		{src: float64(1.1), want: 110},
+		{src: int64(7), want: 700},
+		{src: "not a number", wantErr: true},
+		{src: true, wantErr: true},
+	}
+	for _, tt := range tests {
+		m := Money(99)
+		err := m.Scan(tt.src)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("Scan(%#v) = %v, want an error", tt.src, m)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("Scan(%#v) returned error: %v", tt.src, err)
+			continue
+		}
+		if m != tt.want {
+			t.Errorf("Scan(%#v) = %d, want %d", tt.src, m, tt.want)
+		}
+	}
+}
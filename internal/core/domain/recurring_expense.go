@@ -5,25 +5,105 @@ import (
 	"time"
 )
 
+// RecurringExpense is either a template, the recurrence rule, or one of the occurrences
+// generated from it. GeneratedThrough is only set on templates: the last day occurrences
+// were generated through.
 type RecurringExpense struct {
-	ID          uuid.UUID  `json:"id" db:"ID"`
-	UserID      uuid.UUID  `json:"user_id" db:"user_id"`
-	CategoryID  int        `json:"category_id" db:"category_id"`
-	Amount      float64    `json:"amount" db:"amount"`
-	Description *string    `json:"description" db:"description"`
-	Date        time.Time  `json:"date" db:"date"`
-	CardID      *uuid.UUID `json:"card_id" db:"card_id"`
-	StartDate   time.Time  `json:"start_date" db:"start_date"`
-	EndDate     *time.Time `json:"end_date" db:"end_date"`
-	Frequency   string     `json:"frequency" db:"frequency"`
-	CreatedAt   time.Time  `json:"created_at" db:"created_at"`
-	UpdatedAt   time.Time  `json:"updated_at" db:"updated_at"`
+	ID               uuid.UUID  `json:"id" db:"ID"`
+	UserID           uuid.UUID  `json:"user_id" db:"user_id"`
+	CategoryID       int        `json:"category_id" db:"category_id"`
+	Amount           Money      `json:"amount" db:"amount"`
+	Currency         string     `json:"currency" db:"currency"`
+	Description      *string    `json:"description" db:"description"`
+	Date             time.Time  `json:"date" db:"date"`
+	CardID           *uuid.UUID `json:"card_id" db:"card_id"`
+	StartDate        time.Time  `json:"start_date" db:"start_date"`
+	EndDate          *time.Time `json:"end_date" db:"end_date"`
+	Frequency        string     `json:"frequency" db:"frequency"`
+	TemplateID       *uuid.UUID `json:"template_id" db:"template_id"`
+	GeneratedThrough *time.Time `json:"generated_through" db:"generated_through"`
+	CreatedAt        time.Time  `json:"created_at" db:"created_at"`
+	UpdatedAt        time.Time  `json:"updated_at" db:"updated_at"`
 }
 
 type RecurringExpenseSummary struct {
-	TotalAmount   float64
+	Currency      string
+	TotalAmount   Money
 	TotalCount    int
-	AverageAmount float64
-	ByFrequency   map[string]float64
-	ByCategory    map[int]float64
+	AverageAmount Money
+	ByFrequency   map[string]Money
+	ByCategory    map[int]Money
+}
+
+const (
+	FrequencyDaily    = "daily"
+	FrequencyWeekly   = "weekly"
+	FrequencyBiweekly = "biweekly"
+	FrequencyMonthly  = "monthly"
+	FrequencyYearly   = "yearly"
+)
+
+func IsValidFrequency(frequency string) bool {
+	switch frequency {
+	case FrequencyDaily, FrequencyWeekly, FrequencyBiweekly, FrequencyMonthly, FrequencyYearly:
+		return true
+	default:
+		return false
+	}
+}
+
+// IsTemplate reports whether the expense is a recurrence rule rather than one of its generated occurrences.
+func (e RecurringExpense) IsTemplate() bool {
+	return e.TemplateID == nil
+}
+
+// OccurrencesBetween returns the dates, from StartDate on and never after EndDate,
+// on which the expense falls due inside [from, to].
+func (e RecurringExpense) OccurrencesBetween(from, to time.Time) []time.Time {
+	return occurrencesBetween(e.StartDate, e.EndDate, e.Frequency, from, to)
+}
+
+// occurrencesBetween expands a recurrence starting at start with the given frequency,
+// never after end when set, into the dates that fall inside [from, to].
+func occurrencesBetween(start time.Time, end *time.Time, frequency string, from, to time.Time) []time.Time {
+	var occurrences []time.Time
+	for n := 0; ; n++ {
+		occurrence, ok := nthOccurrence(start, frequency, n)
+		if !ok || occurrence.After(to) || (end != nil && occurrence.After(*end)) {
+			break
+		}
+		if !occurrence.Before(from) {
+			occurrences = append(occurrences, occurrence)
+		}
+	}
+	return occurrences
+}
+
+func nthOccurrence(start time.Time, frequency string, n int) (time.Time, bool) {
+	switch frequency {
+	case FrequencyDaily:
+		return start.AddDate(0, 0, n), true
+	case FrequencyWeekly:
+		return start.AddDate(0, 0, 7*n), true
+	case FrequencyBiweekly:
+		return start.AddDate(0, 0, 14*n), true
+	case FrequencyMonthly:
+		return AddMonthsClamped(start, n), true
+	case FrequencyYearly:
+		return AddMonthsClamped(start, 12*n), true
+	default:
+		return time.Time{}, false
+	}
+}
+
+// AddMonthsClamped adds months to t keeping the day of month, clamped to the
+// last day of the resulting month (Jan 31 + 1 month is Feb 28/29, not Mar 3).
+func AddMonthsClamped(t time.Time, months int) time.Time {
+	firstOfMonth := time.Date(t.Year(), t.Month()+time.Month(months), 1, t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), t.Location())
+	lastDay := firstOfMonth.AddDate(0, 1, -1).Day()
+	day := t.Day()
+	if day > lastDay {
+		day = lastDay
+	}
+	return firstOfMonth.AddDate(0, 0, day-1)
 }
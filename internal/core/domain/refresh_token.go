@@ -5,10 +5,19 @@ import (
 	"time"
 )
 
+// RefreshToken is one refresh token of a family: the token issued at login and each
+// one that replaced it on refresh. Only a keyed hash of the token is stored. RevokedAt
+// is set once the token was used or revoked.
 type RefreshToken struct {
-	ID        uuid.UUID `json:"id"`
-	UserID    uuid.UUID `json:"user_id"`
-	Token     string    `json:"token"`
-	ExpiresAt time.Time `json:"expires_at"`
-	CreatedAt time.Time `json:"created_at"`
+	ID          uuid.UUID  `json:"id"`
+	UserID      uuid.UUID  `json:"user_id"`
+	FamilyID    uuid.UUID  `json:"family_id"`
+	TokenHash   string     `json:"-"`
+	UserAgent   string     `json:"user_agent"`
+	IPAddress   string     `json:"ip_address"`
+	DeviceLabel string     `json:"device_label"`
+	LastUsedAt  time.Time  `json:"last_used_at"`
+	ExpiresAt   time.Time  `json:"expires_at"`
+	RevokedAt   *time.Time `json:"revoked_at"`
+	CreatedAt   time.Time  `json:"created_at"`
 }
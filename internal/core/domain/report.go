@@ -0,0 +1,49 @@
+package domain
+
+import "time"
+
+// MonthlyReport gathers every expense source of one calendar month, by category
+// and against the previous month and the user's expenditure limit. Every amount
+// is converted into the user's base currency at the rate of its own date.
+type MonthlyReport struct {
+	Month                   string            `json:"month"`
+	StartDate               time.Time         `json:"start_date"`
+	EndDate                 time.Time         `json:"end_date"`
+	Currency                string            `json:"currency"`
+	SimpleExpensesTotal     Money             `json:"simple_expenses_total"`
+	RecurringExpensesTotal  Money             `json:"recurring_expenses_total"`
+	CreditCardExpensesTotal Money             `json:"credit_card_expenses_total"`
+	TotalExpenses           Money             `json:"total_expenses"`
+	IncomeTotal             Money             `json:"income_total"`
+	NetCashFlow             Money             `json:"net_cash_flow"`
+	ExpenditureLimit        Money             `json:"expenditure_limit"`
+	LimitUsedPercentage     *float64          `json:"limit_used_percentage"`
+	ByCategory              []CategoryTotal   `json:"by_category"`
+	PreviousMonth           MonthComparison   `json:"previous_month"`
+	TopExpenses             []ReportedExpense `json:"top_expenses"`
+}
+
+// CategoryTotal is what was spent in one category during the month and the month before.
+type CategoryTotal struct {
+	CategoryID     int     `json:"category_id"`
+	CategoryName   string  `json:"category_name"`
+	Amount         Money   `json:"amount"`
+	Percentage     float64 `json:"percentage"`
+	PreviousAmount Money   `json:"previous_amount"`
+	Difference     Money   `json:"difference"`
+}
+
+// MonthComparison compares the month of a report with the month before it.
+// PercentageChange is nil when nothing was spent in the previous month.
+type MonthComparison struct {
+	Month            string   `json:"month"`
+	TotalExpenses    Money    `json:"total_expenses"`
+	Difference       Money    `json:"difference"`
+	PercentageChange *float64 `json:"percentage_change"`
+}
+
+// ReportedExpense is a ledger entry annotated with the name of its category.
+type ReportedExpense struct {
+	Transaction
+	CategoryName string `json:"category_name"`
+}
@@ -0,0 +1,25 @@
+package domain
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+// SessionClient describes the client that logs in or refreshes a session.
+type SessionClient struct {
+	UserAgent   string
+	IPAddress   string
+	DeviceLabel string
+}
+
+// Session is a login of the user on one device: the refresh token family started by the
+// login. Its ID is the family ID, and LastUsedAt is the time of its last login or refresh.
+type Session struct {
+	ID          uuid.UUID `json:"id"`
+	DeviceLabel string    `json:"device_label"`
+	UserAgent   string    `json:"user_agent"`
+	IPAddress   string    `json:"ip_address"`
+	CreatedAt   time.Time `json:"created_at"`
+	LastUsedAt  time.Time `json:"last_used_at"`
+	ExpiresAt   time.Time `json:"expires_at"`
+}
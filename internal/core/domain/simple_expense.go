@@ -9,16 +9,19 @@ type SimpleExpense struct {
 	ID          uuid.UUID `json:"id" db:"ID"`
 	UserID      uuid.UUID `json:"user_id" db:"user_id"`
 	CategoryID  int       `json:"category_id" db:"category_id"`
-	Amount      float64   `json:"amount" db:"amount"`
+	Amount      Money     `json:"amount" db:"amount"`
+	Currency    string    `json:"currency" db:"currency"`
 	Description *string   `json:"description" db:"description"`
 	Date        time.Time `json:"date" db:"date"`
+	ExternalID  *string   `json:"external_id,omitempty" db:"external_id"`
 	CreatedAt   time.Time `json:"created_at" db:"created_at"`
 	UpdatedAt   time.Time `json:"updated_at" db:"updated_at"`
 }
 
 type SimpleExpenseSummary struct {
-	TotalAmount   float64
+	Currency      string
+	TotalAmount   Money
 	TotalCount    int
-	AverageAmount float64
-	ByCategory    map[int]float64
+	AverageAmount Money
+	ByCategory    map[int]Money
 }
@@ -0,0 +1,39 @@
+package domain
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+// TOTP is the time-based one-time password factor of a user. It is enforced at login
+// only once ConfirmedAt is set, after the user proved the authenticator app works.
+type TOTP struct {
+	UserID         uuid.UUID
+	Secret         string
+	ConfirmedAt    *time.Time
+	LastUsedStep   int64
+	FailedAttempts int
+	LockedUntil    *time.Time
+	CreatedAt      time.Time
+}
+
+func (t TOTP) Enabled() bool {
+	return t.ConfirmedAt != nil
+}
+
+// TOTPEnrollment is the secret to add to an authenticator app, both as base32 text and
+// as an otpauth URI meant to be shown as a QR code.
+type TOTPEnrollment struct {
+	Secret string `json:"secret"`
+	URI    string `json:"uri"`
+}
+
+// LoginResult is the outcome of a login with a valid password: the token pair, or, when
+// the user has two-factor authentication on, a short-lived MFA token to exchange together
+// with a code for the token pair.
+type LoginResult struct {
+	AccessToken  string `json:"access_token,omitempty"`
+	RefreshToken string `json:"refresh_token,omitempty"`
+	MFARequired  bool   `json:"mfa_required,omitempty"`
+	MFAToken     string `json:"mfa_token,omitempty"`
+}
@@ -0,0 +1,60 @@
+package domain
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+const (
+	TransactionTypeSimpleExpense     = "simple_expense"
+	TransactionTypeRecurringExpense  = "recurring_expense"
+	TransactionTypeCreditCardExpense = "credit_card_expense"
+	TransactionTypeIncome            = "income"
+)
+
+const (
+	TransactionSortDate   = "date"
+	TransactionSortAmount = "amount"
+)
+
+// Transaction is one entry of the unified ledger. Recurring expenses appear as their
+// generated occurrences, card purchases as their installments (Amount is the
+// installment value) and recurring incomes once per occurrence. Amount is always
+// positive; Type tells whether money came in or went out. When the entry was
+// converted into another currency, OriginalAmount and OriginalCurrency keep the
+// amount as it was registered. The occurrences of a recurring income share its ID,
+// so OccurrenceID, which adds the date to it, is what tells entries apart.
+type Transaction struct {
+	ID                   uuid.UUID  `json:"id"`
+	OccurrenceID         string     `json:"occurrence_id"`
+	Type                 string     `json:"type"`
+	CategoryID           int        `json:"category_id"`
+	Amount               Money      `json:"amount"`
+	Currency             string     `json:"currency"`
+	OriginalAmount       *Money     `json:"original_amount,omitempty"`
+	OriginalCurrency     *string    `json:"original_currency,omitempty"`
+	Description          *string    `json:"description"`
+	Date                 time.Time  `json:"date"`
+	CardID               *uuid.UUID `json:"card_id,omitempty"`
+	TemplateID           *uuid.UUID `json:"template_id,omitempty"`
+	ParcelNumber         *int       `json:"parcel_number,omitempty"`
+	InstallmentsQuantity *int       `json:"installments_quantity,omitempty"`
+	CreatedAt            time.Time  `json:"created_at"`
+}
+
+// TransactionPage is one page of the ledger together with the number of entries matching the filters.
+type TransactionPage struct {
+	Transactions []Transaction `json:"transactions"`
+	Total        int           `json:"total"`
+	Limit        int           `json:"limit"`
+	Offset       int           `json:"offset"`
+}
+
+func IsValidTransactionType(transactionType string) bool {
+	switch transactionType {
+	case TransactionTypeSimpleExpense, TransactionTypeRecurringExpense, TransactionTypeCreditCardExpense, TransactionTypeIncome:
+		return true
+	default:
+		return false
+	}
+}
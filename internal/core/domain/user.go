@@ -13,8 +13,9 @@ type User struct {
 	Password         string    `json:"password"`
 	Email            string    `json:"email"`
 	ProfilePicture   string    `json:"profile_picture"`
-	Income           float64   `json:"income"`
-	ExpenditureLimit float64   `json:"expenditure_limit"`
+	Income           Money     `json:"income"`
+	ExpenditureLimit Money     `json:"expenditure_limit"`
+	BaseCurrency     string    `json:"base_currency"`
 	CreatedAt        time.Time `json:"created_at"`
 	UpdatedAt        time.Time `json:"updated_at"`
 }
@@ -4,10 +4,19 @@ import (
 	"context"
 	"github.com/google/uuid"
 	"github.com/misalima/my-budget-planner-backend/internal/core/domain"
+	"time"
 )
 
 type AuthLoader interface {
-	StoreRefreshToken(ctx context.Context, userId uuid.UUID, refreshToken string) error
+	StoreRefreshToken(ctx context.Context, userId uuid.UUID, familyId uuid.UUID, refreshToken string, client domain.SessionClient) error
 	GetRefreshToken(ctx context.Context, token string) (domain.RefreshToken, error)
+	RotateRefreshToken(ctx context.Context, current domain.RefreshToken, refreshToken string, client domain.SessionClient) error
+	RevokeRefreshTokenFamily(ctx context.Context, familyId uuid.UUID) error
 	DeleteRefreshToken(ctx context.Context, token string) error
+	FetchSessions(ctx context.Context, userId uuid.UUID) ([]domain.Session, error)
+	DeleteSession(ctx context.Context, userId uuid.UUID, sessionId uuid.UUID) error
+	IsSessionActive(ctx context.Context, userId uuid.UUID, sessionId uuid.UUID) (bool, error)
+	DeleteExpiredRefreshTokens(ctx context.Context, now time.Time) (int64, error)
+	RevokeUserTokens(ctx context.Context, userId uuid.UUID, validAfter time.Time) error
+	GetTokensValidAfter(ctx context.Context, userId uuid.UUID) (*time.Time, error)
 }
@@ -0,0 +1,15 @@
+package irepository
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"github.com/misalima/my-budget-planner-backend/internal/core/domain"
+)
+
+type BudgetLoader interface {
+	InsertBudget(ctx context.Context, budget domain.Budget) (domain.Budget, error)
+	UpdateBudget(ctx context.Context, budget domain.Budget) (domain.Budget, error)
+	DeleteBudget(ctx context.Context, id int) error
+	FindBudgetByID(ctx context.Context, id int) (domain.Budget, error)
+	FindBudgetsByUser(ctx context.Context, userID uuid.UUID) ([]domain.Budget, error)
+}
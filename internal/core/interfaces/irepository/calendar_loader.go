@@ -0,0 +1,13 @@
+package irepository
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"github.com/misalima/my-budget-planner-backend/internal/core/domain"
+)
+
+type CalendarLoader interface {
+	StoreCalendarFeed(ctx context.Context, feed domain.CalendarFeed, tokenHash string) error
+	FindCalendarFeedUser(ctx context.Context, tokenHash string) (uuid.UUID, error)
+	DeleteCalendarFeed(ctx context.Context, userID uuid.UUID) error
+}
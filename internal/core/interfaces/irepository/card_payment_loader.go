@@ -0,0 +1,14 @@
+package irepository
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"github.com/misalima/my-budget-planner-backend/internal/core/domain"
+)
+
+type CardPaymentLoader interface {
+	InsertCardPayment(ctx context.Context, payment domain.CardPayment) (domain.CardPayment, error)
+	DeleteCardPayment(ctx context.Context, id uuid.UUID) error
+	FindCardPaymentByID(ctx context.Context, id uuid.UUID) (domain.CardPayment, error)
+	FindCardPaymentsByCard(ctx context.Context, cardID uuid.UUID) ([]domain.CardPayment, error)
+}
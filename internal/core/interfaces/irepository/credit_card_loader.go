@@ -11,4 +11,5 @@ type CreditCardLoader interface {
 	FetchOneByID(ctx context.Context, id uuid.UUID) (*domain.CreditCard, error)
 	Create(ctx context.Context, cc *domain.CreditCard) error
 	Delete(ctx context.Context, id uuid.UUID) error
+	RecomputeCurrentLimit(ctx context.Context, id uuid.UUID) (*domain.CreditCard, error)
 }
@@ -0,0 +1,22 @@
+package irepository
+
+import (
+	"context"
+	"github.com/misalima/my-budget-planner-backend/internal/core/domain"
+	"time"
+)
+
+type ExchangeRateLoader interface {
+	UpsertExchangeRates(ctx context.Context, rates []domain.ExchangeRate) error
+	FindLatestExchangeRate(ctx context.Context, fromCurrency, toCurrency string, date time.Time) (domain.ExchangeRate, error)
+	FindExchangeRates(ctx context.Context, filters ExchangeRateFilters) ([]domain.ExchangeRate, error)
+}
+
+type ExchangeRateFilters struct {
+	BaseCurrency  *string
+	QuoteCurrency *string
+	StartDate     *time.Time
+	EndDate       *time.Time
+	Limit         *int
+	Offset        *int
+}
@@ -0,0 +1,13 @@
+package irepository
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"github.com/misalima/my-budget-planner-backend/internal/core/domain"
+)
+
+type ImportLoader interface {
+	FindImportedExternalIDs(ctx context.Context, userID uuid.UUID, externalIDs []string) (map[string]bool, error)
+	InsertImportedEntries(ctx context.Context, expenses []domain.SimpleExpense, incomes []domain.Income) ([]domain.SimpleExpense, []domain.Income, error)
+	RestoreArchive(ctx context.Context, restore domain.ArchiveRestore) error
+}
@@ -0,0 +1,28 @@
+package irepository
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"github.com/misalima/my-budget-planner-backend/internal/core/domain"
+	"time"
+)
+
+type IncomeLoader interface {
+	InsertIncome(ctx context.Context, income domain.Income) (domain.Income, error)
+	UpdateIncome(ctx context.Context, income domain.Income) (domain.Income, error)
+	DeleteIncome(ctx context.Context, id uuid.UUID) error
+	FindIncomeByID(ctx context.Context, id uuid.UUID) (domain.Income, error)
+	FindIncomes(ctx context.Context, userID uuid.UUID, filters IncomeFilters) ([]domain.Income, error)
+	FindIncomesByDateRange(ctx context.Context, userID uuid.UUID, startDate, endDate time.Time) ([]domain.Income, error)
+}
+
+type IncomeFilters struct {
+	CategoryID *int
+	Recurring  *bool
+	StartDate  *time.Time
+	EndDate    *time.Time
+	MinAmount  *float64
+	MaxAmount  *float64
+	Limit      *int
+	Offset     *int
+}
@@ -15,10 +15,16 @@ type RecurringExpenseLoader interface {
 	FindRecurringExpenses(ctx context.Context, userID uuid.UUID, filters RecurringExpenseFilters) ([]domain.RecurringExpense, error)
 	FindRecurringExpensesByUser(ctx context.Context, userID uuid.UUID) ([]domain.RecurringExpense, error)
 	FindRecurringExpensesByDateRange(ctx context.Context, userID uuid.UUID, startDate, endDate time.Time) ([]domain.RecurringExpense, error)
-	InsertGeneratedRecurringExpenses(ctx context.Context, expenses []domain.RecurringExpense) error
+	FindGeneratedRecurringExpensesByDateRange(ctx context.Context, userID uuid.UUID, startDate, endDate time.Time) ([]domain.RecurringExpense, error)
+	StoreGeneratedRecurringExpenses(ctx context.Context, templateID uuid.UUID, expenses []domain.RecurringExpense, through time.Time) (int, error)
+	ReplaceGeneratedRecurringExpensesFrom(ctx context.Context, templateID uuid.UUID, from time.Time, expenses []domain.RecurringExpense) error
 }
 
+// RecurringExpenseFilters selects templates by default. Setting Generated or
+// TemplateID selects generated occurrences instead.
 type RecurringExpenseFilters struct {
+	Generated  bool
+	TemplateID *uuid.UUID
 	CategoryID *int
 	CardID     *uuid.UUID
 	Frequency  *string
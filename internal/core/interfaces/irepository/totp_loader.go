@@ -0,0 +1,19 @@
+package irepository
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"github.com/misalima/my-budget-planner-backend/internal/core/domain"
+	"time"
+)
+
+type TOTPLoader interface {
+	StoreTOTP(ctx context.Context, totp domain.TOTP) error
+	GetTOTP(ctx context.Context, userID uuid.UUID) (domain.TOTP, error)
+	ConfirmTOTP(ctx context.Context, userID uuid.UUID, step int64, recoveryCodeHashes []string, confirmedAt time.Time) error
+	UseTOTPStep(ctx context.Context, userID uuid.UUID, step int64) error
+	UseRecoveryCode(ctx context.Context, userID uuid.UUID, codeHash string, usedAt time.Time) error
+	RecordTOTPFailure(ctx context.Context, userID uuid.UUID, maxAttempts int, lockedUntil time.Time) (bool, error)
+	DeleteTOTP(ctx context.Context, userID uuid.UUID) error
+	UseMFAToken(ctx context.Context, userID uuid.UUID, tokenID string, expiresAt time.Time) error
+}
@@ -0,0 +1,27 @@
+package irepository
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"github.com/misalima/my-budget-planner-backend/internal/core/domain"
+	"time"
+)
+
+type TransactionLoader interface {
+	FindTransactions(ctx context.Context, userID uuid.UUID, filters TransactionFilters) ([]domain.Transaction, int, error)
+}
+
+// TransactionFilters selects a page of the ledger with the filters every source shares,
+// plus the ones only the ledger has. Types lists the sources to include (all when
+// empty). Entries in other currencies cannot be compared by amount, so MinAmount and
+// MaxAmount require Currency. Recurring incomes are expanded into their occurrences up
+// to RecurringIncomeUntil.
+type TransactionFilters struct {
+	SimpleExpenseFilters
+	Types                []string
+	CardID               *uuid.UUID
+	Currency             *string
+	RecurringIncomeUntil time.Time
+	SortBy               string
+	Ascending            bool
+}
@@ -2,10 +2,14 @@ package irepository
 
 import (
 	"context"
+	"github.com/google/uuid"
 	"github.com/misalima/my-budget-planner-backend/internal/core/domain"
 )
 
 type UserLoader interface {
 	CreateUser(ctx context.Context, user *domain.User) error
 	GetUserByEmail(ctx context.Context, email string) (*domain.User, error)
+	GetUserByID(ctx context.Context, id uuid.UUID) (*domain.User, error)
+	UpdateBaseCurrency(ctx context.Context, id uuid.UUID, currency string) error
+	ListUserIDs(ctx context.Context) ([]uuid.UUID, error)
 }
@@ -4,12 +4,22 @@ import (
 	"context"
 	"github.com/google/uuid"
 	"github.com/misalima/my-budget-planner-backend/internal/core/domain"
+	"time"
 )
 
 type AuthManager interface {
-	Login(email, password string) (accessToken string, refreshToken string, err error)
-	SaveRefreshToken(userId uuid.UUID, refreshToken string) error
+	Login(email, password string, client domain.SessionClient) (domain.LoginResult, error)
+	CompleteMFALogin(mfaToken, code string, client domain.SessionClient) (domain.LoginResult, error)
+	StartTOTPEnrollment(ctx context.Context, userId uuid.UUID) (domain.TOTPEnrollment, error)
+	ConfirmTOTP(ctx context.Context, userId uuid.UUID, code string) (recoveryCodes []string, err error)
+	DisableTOTP(ctx context.Context, userId uuid.UUID, code string) error
+	SaveRefreshToken(userId uuid.UUID, sessionId uuid.UUID, refreshToken string, client domain.SessionClient) error
 	ValidateRefreshToken(ctx context.Context, userId uuid.UUID, token string) (domain.RefreshToken, error)
 	DeleteRefreshToken(token string) error
-	RefreshToken(userId uuid.UUID, token string) (newAccessToken string, err error)
+	RefreshToken(token string, client domain.SessionClient) (newAccessToken string, newRefreshToken string, err error)
+	ListSessions(ctx context.Context, userId uuid.UUID) ([]domain.Session, error)
+	RevokeSession(ctx context.Context, userId uuid.UUID, sessionId uuid.UUID) error
+	PurgeExpiredRefreshTokens(ctx context.Context) (int, error)
+	LogoutAll(ctx context.Context, userId uuid.UUID) error
+	ValidateAccessToken(ctx context.Context, userId uuid.UUID, sessionId uuid.UUID, issuedAt time.Time) error
 }
@@ -0,0 +1,19 @@
+package iservice
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"github.com/misalima/my-budget-planner-backend/internal/core/domain"
+	"time"
+)
+
+type BudgetManager interface {
+	CreateBudget(ctx context.Context, budget domain.Budget) (domain.Budget, error)
+	UpdateBudget(ctx context.Context, budget domain.Budget) (domain.Budget, error)
+	DeleteBudget(ctx context.Context, id int, userID uuid.UUID) error
+	GetBudgetByID(ctx context.Context, id int, userID uuid.UUID) (domain.Budget, error)
+	ListBudgets(ctx context.Context, userID uuid.UUID) ([]domain.Budget, error)
+	GetBudgetConsumption(ctx context.Context, id int, userID uuid.UUID, date time.Time) (domain.BudgetConsumption, error)
+	ListBudgetConsumptions(ctx context.Context, userID uuid.UUID, date time.Time) ([]domain.BudgetConsumption, error)
+	ListBudgetPeriods(ctx context.Context, id int, userID uuid.UUID, from, to time.Time) ([]domain.BudgetConsumption, error)
+}
@@ -0,0 +1,13 @@
+package iservice
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"github.com/misalima/my-budget-planner-backend/internal/core/domain"
+)
+
+type CalendarManager interface {
+	CreateCalendarFeed(ctx context.Context, userID uuid.UUID) (domain.CalendarFeed, error)
+	RevokeCalendarFeed(ctx context.Context, userID uuid.UUID) error
+	RenderCalendarFeed(ctx context.Context, token string) ([]byte, error)
+}
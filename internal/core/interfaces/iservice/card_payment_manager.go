@@ -0,0 +1,14 @@
+package iservice
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"github.com/misalima/my-budget-planner-backend/internal/core/domain"
+)
+
+type CardPaymentManager interface {
+	CreateCardPayment(ctx context.Context, payment domain.CardPayment) (domain.CardPayment, error)
+	DeleteCardPayment(ctx context.Context, cardID uuid.UUID, id uuid.UUID, userID uuid.UUID) error
+	GetCardPaymentByID(ctx context.Context, cardID uuid.UUID, id uuid.UUID, userID uuid.UUID) (domain.CardPayment, error)
+	ListCardPayments(ctx context.Context, cardID uuid.UUID, userID uuid.UUID) ([]domain.CardPayment, error)
+}
@@ -1,8 +1,10 @@
 package iservice
 
 import (
+	"context"
 	"github.com/google/uuid"
 	"github.com/misalima/my-budget-planner-backend/internal/core/domain"
+	"time"
 )
 
 type CreditCardManager interface {
@@ -10,4 +12,6 @@ type CreditCardManager interface {
 	GetByID(id uuid.UUID) (*domain.CreditCard, error)
 	Create(cc *domain.CreditCard) error
 	Delete(id uuid.UUID) error
+	GetStatement(ctx context.Context, cardID uuid.UUID, userID uuid.UUID, year int, month time.Month) (domain.CreditCardStatement, error)
+	RecomputeLimit(ctx context.Context, cardID uuid.UUID, userID uuid.UUID) (*domain.CreditCard, error)
 }
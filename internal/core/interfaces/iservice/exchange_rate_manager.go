@@ -0,0 +1,13 @@
+package iservice
+
+import (
+	"context"
+	"github.com/misalima/my-budget-planner-backend/internal/core/domain"
+	"github.com/misalima/my-budget-planner-backend/internal/core/interfaces/irepository"
+	"io"
+)
+
+type ExchangeRateManager interface {
+	ImportExchangeRatesCSV(ctx context.Context, r io.Reader) (int, error)
+	ListExchangeRates(ctx context.Context, filters irepository.ExchangeRateFilters) ([]domain.ExchangeRate, error)
+}
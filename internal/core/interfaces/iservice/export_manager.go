@@ -0,0 +1,14 @@
+package iservice
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"github.com/misalima/my-budget-planner-backend/internal/core/domain"
+	"io"
+)
+
+type ExportManager interface {
+	ExportAccount(ctx context.Context, userID uuid.UUID) (domain.AccountExport, error)
+	WriteExportArchive(w io.Writer, export domain.AccountExport) error
+	ExportLedger(ctx context.Context, userID uuid.UUID, options domain.LedgerExportOptions) ([]byte, error)
+}
@@ -0,0 +1,15 @@
+package iservice
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"github.com/misalima/my-budget-planner-backend/internal/core/domain"
+	"io"
+)
+
+type ImportManager interface {
+	ImportCSV(ctx context.Context, userID uuid.UUID, r io.Reader, options domain.CSVImportOptions, confirm bool) (domain.ImportPreview, error)
+	ImportOFX(ctx context.Context, userID uuid.UUID, r io.Reader, options domain.OFXImportOptions, confirm bool) (domain.ImportPreview, error)
+	ImportCardStatement(ctx context.Context, userID uuid.UUID, r io.Reader, options domain.CardStatementImportOptions, confirm bool) (domain.ImportPreview, error)
+	ImportArchive(ctx context.Context, userID uuid.UUID, bundle domain.ArchiveBundle) (domain.ArchiveImportResult, error)
+}
@@ -0,0 +1,19 @@
+package iservice
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"github.com/misalima/my-budget-planner-backend/internal/core/domain"
+	"github.com/misalima/my-budget-planner-backend/internal/core/interfaces/irepository"
+	"time"
+)
+
+type IncomeManager interface {
+	CreateIncome(ctx context.Context, income domain.Income) (domain.Income, error)
+	UpdateIncome(ctx context.Context, income domain.Income) (domain.Income, error)
+	DeleteIncome(ctx context.Context, id uuid.UUID, userID uuid.UUID) error
+	GetIncomeByID(ctx context.Context, id uuid.UUID, userID uuid.UUID) (domain.Income, error)
+	ListIncomes(ctx context.Context, userID uuid.UUID, filters irepository.IncomeFilters) ([]domain.Income, error)
+	GetIncomeSummary(ctx context.Context, userID uuid.UUID, startDate, endDate time.Time) (domain.IncomeSummary, error)
+	GetCashFlow(ctx context.Context, userID uuid.UUID, startDate, endDate time.Time) (domain.CashFlow, error)
+}
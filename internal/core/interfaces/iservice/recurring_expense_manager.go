@@ -14,6 +14,6 @@ type RecurringExpenseManager interface {
 	DeleteRecurringExpense(ctx context.Context, id uuid.UUID, userID uuid.UUID) error
 	GetRecurringExpenseByID(ctx context.Context, id uuid.UUID, userID uuid.UUID) (domain.RecurringExpense, error)
 	ListRecurringExpenses(ctx context.Context, userID uuid.UUID, filters irepository.RecurringExpenseFilters) ([]domain.RecurringExpense, error)
-	GenerateRecurringExpenses(ctx context.Context, userID uuid.UUID, targetDate time.Time) error
+	GenerateRecurringExpenses(ctx context.Context, userID uuid.UUID, targetDate time.Time) (int, error)
 	GetRecurringExpenseSummary(ctx context.Context, userID uuid.UUID, startDate, endDate time.Time) (domain.RecurringExpenseSummary, error)
 }
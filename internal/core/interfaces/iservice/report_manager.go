@@ -0,0 +1,12 @@
+package iservice
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"github.com/misalima/my-budget-planner-backend/internal/core/domain"
+	"time"
+)
+
+type ReportManager interface {
+	GetMonthlyReport(ctx context.Context, userID uuid.UUID, year int, month time.Month) (domain.MonthlyReport, error)
+}
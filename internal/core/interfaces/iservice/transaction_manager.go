@@ -0,0 +1,12 @@
+package iservice
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"github.com/misalima/my-budget-planner-backend/internal/core/domain"
+	"github.com/misalima/my-budget-planner-backend/internal/core/interfaces/irepository"
+)
+
+type TransactionManager interface {
+	ListTransactions(ctx context.Context, userID uuid.UUID, filters irepository.TransactionFilters) (domain.TransactionPage, error)
+}
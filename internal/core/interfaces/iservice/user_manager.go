@@ -1,7 +1,13 @@
 package iservice
 
-import "github.com/misalima/my-budget-planner-backend/internal/core/domain"
+import (
+	"context"
+	"github.com/google/uuid"
+	"github.com/misalima/my-budget-planner-backend/internal/core/domain"
+)
 
 type UserManager interface {
 	RegisterUser(user *domain.User) error
+	ListUserIDs(ctx context.Context) ([]uuid.UUID, error)
+	UpdateBaseCurrency(ctx context.Context, userID uuid.UUID, currency string) (string, error)
 }
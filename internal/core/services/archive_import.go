@@ -0,0 +1,492 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/misalima/my-budget-planner-backend/internal/core/domain"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// archiveRestorer checks the records of an archive bundle one list at a time, remapping
+// their IDs and collecting the conflicts that keep the bundle from being restored.
+type archiveRestorer struct {
+	restore       domain.ArchiveRestore
+	conflicts     []domain.ArchiveConflict
+	categoryKinds map[int]string
+	cardIDs       map[uuid.UUID]uuid.UUID
+	now           time.Time
+}
+
+func (r *archiveRestorer) conflict(kind, id, reason string, args ...interface{}) {
+	r.conflicts = append(r.conflicts, domain.ArchiveConflict{Kind: kind, ID: id, Reason: fmt.Sprintf(reason, args...)})
+}
+
+// ImportArchive restores an archive bundle into the account of the user. Every record
+// gets a new ID. Bundle categories with the name and kind of a category the user can
+// already use are mapped onto it, and the others are created. When any record conflicts
+// with the account or the rest of the bundle, nothing is stored and the conflicts are
+// returned; otherwise everything is stored in a single transaction.
+func (s *ImportService) ImportArchive(ctx context.Context, userID uuid.UUID, bundle domain.ArchiveBundle) (domain.ArchiveImportResult, error) {
+	if bundle.Format != domain.ExportFormat {
+		return domain.ArchiveImportResult{}, fmt.Errorf("%w: unknown bundle format %q", domain.ErrInvalidInput, bundle.Format)
+	}
+	if bundle.Version < 1 || bundle.Version > domain.ExportFormatVersion {
+		return domain.ArchiveImportResult{}, fmt.Errorf("%w: unsupported bundle version %d", domain.ErrInvalidInput, bundle.Version)
+	}
+
+	r := &archiveRestorer{
+		restore: domain.ArchiveRestore{
+			UserID:      userID,
+			CategoryIDs: make(map[int]int),
+		},
+		categoryKinds: make(map[int]string),
+		cardIDs:       make(map[uuid.UUID]uuid.UUID),
+		now:           time.Now(),
+	}
+
+	categories, err := s.categoryRepo.GetCategoryByUserID(ctx, userID)
+	if err != nil {
+		return domain.ArchiveImportResult{}, err
+	}
+	r.categories(bundle.Categories, categories)
+
+	cards, err := s.cardRepo.FetchAllByUserID(ctx, userID)
+	if err != nil {
+		return domain.ArchiveImportResult{}, err
+	}
+	r.creditCards(bundle.CreditCards, cards)
+
+	var externalIDs []string
+	for _, e := range bundle.SimpleExpenses {
+		if e.ExternalID != nil {
+			externalIDs = append(externalIDs, *e.ExternalID)
+		}
+	}
+	for _, i := range bundle.Incomes {
+		if i.ExternalID != nil {
+			externalIDs = append(externalIDs, *i.ExternalID)
+		}
+	}
+	imported, err := s.repo.FindImportedExternalIDs(ctx, userID, externalIDs)
+	if err != nil {
+		return domain.ArchiveImportResult{}, err
+	}
+	r.simpleExpenses(bundle.SimpleExpenses, imported)
+	r.recurringExpenses(bundle.RecurringExpenses)
+	r.creditCardExpenses(bundle.CreditCardExpenses)
+	r.budgets(bundle.Budgets)
+	r.incomes(bundle.Incomes, imported)
+	r.cardPayments(bundle.CardPayments)
+
+	result := domain.ArchiveImportResult{
+		Counts: map[string]int{
+			"categories":           len(r.restore.NewCategories),
+			"credit_cards":         len(r.restore.CreditCards),
+			"simple_expenses":      len(r.restore.SimpleExpenses),
+			"recurring_expenses":   len(r.restore.RecurringExpenses),
+			"credit_card_expenses": len(r.restore.CreditCardExpenses),
+			"budgets":              len(r.restore.Budgets),
+			"incomes":              len(r.restore.Incomes),
+			"card_payments":        len(r.restore.CardPayments),
+		},
+		Conflicts: r.conflicts,
+	}
+	if len(result.Conflicts) > 0 {
+		return result, nil
+	}
+	result.Conflicts = []domain.ArchiveConflict{}
+
+	if err := s.repo.RestoreArchive(ctx, r.restore); err != nil {
+		return domain.ArchiveImportResult{}, err
+	}
+	result.Restored = true
+	return result, nil
+}
+
+func (r *archiveRestorer) categories(bundle []domain.Category, existing []domain.Category) {
+	byName := make(map[string]int, len(existing))
+	for _, c := range existing {
+		key := c.Kind + ":" + strings.ToLower(strings.TrimSpace(c.Name))
+		// The user's own categories win over shared ones with the same name.
+		if _, ok := byName[key]; !ok || c.UserID != uuid.Nil {
+			byName[key] = c.ID
+		}
+	}
+
+	for _, c := range bundle {
+		id := strconv.Itoa(c.ID)
+		if _, ok := r.categoryKinds[c.ID]; ok {
+			r.conflict("categories", id, "duplicate category id")
+			continue
+		}
+		if c.Kind == "" {
+			c.Kind = domain.CategoryKindExpense
+		}
+		if c.Kind != domain.CategoryKindExpense && c.Kind != domain.CategoryKindIncome {
+			r.conflict("categories", id, "unknown category kind %q", c.Kind)
+			continue
+		}
+		name := strings.TrimSpace(c.Name)
+		if name == "" {
+			r.conflict("categories", id, "category name is empty")
+			continue
+		}
+		r.categoryKinds[c.ID] = c.Kind
+
+		key := c.Kind + ":" + strings.ToLower(name)
+		if existingID, ok := byName[key]; ok {
+			r.restore.CategoryIDs[c.ID] = existingID
+			continue
+		}
+		c.Name, c.UserID = name, r.restore.UserID
+		r.restore.NewCategories = append(r.restore.NewCategories, c)
+	}
+}
+
+// category checks a category reference of a record against the categories of the bundle,
+// which must be of categoryKind.
+func (r *archiveRestorer) category(kind, id string, categoryID int, categoryKind string) bool {
+	bundleKind, ok := r.categoryKinds[categoryID]
+	if !ok {
+		r.conflict(kind, id, "category %d is missing from the bundle or conflicts", categoryID)
+		return false
+	}
+	if bundleKind != categoryKind {
+		r.conflict(kind, id, "category %d is not an %s category", categoryID, categoryKind)
+		return false
+	}
+	return true
+}
+
+// amount checks that an amount of a record is greater than zero.
+func (r *archiveRestorer) amount(kind, id, field string, amount domain.Money) bool {
+	if amount <= 0 {
+		r.conflict(kind, id, "%s must be greater than zero, got %s", field, amount)
+		return false
+	}
+	return true
+}
+
+// card maps a card reference of a record onto the card restored in its place.
+func (r *archiveRestorer) card(kind, id string, cardID uuid.UUID) (uuid.UUID, bool) {
+	newID, ok := r.cardIDs[cardID]
+	if !ok {
+		r.conflict(kind, id, "credit card %s is missing from the bundle or conflicts", cardID)
+	}
+	return newID, ok
+}
+
+func (r *archiveRestorer) currency(kind, id string, currency string) (string, bool) {
+	currency = domain.NormalizeCurrency(currency)
+	if !domain.IsValidCurrency(currency) {
+		r.conflict(kind, id, "invalid currency %q", currency)
+		return "", false
+	}
+	return currency, true
+}
+
+func (r *archiveRestorer) timestamps(createdAt, updatedAt *time.Time) {
+	if createdAt.IsZero() {
+		*createdAt = r.now
+	}
+	if updatedAt.IsZero() {
+		*updatedAt = *createdAt
+	}
+}
+
+func (r *archiveRestorer) creditCards(bundle []domain.CreditCard, existing []domain.CreditCard) {
+	names := make(map[string]bool, len(existing))
+	for _, cc := range existing {
+		names[strings.ToLower(strings.TrimSpace(cc.CardName))] = true
+	}
+
+	for _, cc := range bundle {
+		id := cc.ID.String()
+		if _, ok := r.cardIDs[cc.ID]; ok {
+			r.conflict("credit_cards", id, "duplicate credit card id")
+			continue
+		}
+		name := strings.ToLower(strings.TrimSpace(cc.CardName))
+		switch {
+		case name == "":
+			r.conflict("credit_cards", id, "credit card name is empty")
+			continue
+		case names[name]:
+			r.conflict("credit_cards", id, "a credit card named %q already exists", cc.CardName)
+			continue
+		case cc.TotalLimit <= 0:
+			r.conflict("credit_cards", id, "total limit must be greater than zero, got %s", cc.TotalLimit)
+			continue
+		case cc.DueDate < 1 || cc.DueDate > 31:
+			r.conflict("credit_cards", id, "invalid due day %d", cc.DueDate)
+			continue
+		}
+		names[name] = true
+		if cc.ClosingDay == 0 {
+			cc.ClosingDay = domain.DefaultClosingDay(cc.DueDate)
+		}
+		if cc.ClosingDay < 1 || cc.ClosingDay > 31 {
+			r.conflict("credit_cards", id, "invalid closing day %d", cc.ClosingDay)
+			continue
+		}
+		currency, ok := r.currency("credit_cards", id, cc.Currency)
+		if !ok {
+			continue
+		}
+
+		newID := uuid.New()
+		r.cardIDs[cc.ID] = newID
+		cc.ID, cc.UserID, cc.Currency = newID, r.restore.UserID, currency
+		r.timestamps(&cc.CreatedAt, &cc.UpdatedAt)
+		r.restore.CreditCards = append(r.restore.CreditCards, cc)
+	}
+}
+
+func (r *archiveRestorer) simpleExpenses(bundle []domain.SimpleExpense, imported map[string]bool) {
+	ids := make(map[uuid.UUID]bool, len(bundle))
+	externalIDs := make(map[string]bool)
+	for _, e := range bundle {
+		id := e.ID.String()
+		if ids[e.ID] {
+			r.conflict("simple_expenses", id, "duplicate simple expense id")
+			continue
+		}
+		ids[e.ID] = true
+		if e.ExternalID != nil {
+			if imported[*e.ExternalID] {
+				r.conflict("simple_expenses", id, "external id %q was already imported", *e.ExternalID)
+				continue
+			}
+			if externalIDs[*e.ExternalID] {
+				r.conflict("simple_expenses", id, "duplicate external id %q", *e.ExternalID)
+				continue
+			}
+			externalIDs[*e.ExternalID] = true
+		}
+		if !r.category("simple_expenses", id, e.CategoryID, domain.CategoryKindExpense) || !r.amount("simple_expenses", id, "amount", e.Amount) {
+			continue
+		}
+		currency, ok := r.currency("simple_expenses", id, e.Currency)
+		if !ok {
+			continue
+		}
+
+		e.ID, e.UserID, e.Currency = uuid.New(), r.restore.UserID, currency
+		r.timestamps(&e.CreatedAt, &e.UpdatedAt)
+		r.restore.SimpleExpenses = append(r.restore.SimpleExpenses, e)
+	}
+}
+
+func (r *archiveRestorer) recurringExpenses(bundle []domain.RecurringExpense) {
+	newIDs := make(map[uuid.UUID]uuid.UUID, len(bundle))
+	templates := make(map[uuid.UUID]bool)
+	for _, e := range bundle {
+		if _, ok := newIDs[e.ID]; ok {
+			r.conflict("recurring_expenses", e.ID.String(), "duplicate recurring expense id")
+			continue
+		}
+		newIDs[e.ID] = uuid.New()
+		templates[e.ID] = e.IsTemplate()
+	}
+
+	// Templates go first so that their occurrences can refer to them.
+	restored := make([]domain.RecurringExpense, 0, len(bundle))
+	seen := make(map[uuid.UUID]bool, len(bundle))
+	for _, e := range bundle {
+		id := e.ID.String()
+		if seen[e.ID] {
+			continue
+		}
+		seen[e.ID] = true
+		if !r.category("recurring_expenses", id, e.CategoryID, domain.CategoryKindExpense) || !r.amount("recurring_expenses", id, "amount", e.Amount) {
+			continue
+		}
+		if !domain.IsValidFrequency(e.Frequency) {
+			r.conflict("recurring_expenses", id, "invalid frequency %q", e.Frequency)
+			continue
+		}
+		currency, ok := r.currency("recurring_expenses", id, e.Currency)
+		if !ok {
+			continue
+		}
+		if e.CardID != nil {
+			cardID, ok := r.card("recurring_expenses", id, *e.CardID)
+			if !ok {
+				continue
+			}
+			e.CardID = &cardID
+		}
+		if e.TemplateID != nil {
+			if !templates[*e.TemplateID] {
+				r.conflict("recurring_expenses", id, "template %s is not a recurring expense template of the bundle", e.TemplateID)
+				continue
+			}
+			templateID := newIDs[*e.TemplateID]
+			e.TemplateID = &templateID
+		}
+
+		e.ID, e.UserID, e.Currency = newIDs[e.ID], r.restore.UserID, currency
+		r.timestamps(&e.CreatedAt, &e.UpdatedAt)
+		restored = append(restored, e)
+	}
+	sort.SliceStable(restored, func(i, j int) bool {
+		return restored[i].IsTemplate() && !restored[j].IsTemplate()
+	})
+	r.restore.RecurringExpenses = restored
+}
+
+func (r *archiveRestorer) creditCardExpenses(bundle []domain.CreditCardExpense) {
+	ids := make(map[uuid.UUID]bool, len(bundle))
+	for _, e := range bundle {
+		id := e.ID.String()
+		if ids[e.ID] {
+			r.conflict("credit_card_expenses", id, "duplicate credit card expense id")
+			continue
+		}
+		ids[e.ID] = true
+		if !r.category("credit_card_expenses", id, e.CategoryID, domain.CategoryKindExpense) || !r.amount("credit_card_expenses", id, "amount", e.Amount) {
+			continue
+		}
+		cardID, ok := r.card("credit_card_expenses", id, e.CardID)
+		if !ok {
+			continue
+		}
+		if e.InstallmentsQuantity < 1 || e.ParcelNumber < 1 || e.ParcelNumber > e.InstallmentsQuantity {
+			r.conflict("credit_card_expenses", id, "invalid parcel %d of %d", e.ParcelNumber, e.InstallmentsQuantity)
+			continue
+		}
+		currency, ok := r.currency("credit_card_expenses", id, e.Currency)
+		if !ok {
+			continue
+		}
+
+		e.ID, e.UserID, e.CardID, e.Currency = uuid.New(), r.restore.UserID, cardID, currency
+		r.timestamps(&e.CreatedAt, &e.UpdatedAt)
+		r.restore.CreditCardExpenses = append(r.restore.CreditCardExpenses, e)
+	}
+}
+
+func (r *archiveRestorer) budgets(bundle []domain.Budget) {
+	ids := make(map[int]bool, len(bundle))
+	for _, b := range bundle {
+		id := strconv.Itoa(b.ID)
+		if ids[b.ID] {
+			r.conflict("budgets", id, "duplicate budget id")
+			continue
+		}
+		ids[b.ID] = true
+		name := strings.TrimSpace(b.Name)
+		if name == "" {
+			r.conflict("budgets", id, "budget name is empty")
+			continue
+		}
+		if !r.amount("budgets", id, "amount", b.Amount) {
+			continue
+		}
+		switch b.Period {
+		case domain.BudgetPeriodWeekly, domain.BudgetPeriodMonthly, domain.BudgetPeriodYearly:
+		case domain.BudgetPeriodCustom:
+			if b.EndDate == nil {
+				r.conflict("budgets", id, "custom budgets require an end date")
+				continue
+			}
+		default:
+			r.conflict("budgets", id, "unknown budget period %q", b.Period)
+			continue
+		}
+		if b.StartDate.IsZero() || (b.EndDate != nil && b.EndDate.Before(b.StartDate)) {
+			r.conflict("budgets", id, "invalid budget dates")
+			continue
+		}
+		valid := true
+		for _, categoryID := range b.CategoryIDs {
+			if !r.category("budgets", id, categoryID, domain.CategoryKindExpense) {
+				valid = false
+				break
+			}
+		}
+		if !valid {
+			continue
+		}
+
+		b.Name, b.UserID = name, r.restore.UserID
+		r.timestamps(&b.CreatedAt, &b.UpdatedAt)
+		r.restore.Budgets = append(r.restore.Budgets, b)
+	}
+}
+
+func (r *archiveRestorer) incomes(bundle []domain.Income, imported map[string]bool) {
+	ids := make(map[uuid.UUID]bool, len(bundle))
+	externalIDs := make(map[string]bool)
+	for _, i := range bundle {
+		id := i.ID.String()
+		if ids[i.ID] {
+			r.conflict("incomes", id, "duplicate income id")
+			continue
+		}
+		ids[i.ID] = true
+		if i.ExternalID != nil {
+			if imported[*i.ExternalID] {
+				r.conflict("incomes", id, "external id %q was already imported", *i.ExternalID)
+				continue
+			}
+			if externalIDs[*i.ExternalID] {
+				r.conflict("incomes", id, "duplicate external id %q", *i.ExternalID)
+				continue
+			}
+			externalIDs[*i.ExternalID] = true
+		}
+		if !r.category("incomes", id, i.CategoryID, domain.CategoryKindIncome) || !r.amount("incomes", id, "amount", i.Amount) {
+			continue
+		}
+		if i.Date.IsZero() {
+			r.conflict("incomes", id, "income date is missing")
+			continue
+		}
+		if i.Frequency != nil && !domain.IsValidFrequency(*i.Frequency) {
+			r.conflict("incomes", id, "invalid frequency %q", *i.Frequency)
+			continue
+		}
+		if i.EndDate != nil && (i.Frequency == nil || i.EndDate.Before(i.Date)) {
+			r.conflict("incomes", id, "invalid end date")
+			continue
+		}
+		currency, ok := r.currency("incomes", id, i.Currency)
+		if !ok {
+			continue
+		}
+
+		i.ID, i.UserID, i.Currency = uuid.New(), r.restore.UserID, currency
+		r.timestamps(&i.CreatedAt, &i.UpdatedAt)
+		r.restore.Incomes = append(r.restore.Incomes, i)
+	}
+}
+
+func (r *archiveRestorer) cardPayments(bundle []domain.CardPayment) {
+	ids := make(map[uuid.UUID]bool, len(bundle))
+	for _, p := range bundle {
+		id := p.ID.String()
+		if ids[p.ID] {
+			r.conflict("card_payments", id, "duplicate card payment id")
+			continue
+		}
+		ids[p.ID] = true
+		cardID, ok := r.card("card_payments", id, p.CardID)
+		if !ok || !r.amount("card_payments", id, "amount", p.Amount) {
+			continue
+		}
+		if p.PaidAt.IsZero() || p.StatementMonth.IsZero() {
+			r.conflict("card_payments", id, "payment date or statement month is missing")
+			continue
+		}
+
+		p.ID, p.UserID, p.CardID = uuid.New(), r.restore.UserID, cardID
+		p.StatementMonth = time.Date(p.StatementMonth.Year(), p.StatementMonth.Month(), 1, 0, 0, 0, 0, time.UTC)
+		r.timestamps(&p.CreatedAt, &p.UpdatedAt)
+		r.restore.CardPayments = append(r.restore.CardPayments, p)
+	}
+}
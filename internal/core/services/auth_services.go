@@ -2,71 +2,107 @@ package services
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"github.com/google/uuid"
 	"github.com/misalima/my-budget-planner-backend/internal/api/http/auth"
 	"github.com/misalima/my-budget-planner-backend/internal/core/domain"
 	"github.com/misalima/my-budget-planner-backend/internal/core/interfaces/irepository"
 	"golang.org/x/crypto/bcrypt"
+	"strings"
 	"time"
+	"unicode/utf8"
 )
 
 type AuthService struct {
 	authRepo irepository.AuthLoader
 	userRepo irepository.UserLoader
+	totpRepo irepository.TOTPLoader
 }
 
-func NewAuthService(authRepo irepository.AuthLoader, userRepo irepository.UserLoader) *AuthService {
+func NewAuthService(authRepo irepository.AuthLoader, userRepo irepository.UserLoader, totpRepo irepository.TOTPLoader) *AuthService {
 	return &AuthService{
 		authRepo: authRepo,
 		userRepo: userRepo,
+		totpRepo: totpRepo,
 	}
 }
 
-func (s *AuthService) Login(email, password string) (string, string, error) {
+const (
+	// Longest values stored for the client of a session, in characters.
+	maxSessionUserAgent   = 512
+	maxSessionIPAddress   = 45
+	maxSessionDeviceLabel = 100
+)
+
+// Login checks the password and returns the token pair, unless the user has two-factor
+// authentication on: then it returns an MFA token for CompleteMFALogin instead.
+func (s *AuthService) Login(email, password string, client domain.SessionClient) (domain.LoginResult, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
 	user, err := s.userRepo.GetUserByEmail(ctx, email)
 	if err != nil {
-		return "", "", fmt.Errorf("invalid email or password")
+		return domain.LoginResult{}, fmt.Errorf("invalid email or password")
 	}
 	if user == nil {
-		return "", "", fmt.Errorf("user not found")
+		return domain.LoginResult{}, fmt.Errorf("user not found")
 	}
 
 	err = s.CheckPasswords(password, user.Password)
 	if err != nil {
-		return "", "", err
+		return domain.LoginResult{}, err
 	}
 
-	accessToken, err := auth.GenerateAccessToken(user.ID)
-	if err != nil {
-		return "", "", err
+	totp, err := s.totpRepo.GetTOTP(ctx, user.ID)
+	if err != nil && !errors.Is(err, domain.ErrNotFound) {
+		return domain.LoginResult{}, err
+	}
+	if err == nil && totp.Enabled() {
+		mfaToken, err := auth.GenerateMFAToken(user.ID)
+		if err != nil {
+			return domain.LoginResult{}, err
+		}
+		return domain.LoginResult{MFARequired: true, MFAToken: mfaToken}, nil
 	}
 
-	refreshToken, err := auth.GenerateRefreshToken(user.ID)
+	return s.issueTokens(user.ID, client)
+}
+
+// issueTokens returns a new token pair for the user, starting a new session.
+func (s *AuthService) issueTokens(userId uuid.UUID, client domain.SessionClient) (domain.LoginResult, error) {
+	sessionId := uuid.New()
+	accessToken, err := auth.GenerateAccessToken(userId, sessionId)
 	if err != nil {
-		return "", "", err
+		return domain.LoginResult{}, err
 	}
 
-	err = s.SaveRefreshToken(user.ID, refreshToken)
+	refreshToken, err := auth.GenerateRefreshToken(userId)
 	if err != nil {
-		return "", "", err
+		return domain.LoginResult{}, err
 	}
 
-	return accessToken, refreshToken, nil
+	err = s.SaveRefreshToken(userId, sessionId, refreshToken, client)
+	if err != nil {
+		return domain.LoginResult{}, err
+	}
 
+	return domain.LoginResult{AccessToken: accessToken, RefreshToken: refreshToken}, nil
 }
 
-func (s *AuthService) SaveRefreshToken(userId uuid.UUID, refreshToken string) error {
+// SaveRefreshToken stores the refresh token issued at login as the first of a new family,
+// which is a new session of the client.
+func (s *AuthService) SaveRefreshToken(userId uuid.UUID, sessionId uuid.UUID, refreshToken string, client domain.SessionClient) error {
 
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
-	return s.authRepo.StoreRefreshToken(ctx, userId, refreshToken)
+	return s.authRepo.StoreRefreshToken(ctx, userId, sessionId, refreshToken, normalizeSessionClient(client))
 }
 
+// ValidateRefreshToken checks that the token is stored, belongs to the user and is still
+// valid. A token that was already used or revoked is a sign that it was stolen, so its
+// whole family is revoked.
 func (s *AuthService) ValidateRefreshToken(ctx context.Context, userId uuid.UUID, token string) (domain.RefreshToken, error) {
 
 	refreshToken, err := s.authRepo.GetRefreshToken(ctx, token)
@@ -78,6 +114,10 @@ func (s *AuthService) ValidateRefreshToken(ctx context.Context, userId uuid.UUID
 		return refreshToken, fmt.Errorf("refresh token doesnt belong to requesting user")
 	}
 
+	if refreshToken.RevokedAt != nil {
+		return refreshToken, s.revokeReusedFamily(ctx, refreshToken)
+	}
+
 	if time.Now().After(refreshToken.ExpiresAt) {
 		return refreshToken, fmt.Errorf("refresh token has expired")
 	}
@@ -85,6 +125,13 @@ func (s *AuthService) ValidateRefreshToken(ctx context.Context, userId uuid.UUID
 	return refreshToken, nil
 }
 
+func (s *AuthService) revokeReusedFamily(ctx context.Context, refreshToken domain.RefreshToken) error {
+	if err := s.authRepo.RevokeRefreshTokenFamily(ctx, refreshToken.FamilyID); err != nil {
+		return err
+	}
+	return domain.ErrRefreshTokenReused
+}
+
 func (s *AuthService) DeleteRefreshToken(token string) error {
 
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
@@ -93,21 +140,91 @@ func (s *AuthService) DeleteRefreshToken(token string) error {
 	return s.authRepo.DeleteRefreshToken(ctx, token)
 }
 
-func (s *AuthService) RefreshToken(userId uuid.UUID, token string) (string, error) {
+// RefreshToken rotates the refresh token: it returns a new access token and a new
+// refresh token of the same family, and the presented one stops working. The user is
+// the one the refresh token was signed for, so no access token is needed.
+func (s *AuthService) RefreshToken(token string, client domain.SessionClient) (string, string, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
+	userId, err := auth.ParseRefreshToken(token)
+	if err != nil {
+		return "", "", fmt.Errorf("invalid refresh token")
+	}
+
 	refreshToken, err := s.ValidateRefreshToken(ctx, userId, token)
 	if err != nil {
-		return "", err
+		return "", "", err
 	}
 
-	newAccessToken, err := auth.GenerateAccessToken(refreshToken.UserID)
+	newAccessToken, err := auth.GenerateAccessToken(refreshToken.UserID, refreshToken.FamilyID)
 	if err != nil {
-		return "", err
+		return "", "", err
+	}
+	newRefreshToken, err := auth.GenerateRefreshToken(refreshToken.UserID)
+	if err != nil {
+		return "", "", err
 	}
 
-	return newAccessToken, nil
+	err = s.authRepo.RotateRefreshToken(ctx, refreshToken, newRefreshToken, normalizeSessionClient(client))
+	if errors.Is(err, domain.ErrRefreshTokenReused) {
+		// Another refresh used the same token first.
+		return "", "", s.revokeReusedFamily(ctx, refreshToken)
+	}
+	if err != nil {
+		return "", "", err
+	}
+
+	return newAccessToken, newRefreshToken, nil
+}
+
+func (s *AuthService) ListSessions(ctx context.Context, userId uuid.UUID) ([]domain.Session, error) {
+	return s.authRepo.FetchSessions(ctx, userId)
+}
+
+// RevokeSession ends one session of the user: its refresh tokens stop working, and so do
+// the access tokens already issued to it.
+func (s *AuthService) RevokeSession(ctx context.Context, userId uuid.UUID, sessionId uuid.UUID) error {
+	return s.authRepo.DeleteSession(ctx, userId, sessionId)
+}
+
+func (s *AuthService) PurgeExpiredRefreshTokens(ctx context.Context) (int, error) {
+	deleted, err := s.authRepo.DeleteExpiredRefreshTokens(ctx, time.Now())
+	return int(deleted), err
+}
+
+// LogoutAll deletes every refresh token of the user and rejects every access token
+// issued until now. Tokens only carry their issue time to the second, so the cut is
+// made at the start of the next second: a token issued earlier in the current second
+// cannot be told apart from one issued before the logout, so it is rejected as well.
+func (s *AuthService) LogoutAll(ctx context.Context, userId uuid.UUID) error {
+	now := time.Now()
+	cutoff := now.Truncate(time.Second)
+	if cutoff.Before(now) {
+		cutoff = cutoff.Add(time.Second)
+	}
+	return s.authRepo.RevokeUserTokens(ctx, userId, cutoff)
+}
+
+// ValidateAccessToken rejects access tokens issued before the user last logged out of
+// every device, and those of a session that was ended. Tokens without an issue time are
+// only accepted until the user first logs out of every device.
+func (s *AuthService) ValidateAccessToken(ctx context.Context, userId uuid.UUID, sessionId uuid.UUID, issuedAt time.Time) error {
+	validAfter, err := s.authRepo.GetTokensValidAfter(ctx, userId)
+	if err != nil {
+		return err
+	}
+	if validAfter != nil && issuedAt.Before(*validAfter) {
+		return fmt.Errorf("access token has been revoked")
+	}
+	active, err := s.authRepo.IsSessionActive(ctx, userId, sessionId)
+	if err != nil {
+		return err
+	}
+	if !active {
+		return fmt.Errorf("access token session has ended")
+	}
+	return nil
 }
 
 func (s *AuthService) CheckPasswords(password, hashedPassword string) error {
@@ -117,3 +234,69 @@ func (s *AuthService) CheckPasswords(password, hashedPassword string) error {
 	}
 	return nil
 }
+
+// normalizeSessionClient trims the client details to what is stored, naming the device after its
+// user agent when the client did not give it a label.
+func normalizeSessionClient(client domain.SessionClient) domain.SessionClient {
+	client.UserAgent = truncateRunes(strings.TrimSpace(client.UserAgent), maxSessionUserAgent)
+	client.IPAddress = truncateRunes(strings.TrimSpace(client.IPAddress), maxSessionIPAddress)
+	client.DeviceLabel = strings.Join(strings.Fields(client.DeviceLabel), " ")
+	if client.DeviceLabel == "" {
+		client.DeviceLabel = deviceLabel(client.UserAgent)
+	}
+	client.DeviceLabel = truncateRunes(client.DeviceLabel, maxSessionDeviceLabel)
+	return client
+}
+
+// deviceLabel describes a user agent as its browser and operating system, such as
+// "Firefox on Windows". User agents of other clients are named after their product.
+func deviceLabel(userAgent string) string {
+	var browser, system string
+	switch {
+	case strings.Contains(userAgent, "Edg/"):
+		browser = "Edge"
+	case strings.Contains(userAgent, "OPR/"):
+		browser = "Opera"
+	case strings.Contains(userAgent, "Firefox/"), strings.Contains(userAgent, "FxiOS/"):
+		browser = "Firefox"
+	case strings.Contains(userAgent, "Chrome/"), strings.Contains(userAgent, "CriOS/"):
+		browser = "Chrome"
+	case strings.Contains(userAgent, "Safari/"):
+		browser = "Safari"
+	}
+	// iOS and Android user agents also mention macOS and Linux, so they are checked first.
+	switch {
+	case strings.Contains(userAgent, "iPhone"):
+		system = "iPhone"
+	case strings.Contains(userAgent, "iPad"):
+		system = "iPad"
+	case strings.Contains(userAgent, "Android"):
+		system = "Android"
+	case strings.Contains(userAgent, "Windows"):
+		system = "Windows"
+	case strings.Contains(userAgent, "Mac OS X"):
+		system = "macOS"
+	case strings.Contains(userAgent, "Linux"):
+		system = "Linux"
+	}
+
+	switch {
+	case browser != "" && system != "":
+		return browser + " on " + system
+	case browser != "":
+		return browser
+	case system != "":
+		return system
+	}
+	if product, _, _ := strings.Cut(userAgent, "/"); strings.TrimSpace(product) != "" {
+		return strings.Fields(product)[0]
+	}
+	return "Unknown device"
+}
+
+func truncateRunes(value string, max int) string {
+	if utf8.RuneCountInString(value) <= max {
+		return value
+	}
+	return string([]rune(value)[:max])
+}
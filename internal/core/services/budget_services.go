@@ -0,0 +1,253 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/misalima/my-budget-planner-backend/internal/core/domain"
+	"github.com/misalima/my-budget-planner-backend/internal/core/interfaces/irepository"
+	"time"
+)
+
+type BudgetService struct {
+	repo                  irepository.BudgetLoader
+	categoryRepo          irepository.CategoryLoader
+	simpleExpenseRepo     irepository.SimpleExpenseLoader
+	recurringExpenseRepo  irepository.RecurringExpenseLoader
+	creditCardExpenseRepo irepository.CreditCardExpenseLoader
+	userRepo              irepository.UserLoader
+	exchangeRateRepo      irepository.ExchangeRateLoader
+}
+
+func NewBudgetService(
+	repo irepository.BudgetLoader,
+	categoryRepo irepository.CategoryLoader,
+	simpleExpenseRepo irepository.SimpleExpenseLoader,
+	recurringExpenseRepo irepository.RecurringExpenseLoader,
+	creditCardExpenseRepo irepository.CreditCardExpenseLoader,
+	userRepo irepository.UserLoader,
+	exchangeRateRepo irepository.ExchangeRateLoader,
+) *BudgetService {
+	return &BudgetService{
+		repo:                  repo,
+		categoryRepo:          categoryRepo,
+		simpleExpenseRepo:     simpleExpenseRepo,
+		recurringExpenseRepo:  recurringExpenseRepo,
+		creditCardExpenseRepo: creditCardExpenseRepo,
+		userRepo:              userRepo,
+		exchangeRateRepo:      exchangeRateRepo,
+	}
+}
+
+func (s *BudgetService) CreateBudget(ctx context.Context, budget domain.Budget) (domain.Budget, error) {
+	if budget.Period == "" {
+		// Budgets created with an explicit end date and no period keep the original one-off behaviour.
+		budget.Period = domain.BudgetPeriodMonthly
+		if budget.EndDate != nil {
+			budget.Period = domain.BudgetPeriodCustom
+		}
+	}
+	if err := s.validateBudget(ctx, budget); err != nil {
+		return domain.Budget{}, err
+	}
+	return s.repo.InsertBudget(ctx, budget)
+}
+
+func (s *BudgetService) UpdateBudget(ctx context.Context, budget domain.Budget) (domain.Budget, error) {
+	if _, err := s.GetBudgetByID(ctx, budget.ID, budget.UserID); err != nil {
+		return domain.Budget{}, err
+	}
+	if err := s.validateBudget(ctx, budget); err != nil {
+		return domain.Budget{}, err
+	}
+	return s.repo.UpdateBudget(ctx, budget)
+}
+
+func (s *BudgetService) DeleteBudget(ctx context.Context, id int, userID uuid.UUID) error {
+	if _, err := s.GetBudgetByID(ctx, id, userID); err != nil {
+		return err
+	}
+	return s.repo.DeleteBudget(ctx, id)
+}
+
+func (s *BudgetService) GetBudgetByID(ctx context.Context, id int, userID uuid.UUID) (domain.Budget, error) {
+	budget, err := s.repo.FindBudgetByID(ctx, id)
+	if err != nil {
+		return budget, err
+	}
+	if budget.UserID != userID {
+		return domain.Budget{}, domain.ErrNotFound
+	}
+	return budget, nil
+}
+
+func (s *BudgetService) ListBudgets(ctx context.Context, userID uuid.UUID) ([]domain.Budget, error) {
+	return s.repo.FindBudgetsByUser(ctx, userID)
+}
+
+func (s *BudgetService) GetBudgetConsumption(ctx context.Context, id int, userID uuid.UUID, date time.Time) (domain.BudgetConsumption, error) {
+	budget, err := s.GetBudgetByID(ctx, id, userID)
+	if err != nil {
+		return domain.BudgetConsumption{}, err
+	}
+	periods, err := s.computePeriods(ctx, budget, date)
+	if err != nil {
+		return domain.BudgetConsumption{}, err
+	}
+	for _, p := range periods {
+		if (domain.DateRange{Start: p.PeriodStart, End: p.PeriodEnd}).Contains(date) {
+			return p, nil
+		}
+	}
+	return domain.BudgetConsumption{}, fmt.Errorf("%w: budget has no period containing %s", domain.ErrInvalidInput, date.Format("2006-01-02"))
+}
+
+func (s *BudgetService) ListBudgetConsumptions(ctx context.Context, userID uuid.UUID, date time.Time) ([]domain.BudgetConsumption, error) {
+	budgets, err := s.repo.FindBudgetsByUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	consumptions := []domain.BudgetConsumption{}
+	for _, budget := range budgets {
+		periods, err := s.computePeriods(ctx, budget, date)
+		if err != nil {
+			return nil, err
+		}
+		for _, p := range periods {
+			if (domain.DateRange{Start: p.PeriodStart, End: p.PeriodEnd}).Contains(date) {
+				consumptions = append(consumptions, p)
+			}
+		}
+	}
+	return consumptions, nil
+}
+
+func (s *BudgetService) ListBudgetPeriods(ctx context.Context, id int, userID uuid.UUID, from, to time.Time) ([]domain.BudgetConsumption, error) {
+	budget, err := s.GetBudgetByID(ctx, id, userID)
+	if err != nil {
+		return nil, err
+	}
+	periods, err := s.computePeriods(ctx, budget, to)
+	if err != nil {
+		return nil, err
+	}
+	result := []domain.BudgetConsumption{}
+	for _, p := range periods {
+		if !p.PeriodEnd.Before(from) {
+			result = append(result, p)
+		}
+	}
+	return result, nil
+}
+
+// computePeriods walks every period of the budget from its start up to the one containing until,
+// so that rolled over amounts are carried from the very first period. Recurring expenses count
+// through their stored occurrences, and expenses are converted into the user's base currency
+// at the rate of their own date.
+func (s *BudgetService) computePeriods(ctx context.Context, budget domain.Budget, until time.Time) ([]domain.BudgetConsumption, error) {
+	periods := budget.PeriodsUntil(until)
+	if len(periods) == 0 {
+		return nil, nil
+	}
+	rangeStart, rangeEnd := periods[0].Start, periods[len(periods)-1].End
+
+	simpleExpenses, err := s.simpleExpenseRepo.FindSimpleExpensesByDateRange(ctx, budget.UserID, rangeStart, rangeEnd)
+	if err != nil {
+		return nil, err
+	}
+	recurringExpenses, err := s.recurringExpenseRepo.FindGeneratedRecurringExpensesByDateRange(ctx, budget.UserID, rangeStart, rangeEnd)
+	if err != nil {
+		return nil, err
+	}
+	creditCardExpenses, err := s.creditCardExpenseRepo.FindCreditCardExpensesByDateRange(ctx, budget.UserID, rangeStart, rangeEnd)
+	if err != nil {
+		return nil, err
+	}
+	converter, err := newBaseCurrencyConverter(ctx, s.userRepo, s.exchangeRateRepo, budget.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	consumptions := make([]domain.BudgetConsumption, 0, len(periods))
+	carried := domain.Money(0)
+	for _, period := range periods {
+		c := domain.BudgetConsumption{Budget: budget, PeriodStart: period.Start, PeriodEnd: period.End, Currency: converter.target}
+		if budget.Rollover {
+			c.CarriedOver = carried
+		}
+		c.AvailableAmount = budget.Amount + c.CarriedOver
+
+		for _, e := range simpleExpenses {
+			if budget.CoversCategory(e.CategoryID) && period.Contains(e.Date) {
+				amount, err := converter.convert(ctx, e.Amount, e.Currency, e.Date)
+				if err != nil {
+					return nil, err
+				}
+				c.SimpleExpensesTotal += amount
+			}
+		}
+		for _, e := range recurringExpenses {
+			if budget.CoversCategory(e.CategoryID) && period.Contains(e.Date) {
+				amount, err := converter.convert(ctx, e.Amount, e.Currency, e.Date)
+				if err != nil {
+					return nil, err
+				}
+				c.RecurringExpensesTotal += amount
+			}
+		}
+		for _, e := range creditCardExpenses {
+			if budget.CoversCategory(e.CategoryID) && period.Contains(e.Date) {
+				amount, err := converter.convert(ctx, e.InstallmentValue(), e.Currency, e.Date)
+				if err != nil {
+					return nil, err
+				}
+				c.CreditCardExpensesTotal += amount
+			}
+		}
+
+		c.SpentAmount = c.SimpleExpensesTotal + c.RecurringExpensesTotal + c.CreditCardExpensesTotal
+		c.RemainingAmount = c.AvailableAmount - c.SpentAmount
+		if c.AvailableAmount > 0 {
+			c.PercentageUsed = c.SpentAmount.Float64() / c.AvailableAmount.Float64() * 100
+		}
+		carried = c.RemainingAmount
+		consumptions = append(consumptions, c)
+	}
+	return consumptions, nil
+}
+
+func (s *BudgetService) validateBudget(ctx context.Context, budget domain.Budget) error {
+	if budget.Amount <= 0 {
+		return fmt.Errorf("%w: budget amount must be greater than zero", domain.ErrInvalidInput)
+	}
+	switch budget.Period {
+	case domain.BudgetPeriodWeekly, domain.BudgetPeriodMonthly, domain.BudgetPeriodYearly:
+	case domain.BudgetPeriodCustom:
+		if budget.EndDate == nil {
+			return fmt.Errorf("%w: custom budgets require an end date", domain.ErrInvalidInput)
+		}
+	default:
+		return fmt.Errorf("%w: unknown budget period %q", domain.ErrInvalidInput, budget.Period)
+	}
+	if budget.EndDate != nil && budget.EndDate.Before(budget.StartDate) {
+		return fmt.Errorf("%w: budget end date must not be before its start date", domain.ErrInvalidInput)
+	}
+	if len(budget.CategoryIDs) == 0 {
+		return nil
+	}
+
+	categories, err := s.categoryRepo.GetCategoryByUserID(ctx, budget.UserID)
+	if err != nil {
+		return err
+	}
+	allowed := make(map[int]bool, len(categories))
+	for _, c := range categories {
+		allowed[c.ID] = true
+	}
+	for _, id := range budget.CategoryIDs {
+		if !allowed[id] {
+			return fmt.Errorf("%w: category %d does not exist", domain.ErrInvalidInput, id)
+		}
+	}
+	return nil
+}
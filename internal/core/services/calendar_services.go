@@ -0,0 +1,176 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/misalima/my-budget-planner-backend/internal/core/domain"
+	"github.com/misalima/my-budget-planner-backend/internal/core/interfaces/irepository"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	// calendarFeedMonthsBack and calendarFeedMonthsAhead bound the events of a feed,
+	// counted in months from the current one.
+	calendarFeedMonthsBack  = 1
+	calendarFeedMonthsAhead = 12
+	// calendarLineLength is the longest line, in octets, iCalendar allows before folding.
+	calendarLineLength = 75
+)
+
+type CalendarService struct {
+	repo                 irepository.CalendarLoader
+	cardRepo             irepository.CreditCardLoader
+	recurringExpenseRepo irepository.RecurringExpenseLoader
+}
+
+func NewCalendarService(
+	repo irepository.CalendarLoader,
+	cardRepo irepository.CreditCardLoader,
+	recurringExpenseRepo irepository.RecurringExpenseLoader,
+) *CalendarService {
+	return &CalendarService{
+		repo:                 repo,
+		cardRepo:             cardRepo,
+		recurringExpenseRepo: recurringExpenseRepo,
+	}
+}
+
+// CreateCalendarFeed issues a new feed token for the user, replacing the previous one,
+// which stops working. The token is returned only here.
+func (s *CalendarService) CreateCalendarFeed(ctx context.Context, userID uuid.UUID) (domain.CalendarFeed, error) {
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return domain.CalendarFeed{}, fmt.Errorf("failed to generate calendar feed token: %w", err)
+	}
+	feed := domain.CalendarFeed{
+		UserID:    userID,
+		Token:     base64.RawURLEncoding.EncodeToString(secret),
+		CreatedAt: time.Now(),
+	}
+	if err := s.repo.StoreCalendarFeed(ctx, feed, calendarTokenHash(feed.Token)); err != nil {
+		return domain.CalendarFeed{}, err
+	}
+	return feed, nil
+}
+
+func (s *CalendarService) RevokeCalendarFeed(ctx context.Context, userID uuid.UUID) error {
+	return s.repo.DeleteCalendarFeed(ctx, userID)
+}
+
+// RenderCalendarFeed returns the iCalendar feed opened by the token: the due dates of
+// every credit card and the occurrences of every recurring expense, from the previous
+// month to a year ahead.
+func (s *CalendarService) RenderCalendarFeed(ctx context.Context, token string) ([]byte, error) {
+	userID, err := s.repo.FindCalendarFeedUser(ctx, calendarTokenHash(token))
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	firstMonth := time.Date(now.Year(), now.Month()-calendarFeedMonthsBack, 1, 0, 0, 0, 0, time.UTC)
+	lastDay := time.Date(now.Year(), now.Month()+calendarFeedMonthsAhead+1, 0, 0, 0, 0, 0, time.UTC)
+
+	var events []domain.CalendarEvent
+	cards, err := s.cardRepo.FetchAllByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	for _, card := range cards {
+		for month := firstMonth; !month.After(lastDay); month = month.AddDate(0, 1, 0) {
+			_, closingDate, dueDate := card.StatementDates(month.Year(), month.Month())
+			events = append(events, domain.CalendarEvent{
+				UID:         fmt.Sprintf("card-%s-%s@my-budget-planner", card.ID, dueDate.Format("20060102")),
+				Date:        dueDate,
+				Summary:     fmt.Sprintf("%s bill due", card.CardName),
+				Description: fmt.Sprintf("Statement closed on %s.", closingDate.Format("2006-01-02")),
+			})
+		}
+	}
+
+	templates, err := s.recurringExpenseRepo.FindRecurringExpenses(ctx, userID, irepository.RecurringExpenseFilters{})
+	if err != nil {
+		return nil, err
+	}
+	for _, template := range templates {
+		summary := ledgerDescription(template.Description, "Recurring expense")
+		for _, date := range template.OccurrencesBetween(firstMonth, lastDay) {
+			events = append(events, domain.CalendarEvent{
+				UID:         fmt.Sprintf("recurring-%s-%s@my-budget-planner", template.ID, date.Format("20060102")),
+				Date:        date,
+				Summary:     fmt.Sprintf("%s: %s %s", summary, template.Amount, template.Currency),
+				Description: fmt.Sprintf("Recurring expense, %s.", template.Frequency),
+			})
+		}
+	}
+
+	sort.SliceStable(events, func(i, j int) bool {
+		return events[i].Date.Before(events[j].Date)
+	})
+	return writeCalendar(events, now), nil
+}
+
+func calendarTokenHash(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// writeCalendar renders the events as an iCalendar (RFC 5545) document of all-day events.
+func writeCalendar(events []domain.CalendarEvent, now time.Time) []byte {
+	var buf bytes.Buffer
+	line := func(format string, args ...interface{}) {
+		writeCalendarLine(&buf, fmt.Sprintf(format, args...))
+	}
+	line("BEGIN:VCALENDAR")
+	line("VERSION:2.0")
+	line("PRODID:-//My Budget Planner//Calendar Feed//EN")
+	line("CALSCALE:GREGORIAN")
+	line("METHOD:PUBLISH")
+	line("X-WR-CALNAME:My Budget Planner")
+	for _, event := range events {
+		line("BEGIN:VEVENT")
+		line("UID:%s", event.UID)
+		line("DTSTAMP:%s", now.Format("20060102T150405Z"))
+		line("DTSTART;VALUE=DATE:%s", event.Date.Format("20060102"))
+		line("DTEND;VALUE=DATE:%s", event.Date.AddDate(0, 0, 1).Format("20060102"))
+		line("SUMMARY:%s", escapeCalendarText(event.Summary))
+		if event.Description != "" {
+			line("DESCRIPTION:%s", escapeCalendarText(event.Description))
+		}
+		line("TRANSP:TRANSPARENT")
+		line("END:VEVENT")
+	}
+	line("END:VCALENDAR")
+	return buf.Bytes()
+}
+
+// writeCalendarLine ends the line with CRLF, folding it into continuation lines that
+// start with a space when it is too long. Lines are never cut inside a character.
+func writeCalendarLine(buf *bytes.Buffer, text string) {
+	limit := calendarLineLength
+	for len(text) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(text[cut]) {
+			cut--
+		}
+		buf.WriteString(text[:cut])
+		buf.WriteString("\r\n ")
+		text = text[cut:]
+		// The leading space of continuation lines counts towards their length.
+		limit = calendarLineLength - 1
+	}
+	buf.WriteString(text)
+	buf.WriteString("\r\n")
+}
+
+func escapeCalendarText(text string) string {
+	return strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`).Replace(text)
+}
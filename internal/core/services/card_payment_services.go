@@ -0,0 +1,77 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/misalima/my-budget-planner-backend/internal/core/domain"
+	"github.com/misalima/my-budget-planner-backend/internal/core/interfaces/irepository"
+	"time"
+)
+
+type CardPaymentService struct {
+	repo     irepository.CardPaymentLoader
+	cardRepo irepository.CreditCardLoader
+}
+
+func NewCardPaymentService(repo irepository.CardPaymentLoader, cardRepo irepository.CreditCardLoader) *CardPaymentService {
+	return &CardPaymentService{repo: repo, cardRepo: cardRepo}
+}
+
+// CreateCardPayment records a payment towards a statement of the card. Payments
+// without a statement month are applied to the first statement due on or after
+// the payment date.
+func (s *CardPaymentService) CreateCardPayment(ctx context.Context, payment domain.CardPayment) (domain.CardPayment, error) {
+	card, err := s.findCard(ctx, payment.CardID, payment.UserID)
+	if err != nil {
+		return domain.CardPayment{}, err
+	}
+	if payment.Amount <= 0 {
+		return domain.CardPayment{}, fmt.Errorf("%w: payment amount must be greater than zero", domain.ErrInvalidInput)
+	}
+	if payment.PaidAt.IsZero() {
+		payment.PaidAt = time.Now().UTC().Truncate(24 * time.Hour)
+	}
+	if payment.StatementMonth.IsZero() {
+		payment.StatementMonth = card.StatementMonthFor(payment.PaidAt)
+	} else {
+		payment.StatementMonth = time.Date(payment.StatementMonth.Year(), payment.StatementMonth.Month(), 1, 0, 0, 0, 0, time.UTC)
+	}
+	return s.repo.InsertCardPayment(ctx, payment)
+}
+
+func (s *CardPaymentService) DeleteCardPayment(ctx context.Context, cardID uuid.UUID, id uuid.UUID, userID uuid.UUID) error {
+	if _, err := s.GetCardPaymentByID(ctx, cardID, id, userID); err != nil {
+		return err
+	}
+	return s.repo.DeleteCardPayment(ctx, id)
+}
+
+func (s *CardPaymentService) GetCardPaymentByID(ctx context.Context, cardID uuid.UUID, id uuid.UUID, userID uuid.UUID) (domain.CardPayment, error) {
+	payment, err := s.repo.FindCardPaymentByID(ctx, id)
+	if err != nil {
+		return domain.CardPayment{}, err
+	}
+	if payment.UserID != userID || payment.CardID != cardID {
+		return domain.CardPayment{}, domain.ErrNotFound
+	}
+	return payment, nil
+}
+
+func (s *CardPaymentService) ListCardPayments(ctx context.Context, cardID uuid.UUID, userID uuid.UUID) ([]domain.CardPayment, error) {
+	if _, err := s.findCard(ctx, cardID, userID); err != nil {
+		return nil, err
+	}
+	return s.repo.FindCardPaymentsByCard(ctx, cardID)
+}
+
+func (s *CardPaymentService) findCard(ctx context.Context, cardID uuid.UUID, userID uuid.UUID) (*domain.CreditCard, error) {
+	card, err := s.cardRepo.FetchOneByID(ctx, cardID)
+	if err != nil {
+		return nil, err
+	}
+	if card.UserID != userID {
+		return nil, domain.ErrNotFound
+	}
+	return card, nil
+}
@@ -2,6 +2,7 @@ package services
 
 import (
 	"context"
+	"fmt"
 	"github.com/google/uuid"
 	"github.com/misalima/my-budget-planner-backend/internal/core/domain"
 	"github.com/misalima/my-budget-planner-backend/internal/core/interfaces/irepository"
@@ -21,6 +22,13 @@ func NewCategoryService(repo irepository.CategoryLoader) *CategoryService {
 func (c *CategoryService) CreateCategory(category *domain.Category) error {
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
+	switch category.Kind {
+	case "":
+		category.Kind = domain.CategoryKindExpense
+	case domain.CategoryKindExpense, domain.CategoryKindIncome:
+	default:
+		return fmt.Errorf("%w: unknown category kind %q", domain.ErrInvalidInput, category.Kind)
+	}
 	err := c.repo.CheckUserExists(ctx, category.UserID)
 	if err != nil {
 		return err
@@ -2,6 +2,7 @@ package services
 
 import (
 	"context"
+	"fmt"
 	"github.com/google/uuid"
 	"github.com/misalima/my-budget-planner-backend/internal/core/domain"
 	"github.com/misalima/my-budget-planner-backend/internal/core/interfaces/irepository"
@@ -9,21 +10,53 @@ import (
 )
 
 type CreditCardExpenseService struct {
-	repo irepository.CreditCardExpenseLoader
+	repo             irepository.CreditCardExpenseLoader
+	cardRepo         irepository.CreditCardLoader
+	userRepo         irepository.UserLoader
+	exchangeRateRepo irepository.ExchangeRateLoader
 }
 
-func NewCreditCardExpenseService(repo irepository.CreditCardExpenseLoader) *CreditCardExpenseService {
-	return &CreditCardExpenseService{repo: repo}
+func NewCreditCardExpenseService(
+	repo irepository.CreditCardExpenseLoader,
+	cardRepo irepository.CreditCardLoader,
+	userRepo irepository.UserLoader,
+	exchangeRateRepo irepository.ExchangeRateLoader,
+) *CreditCardExpenseService {
+	return &CreditCardExpenseService{
+		repo:             repo,
+		cardRepo:         cardRepo,
+		userRepo:         userRepo,
+		exchangeRateRepo: exchangeRateRepo,
+	}
 }
 
 func (s *CreditCardExpenseService) CreateCreditCardExpense(ctx context.Context, expense domain.CreditCardExpense) (domain.CreditCardExpense, error) {
+	currency, err := s.cardCurrency(ctx, expense.CardID, expense.UserID, expense.Currency)
+	if err != nil {
+		return domain.CreditCardExpense{}, err
+	}
+	expense.Currency = currency
+
+	if expense.InstallmentsQuantity <= 1 {
+		expense.InstallmentsQuantity = 1
+		expense.ParcelNumber = 1
+		expense.InstallmentAmount = expense.Amount
+	}
+
 	if expense.InstallmentsQuantity > 1 {
+		// Each parcel charges only its own share against the card limit. Unless the
+		// parcel value is given, the amount is split exactly and the first parcel
+		// takes the cents that do not divide evenly.
+		shares := expense.Amount.Split(expense.InstallmentsQuantity)
 		installments := make([]domain.CreditCardExpense, expense.InstallmentsQuantity)
 		for i := 0; i < expense.InstallmentsQuantity; i++ {
 			inst := expense
 			inst.ID = uuid.New()
-			inst.Date = expense.Date.AddDate(0, i, 0)
+			inst.Date = domain.AddMonthsClamped(expense.Date, i)
 			inst.ParcelNumber = i + 1
+			if expense.InstallmentAmount == 0 {
+				inst.InstallmentAmount = shares[i]
+			}
 			installments[i] = inst
 		}
 		err := s.repo.InsertInstallments(ctx, installments)
@@ -35,7 +68,40 @@ func (s *CreditCardExpenseService) CreateCreditCardExpense(ctx context.Context,
 	return s.repo.InsertCreditCardExpense(ctx, expense)
 }
 
+// UpdateCreditCardExpense updates the fields that are set. The amount of a single purchase
+// is also its installment value, so both change together. The amounts and the number of
+// parcels of a purchase in installments cannot change, as that would take splitting it again.
 func (s *CreditCardExpenseService) UpdateCreditCardExpense(ctx context.Context, expense domain.CreditCardExpense) (domain.CreditCardExpense, error) {
+	current, err := s.GetCreditCardExpenseByID(ctx, expense.ID, expense.UserID)
+	if err != nil {
+		return domain.CreditCardExpense{}, err
+	}
+	if expense.InstallmentsQuantity != 0 && expense.InstallmentsQuantity != current.InstallmentsQuantity {
+		return domain.CreditCardExpense{}, fmt.Errorf("%w: the number of installments cannot change, delete the purchase and register it again", domain.ErrInvalidInput)
+	}
+	if current.InstallmentsQuantity > 1 {
+		if (expense.Amount != 0 && expense.Amount != current.Amount) ||
+			(expense.InstallmentAmount != 0 && expense.InstallmentAmount != current.InstallmentAmount) {
+			return domain.CreditCardExpense{}, fmt.Errorf("%w: the amount of a purchase in installments cannot change, delete the purchase and register it again", domain.ErrInvalidInput)
+		}
+	} else {
+		if expense.Amount == 0 {
+			expense.Amount = expense.InstallmentAmount
+		}
+		expense.InstallmentAmount = expense.Amount
+	}
+
+	if expense.CardID != uuid.Nil || expense.Currency != "" {
+		cardID := expense.CardID
+		if cardID == uuid.Nil {
+			cardID = current.CardID
+		}
+		currency, err := s.cardCurrency(ctx, cardID, expense.UserID, expense.Currency)
+		if err != nil {
+			return domain.CreditCardExpense{}, err
+		}
+		expense.Currency = currency
+	}
 	return s.repo.UpdateCreditCardExpense(ctx, expense)
 }
 
@@ -65,24 +131,53 @@ func (s *CreditCardExpenseService) ListCreditCardExpenses(ctx context.Context, u
 	return s.repo.FindCreditCardExpenses(ctx, userID, filters)
 }
 
+// GetCreditCardExpenseSummary totals the parcels that fall in the range in the user's base
+// currency, converting each one at the rate of its date. Every parcel counts its own value,
+// not the total of the purchase it belongs to.
 func (s *CreditCardExpenseService) GetCreditCardExpenseSummary(ctx context.Context, userID uuid.UUID, startDate, endDate time.Time) (domain.CreditCardExpenseSummary, error) {
 	expenses, err := s.repo.FindCreditCardExpensesByDateRange(ctx, userID, startDate, endDate)
 	if err != nil {
 		return domain.CreditCardExpenseSummary{}, err
 	}
+	converter, err := newBaseCurrencyConverter(ctx, s.userRepo, s.exchangeRateRepo, userID)
+	if err != nil {
+		return domain.CreditCardExpenseSummary{}, err
+	}
 	var summary domain.CreditCardExpenseSummary
-	summary.ByCard = make(map[uuid.UUID]float64)
-	summary.ByCategory = make(map[int]float64)
-	summary.ByInstallmentsNumber = make(map[int]float64)
+	summary.Currency = converter.target
+	summary.ByCard = make(map[uuid.UUID]domain.Money)
+	summary.ByCategory = make(map[int]domain.Money)
+	summary.ByInstallmentsNumber = make(map[int]domain.Money)
 	for _, e := range expenses {
-		summary.TotalAmount += e.Amount
+		amount, err := converter.convert(ctx, e.InstallmentValue(), e.Currency, e.Date)
+		if err != nil {
+			return domain.CreditCardExpenseSummary{}, err
+		}
+		summary.TotalAmount += amount
 		summary.TotalCount++
-		summary.ByCard[e.CardID] += e.Amount
-		summary.ByCategory[e.CategoryID] += e.Amount
-		summary.ByInstallmentsNumber[e.InstallmentsQuantity] += e.Amount
+		summary.ByCard[e.CardID] += amount
+		summary.ByCategory[e.CategoryID] += amount
+		summary.ByInstallmentsNumber[e.InstallmentsQuantity] += amount
 	}
 	if summary.TotalCount > 0 {
-		summary.AverageAmount = summary.TotalAmount / float64(summary.TotalCount)
+		summary.AverageAmount = summary.TotalAmount.DivideBy(summary.TotalCount)
 	}
 	return summary, nil
 }
+
+// cardCurrency resolves the currency of a purchase on the card. Purchases are always
+// registered in the currency of their card, so its limit never mixes currencies.
+func (s *CreditCardExpenseService) cardCurrency(ctx context.Context, cardID uuid.UUID, userID uuid.UUID, currency string) (string, error) {
+	card, err := s.cardRepo.FetchOneByID(ctx, cardID)
+	if err != nil {
+		return "", err
+	}
+	if card.UserID != userID {
+		return "", domain.ErrNotFound
+	}
+	currency = domain.NormalizeCurrency(currency)
+	if currency != "" && currency != card.Currency {
+		return "", fmt.Errorf("%w: purchases on card %q must be in %s", domain.ErrInvalidInput, card.CardName, card.Currency)
+	}
+	return card.Currency, nil
+}
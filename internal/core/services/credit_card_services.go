@@ -2,6 +2,7 @@ package services
 
 import (
 	"context"
+	"fmt"
 	"github.com/google/uuid"
 	"github.com/misalima/my-budget-planner-backend/internal/core/domain"
 	"github.com/misalima/my-budget-planner-backend/internal/core/interfaces/irepository"
@@ -9,11 +10,14 @@ import (
 )
 
 type CreditCardService struct {
-	repo irepository.CreditCardLoader
+	repo        irepository.CreditCardLoader
+	expenseRepo irepository.CreditCardExpenseLoader
+	paymentRepo irepository.CardPaymentLoader
+	userRepo    irepository.UserLoader
 }
 
-func NewCreditCardService(repo irepository.CreditCardLoader) *CreditCardService {
-	return &CreditCardService{repo: repo}
+func NewCreditCardService(repo irepository.CreditCardLoader, expenseRepo irepository.CreditCardExpenseLoader, paymentRepo irepository.CardPaymentLoader, userRepo irepository.UserLoader) *CreditCardService {
+	return &CreditCardService{repo: repo, expenseRepo: expenseRepo, paymentRepo: paymentRepo, userRepo: userRepo}
 }
 
 func (s *CreditCardService) GetAllByUserID(userID uuid.UUID) ([]domain.CreditCard, error) {
@@ -29,8 +33,29 @@ func (s *CreditCardService) GetByID(id uuid.UUID) (*domain.CreditCard, error) {
 }
 
 func (s *CreditCardService) Create(cc *domain.CreditCard) error {
+	if cc.DueDate < 1 || cc.DueDate > 31 {
+		return fmt.Errorf("%w: due date must be a day between 1 and 31", domain.ErrInvalidInput)
+	}
+	if cc.ClosingDay == 0 {
+		cc.ClosingDay = domain.DefaultClosingDay(cc.DueDate)
+	}
+	if cc.ClosingDay < 1 || cc.ClosingDay > 31 {
+		return fmt.Errorf("%w: closing day must be a day between 1 and 31", domain.ErrInvalidInput)
+	}
+	if cc.CurrentLimit == 0 {
+		cc.CurrentLimit = cc.TotalLimit
+	}
+	now := time.Now()
+	cc.CreatedAt = now
+	cc.UpdatedAt = now
+
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
+	currency, err := currencyOrBase(ctx, s.userRepo, cc.UserID, cc.Currency)
+	if err != nil {
+		return err
+	}
+	cc.Currency = currency
 	return s.repo.Create(ctx, cc)
 }
 
@@ -39,3 +64,85 @@ func (s *CreditCardService) Delete(id uuid.UUID) error {
 	defer cancel()
 	return s.repo.Delete(ctx, id)
 }
+
+// RecomputeLimit recalculates the available limit of the card from its history,
+// fixing values that drifted from the installments and payments recorded against it.
+func (s *CreditCardService) RecomputeLimit(ctx context.Context, cardID uuid.UUID, userID uuid.UUID) (*domain.CreditCard, error) {
+	card, err := s.repo.FetchOneByID(ctx, cardID)
+	if err != nil {
+		return nil, err
+	}
+	if card.UserID != userID {
+		return nil, domain.ErrNotFound
+	}
+	return s.repo.RecomputeCurrentLimit(ctx, cardID)
+}
+
+// GetStatement builds the statement due in the given month from the installments
+// dated inside its billing cycle. Whatever earlier statements left unpaid (or
+// overpaid) is carried in as the previous balance.
+func (s *CreditCardService) GetStatement(ctx context.Context, cardID uuid.UUID, userID uuid.UUID, year int, month time.Month) (domain.CreditCardStatement, error) {
+	card, err := s.repo.FetchOneByID(ctx, cardID)
+	if err != nil {
+		return domain.CreditCardStatement{}, err
+	}
+	if card.UserID != userID {
+		return domain.CreditCardStatement{}, domain.ErrNotFound
+	}
+
+	cycle, closingDate, dueDate := card.StatementDates(year, month)
+	installments, err := s.expenseRepo.FindCreditCardExpenses(ctx, userID, irepository.CreditCardExpenseFilters{
+		CardID:  &card.ID,
+		EndDate: &cycle.End,
+	})
+	if err != nil {
+		return domain.CreditCardStatement{}, err
+	}
+	payments, err := s.paymentRepo.FindCardPaymentsByCard(ctx, card.ID)
+	if err != nil {
+		return domain.CreditCardStatement{}, err
+	}
+
+	statementMonth := time.Date(year, month, 1, 0, 0, 0, 0, time.UTC)
+	statement := domain.CreditCardStatement{
+		CardID:       card.ID,
+		Currency:     card.Currency,
+		Reference:    fmt.Sprintf("%04d-%02d", year, month),
+		PeriodStart:  cycle.Start,
+		ClosingDate:  closingDate,
+		DueDate:      dueDate,
+		Installments: []domain.CreditCardExpense{},
+		Payments:     []domain.CardPayment{},
+	}
+	for _, installment := range installments {
+		if installment.Date.Before(cycle.Start) {
+			statement.PreviousBalance += installment.InstallmentValue()
+			continue
+		}
+		statement.Installments = append(statement.Installments, installment)
+		statement.PurchasesTotal += installment.InstallmentValue()
+	}
+	for _, payment := range payments {
+		switch {
+		case payment.StatementMonth.Before(statementMonth):
+			statement.PreviousBalance -= payment.Amount
+		case payment.StatementMonth.Equal(statementMonth):
+			statement.Payments = append(statement.Payments, payment)
+			statement.PaymentsTotal += payment.Amount
+		}
+	}
+	statement.TotalDue = statement.PreviousBalance + statement.PurchasesTotal - statement.PaymentsTotal
+
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+	switch {
+	case !today.After(closingDate):
+		statement.Status = domain.StatementStatusOpen
+	case statement.TotalDue <= 0:
+		statement.Status = domain.StatementStatusPaid
+	case statement.PaymentsTotal > 0:
+		statement.Status = domain.StatementStatusPartiallyPaid
+	default:
+		statement.Status = domain.StatementStatusClosed
+	}
+	return statement, nil
+}
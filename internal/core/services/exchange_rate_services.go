@@ -0,0 +1,195 @@
+package services
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/misalima/my-budget-planner-backend/internal/core/domain"
+	"github.com/misalima/my-budget-planner-backend/internal/core/interfaces/irepository"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// exchangeRateCSVColumns are the columns every exchange rate CSV must name in its header.
+var exchangeRateCSVColumns = []string{"date", "base_currency", "quote_currency", "rate"}
+
+type ExchangeRateService struct {
+	repo irepository.ExchangeRateLoader
+}
+
+func NewExchangeRateService(repo irepository.ExchangeRateLoader) *ExchangeRateService {
+	return &ExchangeRateService{repo: repo}
+}
+
+// ImportExchangeRatesCSV reads exchange rates from a CSV whose header names the columns
+// date (YYYY-MM-DD), base_currency, quote_currency and rate, in any order. Every row is
+// validated before anything is stored, and rates already stored for the same pair and
+// date are replaced. It returns how many rates were stored.
+func (s *ExchangeRateService) ImportExchangeRatesCSV(ctx context.Context, r io.Reader) (int, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return 0, fmt.Errorf("%w: exchange rate file is empty", domain.ErrInvalidInput)
+		}
+		return 0, fmt.Errorf("%w: %v", domain.ErrInvalidInput, err)
+	}
+	positions := make(map[string]int, len(header))
+	for i, name := range header {
+		positions[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	for _, column := range exchangeRateCSVColumns {
+		if _, ok := positions[column]; !ok {
+			return 0, fmt.Errorf("%w: exchange rate file has no %q column", domain.ErrInvalidInput, column)
+		}
+	}
+
+	var rates []domain.ExchangeRate
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return 0, fmt.Errorf("%w: %v", domain.ErrInvalidInput, err)
+		}
+		line, _ := reader.FieldPos(0)
+		rate, err := parseExchangeRateRecord(record, positions)
+		if err != nil {
+			return 0, fmt.Errorf("%w: line %d: %v", domain.ErrInvalidInput, line, err)
+		}
+		rates = append(rates, rate)
+	}
+
+	if err := s.repo.UpsertExchangeRates(ctx, rates); err != nil {
+		return 0, err
+	}
+	return len(rates), nil
+}
+
+func (s *ExchangeRateService) ListExchangeRates(ctx context.Context, filters irepository.ExchangeRateFilters) ([]domain.ExchangeRate, error) {
+	return s.repo.FindExchangeRates(ctx, filters)
+}
+
+func parseExchangeRateRecord(record []string, positions map[string]int) (domain.ExchangeRate, error) {
+	field := func(column string) string {
+		if i := positions[column]; i < len(record) {
+			return strings.TrimSpace(record[i])
+		}
+		return ""
+	}
+
+	date, err := time.Parse("2006-01-02", field("date"))
+	if err != nil {
+		return domain.ExchangeRate{}, fmt.Errorf("invalid date %q", field("date"))
+	}
+	base := domain.NormalizeCurrency(field("base_currency"))
+	quote := domain.NormalizeCurrency(field("quote_currency"))
+	if !domain.IsValidCurrency(base) {
+		return domain.ExchangeRate{}, fmt.Errorf("invalid base currency %q", base)
+	}
+	if !domain.IsValidCurrency(quote) {
+		return domain.ExchangeRate{}, fmt.Errorf("invalid quote currency %q", quote)
+	}
+	if base == quote {
+		return domain.ExchangeRate{}, fmt.Errorf("base and quote currency are both %s", base)
+	}
+	rate, err := strconv.ParseFloat(field("rate"), 64)
+	if err != nil || rate <= 0 {
+		return domain.ExchangeRate{}, fmt.Errorf("invalid rate %q", field("rate"))
+	}
+	return domain.ExchangeRate{Date: date, BaseCurrency: base, QuoteCurrency: quote, Rate: rate}, nil
+}
+
+// currencyOrBase validates an optional currency code, falling back to the user's base
+// currency when it is empty.
+func currencyOrBase(ctx context.Context, userRepo irepository.UserLoader, userID uuid.UUID, currency string) (string, error) {
+	currency = domain.NormalizeCurrency(currency)
+	if currency == "" {
+		user, err := userRepo.GetUserByID(ctx, userID)
+		if err != nil {
+			return "", err
+		}
+		return user.BaseCurrency, nil
+	}
+	if !domain.IsValidCurrency(currency) {
+		return "", fmt.Errorf("%w: invalid currency %q", domain.ErrInvalidInput, currency)
+	}
+	return currency, nil
+}
+
+type exchangeRateKey struct {
+	currency string
+	date     time.Time
+}
+
+// currencyConverter converts amounts into one target currency at the latest rate
+// known on each amount's date. It remembers the rates it already looked up, so it
+// is meant to live for a single request.
+type currencyConverter struct {
+	repo   irepository.ExchangeRateLoader
+	target string
+	rates  map[exchangeRateKey]float64
+}
+
+func newCurrencyConverter(repo irepository.ExchangeRateLoader, target string) *currencyConverter {
+	return &currencyConverter{repo: repo, target: target, rates: make(map[exchangeRateKey]float64)}
+}
+
+// newBaseCurrencyConverter builds a converter into the base currency of the user.
+func newBaseCurrencyConverter(ctx context.Context, userRepo irepository.UserLoader, repo irepository.ExchangeRateLoader, userID uuid.UUID) (*currencyConverter, error) {
+	user, err := userRepo.GetUserByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	return newCurrencyConverter(repo, user.BaseCurrency), nil
+}
+
+func (c *currencyConverter) convert(ctx context.Context, amount domain.Money, currency string, date time.Time) (domain.Money, error) {
+	if currency == c.target || currency == "" || amount == 0 {
+		return amount, nil
+	}
+	key := exchangeRateKey{currency: currency, date: date}
+	rate, ok := c.rates[key]
+	if !ok {
+		found, err := c.repo.FindLatestExchangeRate(ctx, currency, c.target, date)
+		if err != nil {
+			if errors.Is(err, domain.ErrNotFound) {
+				return 0, fmt.Errorf("%w: no rate from %s to %s on or before %s",
+					domain.ErrMissingExchangeRate, currency, c.target, date.Format("2006-01-02"))
+			}
+			return 0, err
+		}
+		rate = found.Rate
+		if found.BaseCurrency != currency {
+			rate = 1 / found.Rate
+		}
+		c.rates[key] = rate
+	}
+	return amount.Convert(rate), nil
+}
+
+// convertTransactions converts every ledger entry into the converter's currency. Entries
+// that change currency keep the amount they were registered with as their original amount.
+func convertTransactions(ctx context.Context, converter *currencyConverter, transactions []domain.Transaction) error {
+	for i := range transactions {
+		t := &transactions[i]
+		if t.Currency == converter.target {
+			continue
+		}
+		amount, err := converter.convert(ctx, t.Amount, t.Currency, t.Date)
+		if err != nil {
+			return err
+		}
+		originalAmount, originalCurrency := t.Amount, t.Currency
+		t.OriginalAmount, t.OriginalCurrency = &originalAmount, &originalCurrency
+		t.Amount, t.Currency = amount, converter.target
+	}
+	return nil
+}
@@ -0,0 +1,338 @@
+package services
+
+import (
+	"archive/zip"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/misalima/my-budget-planner-backend/internal/core/domain"
+	"github.com/misalima/my-budget-planner-backend/internal/core/interfaces/irepository"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// exportTable is one kind of record of an export archive, written both as
+// json/<name>.json and as csv/<name>.csv.
+type exportTable struct {
+	name   string
+	data   interface{}
+	header []string
+	rows   [][]string
+}
+
+type ExportService struct {
+	userRepo              irepository.UserLoader
+	categoryRepo          irepository.CategoryLoader
+	cardRepo              irepository.CreditCardLoader
+	simpleExpenseRepo     irepository.SimpleExpenseLoader
+	recurringExpenseRepo  irepository.RecurringExpenseLoader
+	creditCardExpenseRepo irepository.CreditCardExpenseLoader
+	budgetRepo            irepository.BudgetLoader
+	incomeRepo            irepository.IncomeLoader
+	cardPaymentRepo       irepository.CardPaymentLoader
+}
+
+func NewExportService(
+	userRepo irepository.UserLoader,
+	categoryRepo irepository.CategoryLoader,
+	cardRepo irepository.CreditCardLoader,
+	simpleExpenseRepo irepository.SimpleExpenseLoader,
+	recurringExpenseRepo irepository.RecurringExpenseLoader,
+	creditCardExpenseRepo irepository.CreditCardExpenseLoader,
+	budgetRepo irepository.BudgetLoader,
+	incomeRepo irepository.IncomeLoader,
+	cardPaymentRepo irepository.CardPaymentLoader,
+) *ExportService {
+	return &ExportService{
+		userRepo:              userRepo,
+		categoryRepo:          categoryRepo,
+		cardRepo:              cardRepo,
+		simpleExpenseRepo:     simpleExpenseRepo,
+		recurringExpenseRepo:  recurringExpenseRepo,
+		creditCardExpenseRepo: creditCardExpenseRepo,
+		budgetRepo:            budgetRepo,
+		incomeRepo:            incomeRepo,
+		cardPaymentRepo:       cardPaymentRepo,
+	}
+}
+
+// ExportAccount loads everything stored for the user. Refresh tokens and the password
+// hash are left out.
+func (s *ExportService) ExportAccount(ctx context.Context, userID uuid.UUID) (domain.AccountExport, error) {
+	user, err := s.userRepo.GetUserByID(ctx, userID)
+	if err != nil {
+		return domain.AccountExport{}, err
+	}
+	export := domain.AccountExport{
+		Profile: domain.ExportProfile{
+			ID:               user.ID,
+			Username:         user.Username,
+			FirstName:        user.FirstName,
+			LastName:         user.LastName,
+			Email:            user.Email,
+			ProfilePicture:   user.ProfilePicture,
+			Income:           user.Income,
+			ExpenditureLimit: user.ExpenditureLimit,
+			BaseCurrency:     user.BaseCurrency,
+			CreatedAt:        user.CreatedAt,
+			UpdatedAt:        user.UpdatedAt,
+		},
+	}
+
+	if export.Categories, err = s.categoryRepo.GetCategoryByUserID(ctx, userID); err != nil {
+		return domain.AccountExport{}, err
+	}
+	if export.CreditCards, err = s.cardRepo.FetchAllByUserID(ctx, userID); err != nil {
+		return domain.AccountExport{}, err
+	}
+	if export.SimpleExpenses, err = s.simpleExpenseRepo.FindSimpleExpensesByUser(ctx, userID); err != nil {
+		return domain.AccountExport{}, err
+	}
+	if export.RecurringExpenses, err = s.recurringExpenseRepo.FindRecurringExpensesByUser(ctx, userID); err != nil {
+		return domain.AccountExport{}, err
+	}
+	if export.CreditCardExpenses, err = s.creditCardExpenseRepo.FindCreditCardExpensesByUser(ctx, userID); err != nil {
+		return domain.AccountExport{}, err
+	}
+	if export.Budgets, err = s.budgetRepo.FindBudgetsByUser(ctx, userID); err != nil {
+		return domain.AccountExport{}, err
+	}
+	if export.Incomes, err = s.incomeRepo.FindIncomes(ctx, userID, irepository.IncomeFilters{}); err != nil {
+		return domain.AccountExport{}, err
+	}
+	export.CardPayments = []domain.CardPayment{}
+	for _, card := range export.CreditCards {
+		payments, err := s.cardPaymentRepo.FindCardPaymentsByCard(ctx, card.ID)
+		if err != nil {
+			return domain.AccountExport{}, err
+		}
+		export.CardPayments = append(export.CardPayments, payments...)
+	}
+
+	export.Manifest = domain.ExportManifest{
+		Format:     domain.ExportFormat,
+		Version:    domain.ExportFormatVersion,
+		ExportedAt: time.Now().UTC(),
+		UserID:     userID,
+		Counts:     make(map[string]int),
+	}
+	for _, table := range exportTables(export) {
+		export.Manifest.Counts[table.name] = len(table.rows)
+	}
+	return export, nil
+}
+
+// WriteExportArchive writes the export as a zip with manifest.json and bundle.json, the
+// bundle an archive import restores, at its root, and every kind of record both under
+// json/ and under csv/.
+func (s *ExportService) WriteExportArchive(w io.Writer, export domain.AccountExport) error {
+	archive := zip.NewWriter(w)
+
+	if err := writeZipJSON(archive, "manifest.json", export.Manifest); err != nil {
+		return err
+	}
+	if err := writeZipJSON(archive, "bundle.json", export.Bundle()); err != nil {
+		return err
+	}
+	for _, table := range exportTables(export) {
+		if err := writeZipJSON(archive, "json/"+table.name+".json", table.data); err != nil {
+			return err
+		}
+		if err := writeZipCSV(archive, "csv/"+table.name+".csv", table.header, table.rows); err != nil {
+			return err
+		}
+	}
+
+	if err := archive.Close(); err != nil {
+		return fmt.Errorf("failed to finish export archive: %w", err)
+	}
+	return nil
+}
+
+func writeZipJSON(archive *zip.Writer, name string, value interface{}) error {
+	file, err := archive.Create(name)
+	if err != nil {
+		return fmt.Errorf("failed to add %s to export archive: %w", name, err)
+	}
+	encoder := json.NewEncoder(file)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(value); err != nil {
+		return fmt.Errorf("failed to write %s: %w", name, err)
+	}
+	return nil
+}
+
+func writeZipCSV(archive *zip.Writer, name string, header []string, rows [][]string) error {
+	file, err := archive.Create(name)
+	if err != nil {
+		return fmt.Errorf("failed to add %s to export archive: %w", name, err)
+	}
+	writer := csv.NewWriter(file)
+	if err := writer.Write(header); err != nil {
+		return fmt.Errorf("failed to write %s: %w", name, err)
+	}
+	if err := writer.WriteAll(rows); err != nil {
+		return fmt.Errorf("failed to write %s: %w", name, err)
+	}
+	return nil
+}
+
+// exportTables lays out every kind of record of the export. The CSV columns follow
+// the JSON field names.
+func exportTables(export domain.AccountExport) []exportTable {
+	tables := []exportTable{
+		{
+			name:   "profile",
+			data:   export.Profile,
+			header: []string{"id", "username", "first_name", "last_name", "email", "profile_picture", "income", "expenditure_limit", "base_currency", "created_at", "updated_at"},
+		},
+		{
+			name:   "categories",
+			data:   export.Categories,
+			header: []string{"id", "category_name", "kind", "user_id"},
+		},
+		{
+			name:   "credit_cards",
+			data:   export.CreditCards,
+			header: []string{"id", "card_name", "total_limit", "current_limit", "currency", "closing_day", "due_date", "created_at", "updated_at"},
+		},
+		{
+			name:   "simple_expenses",
+			data:   export.SimpleExpenses,
+			header: []string{"id", "category_id", "amount", "currency", "description", "date", "external_id", "created_at", "updated_at"},
+		},
+		{
+			name:   "recurring_expenses",
+			data:   export.RecurringExpenses,
+			header: []string{"id", "category_id", "amount", "currency", "description", "date", "card_id", "start_date", "end_date", "frequency", "template_id", "created_at", "updated_at"},
+		},
+		{
+			name:   "credit_card_expenses",
+			data:   export.CreditCardExpenses,
+			header: []string{"id", "category_id", "amount", "currency", "description", "date", "card_id", "installment_amount", "installments_quantity", "parcel_number", "created_at", "updated_at"},
+		},
+		{
+			name:   "budgets",
+			data:   export.Budgets,
+			header: []string{"id", "budget_name", "description", "amount", "period", "rollover", "category_ids", "start_date", "end_date", "created_at", "updated_at"},
+		},
+		{
+			name:   "incomes",
+			data:   export.Incomes,
+			header: []string{"id", "category_id", "amount", "currency", "description", "date", "frequency", "end_date", "external_id", "created_at", "updated_at"},
+		},
+		{
+			name:   "card_payments",
+			data:   export.CardPayments,
+			header: []string{"id", "card_id", "statement_month", "amount", "paid_at", "description", "created_at", "updated_at"},
+		},
+	}
+
+	p := export.Profile
+	tables[0].rows = [][]string{{
+		p.ID.String(), p.Username, p.FirstName, p.LastName, p.Email, p.ProfilePicture, p.Income.String(),
+		p.ExpenditureLimit.String(), p.BaseCurrency, exportTime(p.CreatedAt), exportTime(p.UpdatedAt),
+	}}
+	tables[1].rows = make([][]string, 0, len(export.Categories))
+	for _, c := range export.Categories {
+		tables[1].rows = append(tables[1].rows, []string{strconv.Itoa(c.ID), c.Name, c.Kind, exportUserID(c.UserID)})
+	}
+	tables[2].rows = make([][]string, 0, len(export.CreditCards))
+	for _, c := range export.CreditCards {
+		tables[2].rows = append(tables[2].rows, []string{
+			c.ID.String(), c.CardName, c.TotalLimit.String(), c.CurrentLimit.String(), c.Currency,
+			strconv.Itoa(c.ClosingDay), strconv.Itoa(c.DueDate), exportTime(c.CreatedAt), exportTime(c.UpdatedAt),
+		})
+	}
+	tables[3].rows = make([][]string, 0, len(export.SimpleExpenses))
+	for _, e := range export.SimpleExpenses {
+		tables[3].rows = append(tables[3].rows, []string{
+			e.ID.String(), strconv.Itoa(e.CategoryID), e.Amount.String(), e.Currency, exportString(e.Description),
+			exportDate(e.Date), exportString(e.ExternalID), exportTime(e.CreatedAt), exportTime(e.UpdatedAt),
+		})
+	}
+	tables[4].rows = make([][]string, 0, len(export.RecurringExpenses))
+	for _, e := range export.RecurringExpenses {
+		tables[4].rows = append(tables[4].rows, []string{
+			e.ID.String(), strconv.Itoa(e.CategoryID), e.Amount.String(), e.Currency, exportString(e.Description),
+			exportDate(e.Date), exportUUID(e.CardID), exportDate(e.StartDate), exportOptionalDate(e.EndDate), e.Frequency,
+			exportUUID(e.TemplateID), exportTime(e.CreatedAt), exportTime(e.UpdatedAt),
+		})
+	}
+	tables[5].rows = make([][]string, 0, len(export.CreditCardExpenses))
+	for _, e := range export.CreditCardExpenses {
+		tables[5].rows = append(tables[5].rows, []string{
+			e.ID.String(), strconv.Itoa(e.CategoryID), e.Amount.String(), e.Currency, exportString(e.Description),
+			exportDate(e.Date), e.CardID.String(), e.InstallmentAmount.String(), strconv.Itoa(e.InstallmentsQuantity),
+			strconv.Itoa(e.ParcelNumber), exportTime(e.CreatedAt), exportTime(e.UpdatedAt),
+		})
+	}
+	tables[6].rows = make([][]string, 0, len(export.Budgets))
+	for _, b := range export.Budgets {
+		categoryIDs := make([]string, len(b.CategoryIDs))
+		for i, id := range b.CategoryIDs {
+			categoryIDs[i] = strconv.Itoa(id)
+		}
+		tables[6].rows = append(tables[6].rows, []string{
+			strconv.Itoa(b.ID), b.Name, exportString(b.Description), b.Amount.String(), b.Period, strconv.FormatBool(b.Rollover),
+			strings.Join(categoryIDs, ";"), exportDate(b.StartDate), exportOptionalDate(b.EndDate), exportTime(b.CreatedAt), exportTime(b.UpdatedAt),
+		})
+	}
+	tables[7].rows = make([][]string, 0, len(export.Incomes))
+	for _, i := range export.Incomes {
+		tables[7].rows = append(tables[7].rows, []string{
+			i.ID.String(), strconv.Itoa(i.CategoryID), i.Amount.String(), i.Currency, exportString(i.Description),
+			exportDate(i.Date), exportString(i.Frequency), exportOptionalDate(i.EndDate), exportString(i.ExternalID),
+			exportTime(i.CreatedAt), exportTime(i.UpdatedAt),
+		})
+	}
+	tables[8].rows = make([][]string, 0, len(export.CardPayments))
+	for _, p := range export.CardPayments {
+		tables[8].rows = append(tables[8].rows, []string{
+			p.ID.String(), p.CardID.String(), exportDate(p.StatementMonth), p.Amount.String(), exportDate(p.PaidAt),
+			exportString(p.Description), exportTime(p.CreatedAt), exportTime(p.UpdatedAt),
+		})
+	}
+	return tables
+}
+
+func exportString(value *string) string {
+	if value == nil {
+		return ""
+	}
+	return *value
+}
+
+func exportUUID(value *uuid.UUID) string {
+	if value == nil {
+		return ""
+	}
+	return value.String()
+}
+
+// exportUserID leaves the owner of shared categories empty.
+func exportUserID(value uuid.UUID) string {
+	if value == uuid.Nil {
+		return ""
+	}
+	return value.String()
+}
+
+func exportDate(value time.Time) string {
+	return value.Format("2006-01-02")
+}
+
+func exportOptionalDate(value *time.Time) string {
+	if value == nil {
+		return ""
+	}
+	return exportDate(*value)
+}
+
+func exportTime(value time.Time) string {
+	return value.UTC().Format(time.RFC3339)
+}
@@ -0,0 +1,823 @@
+package services
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/misalima/my-budget-planner-backend/internal/core/domain"
+	"github.com/misalima/my-budget-planner-backend/internal/core/interfaces/irepository"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// maxImportRows caps how many lines a single imported file may have.
+const maxImportRows = 5000
+
+// importDateLayouts maps the date formats accepted by imports to Go layouts.
+var importDateLayouts = map[string]string{
+	"YYYY-MM-DD": "2006-01-02",
+	"DD/MM/YYYY": "02/01/2006",
+	"MM/DD/YYYY": "01/02/2006",
+	"DD-MM-YYYY": "02-01-2006",
+	"DD.MM.YYYY": "02.01.2006",
+}
+
+var importDelimiters = map[string]rune{
+	",":   ',',
+	";":   ';',
+	"|":   '|',
+	"tab": '\t',
+}
+
+// maxImportFileSize caps how many bytes of an imported OFX file are read.
+const maxImportFileSize = 10 << 20
+
+// parcelMatchWindow is how far the date of a stored parcel may be from the date a
+// statement charges it for the two to be taken as the same parcel.
+const parcelMatchWindow = 7 * 24 * time.Hour
+
+// parcelNotation matches the parcel suffix card statements add to installment
+// purchases, such as "STORE - Parcela 3/10" or "STORE PARC 03/10".
+var parcelNotation = regexp.MustCompile(`(?i)\s*(?:-\s*)?parc(?:ela)?\.?\s*(\d{1,2})\s*/\s*(\d{1,2})\s*$`)
+
+// bareParcelNotation also matches a bare "n/m" suffix, such as "STORE 03/10". Dates
+// such as "UBER 05/11" look the same, so it is only used when the user says so.
+var bareParcelNotation = regexp.MustCompile(`(?i)\s*(?:-\s*)?(?:parc(?:ela)?\.?\s*)?(\d{1,2})\s*/\s*(\d{1,2})\s*$`)
+
+type ImportService struct {
+	repo            irepository.ImportLoader
+	cardExpenseRepo irepository.CreditCardExpenseLoader
+	cardRepo        irepository.CreditCardLoader
+	categoryRepo    irepository.CategoryLoader
+	userRepo        irepository.UserLoader
+}
+
+func NewImportService(
+	repo irepository.ImportLoader,
+	cardExpenseRepo irepository.CreditCardExpenseLoader,
+	cardRepo irepository.CreditCardLoader,
+	categoryRepo irepository.CategoryLoader,
+	userRepo irepository.UserLoader,
+) *ImportService {
+	return &ImportService{
+		repo:            repo,
+		cardExpenseRepo: cardExpenseRepo,
+		cardRepo:        cardRepo,
+		categoryRepo:    categoryRepo,
+		userRepo:        userRepo,
+	}
+}
+
+// ImportCSV turns every line of a bank statement CSV into a simple expense and returns
+// them as a preview. When confirm is set and no row is invalid, the expenses are
+// created in a single transaction; otherwise nothing is stored.
+func (s *ImportService) ImportCSV(ctx context.Context, userID uuid.UUID, r io.Reader, options domain.CSVImportOptions, confirm bool) (domain.ImportPreview, error) {
+	currency, err := currencyOrBase(ctx, s.userRepo, userID, options.Currency)
+	if err != nil {
+		return domain.ImportPreview{}, err
+	}
+	parser, err := newCSVRowParser(options)
+	if err != nil {
+		return domain.ImportPreview{}, err
+	}
+	categories, err := s.categories(ctx, userID, domain.CategoryKindExpense)
+	if err != nil {
+		return domain.ImportPreview{}, err
+	}
+	if options.DefaultCategoryID != 0 && !categories.has(options.DefaultCategoryID) {
+		return domain.ImportPreview{}, fmt.Errorf("%w: category %d is not one of your expense categories", domain.ErrInvalidInput, options.DefaultCategoryID)
+	}
+
+	reader, err := newImportCSVReader(r, options.Delimiter)
+	if err != nil {
+		return domain.ImportPreview{}, err
+	}
+	header, err := reader.Read()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return domain.ImportPreview{}, fmt.Errorf("%w: file is empty", domain.ErrInvalidInput)
+		}
+		return domain.ImportPreview{}, fmt.Errorf("%w: %v", domain.ErrInvalidInput, err)
+	}
+	if err := parser.mapHeader(header); err != nil {
+		return domain.ImportPreview{}, err
+	}
+
+	preview := domain.ImportPreview{Rows: []domain.ImportRow{}}
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return domain.ImportPreview{}, fmt.Errorf("%w: %v", domain.ErrInvalidInput, err)
+		}
+		if len(preview.Rows) == maxImportRows {
+			return domain.ImportPreview{}, fmt.Errorf("%w: file has more than %d rows", domain.ErrInvalidInput, maxImportRows)
+		}
+		line, _ := reader.FieldPos(0)
+
+		row := parser.parse(record, line, options.DefaultCategoryID, categories)
+		if row.Expense != nil {
+			row.Expense.UserID = userID
+			row.Expense.Currency = currency
+		}
+		preview.Add(row)
+	}
+
+	if !confirm || preview.InvalidCount > 0 {
+		return preview, nil
+	}
+	return s.commit(ctx, preview)
+}
+
+// ImportOFX turns every STMTTRN entry of an OFX or QFX file into a simple expense, for
+// debits, or an income, for credits, and returns them as a preview. Entries whose FITID
+// was already imported are marked as duplicates and left out. When confirm is set and
+// no entry is invalid, the rest are created in a single transaction.
+func (s *ImportService) ImportOFX(ctx context.Context, userID uuid.UUID, r io.Reader, options domain.OFXImportOptions, confirm bool) (domain.ImportPreview, error) {
+	data, err := io.ReadAll(io.LimitReader(r, maxImportFileSize+1))
+	if err != nil {
+		return domain.ImportPreview{}, fmt.Errorf("failed to read file: %w", err)
+	}
+	if len(data) > maxImportFileSize {
+		return domain.ImportPreview{}, fmt.Errorf("%w: file is larger than %d bytes", domain.ErrInvalidInput, maxImportFileSize)
+	}
+	transactions, err := parseOFX(data)
+	if err != nil {
+		return domain.ImportPreview{}, err
+	}
+	if len(transactions) > maxImportRows {
+		return domain.ImportPreview{}, fmt.Errorf("%w: file has more than %d entries", domain.ErrInvalidInput, maxImportRows)
+	}
+
+	user, err := s.userRepo.GetUserByID(ctx, userID)
+	if err != nil {
+		return domain.ImportPreview{}, err
+	}
+	currency := domain.NormalizeCurrency(options.Currency)
+	if currency != "" && !domain.IsValidCurrency(currency) {
+		return domain.ImportPreview{}, fmt.Errorf("%w: invalid currency %q", domain.ErrInvalidInput, currency)
+	}
+	if err := s.checkCategory(ctx, userID, options.ExpenseCategoryID, domain.CategoryKindExpense); err != nil {
+		return domain.ImportPreview{}, err
+	}
+	if err := s.checkCategory(ctx, userID, options.IncomeCategoryID, domain.CategoryKindIncome); err != nil {
+		return domain.ImportPreview{}, err
+	}
+
+	rows := make([]domain.ImportRow, len(transactions))
+	externalIDs := make([]string, 0, len(transactions))
+	for i, t := range transactions {
+		entryCurrency := currency
+		if entryCurrency == "" {
+			entryCurrency = domain.NormalizeCurrency(t.Currency)
+			if !domain.IsValidCurrency(entryCurrency) {
+				entryCurrency = user.BaseCurrency
+			}
+		}
+		rows[i] = ofxImportRow(t, i+1, userID, entryCurrency, options)
+		if rows[i].Status == domain.ImportRowValid {
+			externalIDs = append(externalIDs, t.ExternalID())
+		}
+	}
+
+	imported, err := s.repo.FindImportedExternalIDs(ctx, userID, externalIDs)
+	if err != nil {
+		return domain.ImportPreview{}, err
+	}
+	preview := domain.ImportPreview{Rows: []domain.ImportRow{}}
+	for i, row := range rows {
+		if row.Status == domain.ImportRowValid {
+			externalID := transactions[i].ExternalID()
+			if imported[externalID] {
+				row = domain.ImportRow{Line: row.Line, Status: domain.ImportRowDuplicate}
+			}
+			imported[externalID] = true
+		}
+		preview.Add(row)
+	}
+
+	if !confirm || preview.InvalidCount > 0 {
+		return preview, nil
+	}
+	return s.commit(ctx, preview)
+}
+
+// ImportCardStatement reads the statement of a credit card, as a CSV or an OFX file, and
+// turns its purchases into card expenses. A line with parcel notation such as
+// "STORE 03/10" is parcel 3 of 10: when that parcel is already stored the line is
+// marked as matched, otherwise the line creates parcels 3 to 10 of the purchase.
+// Payments and refunds are skipped. When confirm is set and no line is invalid, the
+// parcels are created in a single transaction.
+func (s *ImportService) ImportCardStatement(ctx context.Context, userID uuid.UUID, r io.Reader, options domain.CardStatementImportOptions, confirm bool) (domain.ImportPreview, error) {
+	card, err := s.cardRepo.FetchOneByID(ctx, options.CardID)
+	if err != nil {
+		return domain.ImportPreview{}, err
+	}
+	if card.UserID != userID {
+		return domain.ImportPreview{}, domain.ErrNotFound
+	}
+	if err := s.checkCategory(ctx, userID, options.CSV.DefaultCategoryID, domain.CategoryKindExpense); err != nil {
+		return domain.ImportPreview{}, err
+	}
+
+	data, err := io.ReadAll(io.LimitReader(r, maxImportFileSize+1))
+	if err != nil {
+		return domain.ImportPreview{}, fmt.Errorf("failed to read file: %w", err)
+	}
+	if len(data) > maxImportFileSize {
+		return domain.ImportPreview{}, fmt.Errorf("%w: file is larger than %d bytes", domain.ErrInvalidInput, maxImportFileSize)
+	}
+	var lines []statementLine
+	if bytes.Contains(bytes.ToUpper(data), []byte("<OFX>")) {
+		lines, err = ofxStatementLines(data, options.CSV.DefaultCategoryID)
+	} else {
+		lines, err = s.csvStatementLines(ctx, userID, data, options.CSV)
+	}
+	if err != nil {
+		return domain.ImportPreview{}, err
+	}
+
+	rows := make([]domain.ImportRow, len(lines))
+	start, end := time.Time{}, time.Time{}
+	for i, line := range lines {
+		rows[i] = cardStatementRow(line, *card, options)
+		if rows[i].Status != domain.ImportRowValid {
+			continue
+		}
+		// Only the parcel a line lists is matched against the stored ones.
+		date := rows[i].CardExpenses[0].Date
+		if start.IsZero() || date.Before(start) {
+			start = date
+		}
+		if date.After(end) {
+			end = date
+		}
+	}
+
+	var stored []domain.CreditCardExpense
+	if !start.IsZero() {
+		from, to := start.Add(-parcelMatchWindow), end.Add(parcelMatchWindow)
+		stored, err = s.cardExpenseRepo.FindCreditCardExpenses(ctx, userID, irepository.CreditCardExpenseFilters{
+			CardID:    &card.ID,
+			StartDate: &from,
+			EndDate:   &to,
+		})
+		if err != nil {
+			return domain.ImportPreview{}, err
+		}
+	}
+	used := make(map[uuid.UUID]bool)
+	preview := domain.ImportPreview{Rows: []domain.ImportRow{}}
+	for _, row := range rows {
+		if row.Status == domain.ImportRowValid {
+			if match, ok := matchParcel(row.CardExpenses[0], stored, used); ok {
+				used[match.ID] = true
+				row = domain.ImportRow{Line: row.Line, Status: domain.ImportRowMatched, CardExpenses: []domain.CreditCardExpense{match}}
+			}
+		}
+		preview.Add(row)
+	}
+
+	if !confirm || preview.InvalidCount > 0 {
+		return preview, nil
+	}
+	var parcels []domain.CreditCardExpense
+	for _, row := range preview.Rows {
+		if row.Status == domain.ImportRowValid {
+			parcels = append(parcels, row.CardExpenses...)
+		}
+	}
+	if err := s.cardExpenseRepo.InsertInstallments(ctx, parcels); err != nil {
+		return domain.ImportPreview{}, err
+	}
+	preview.Committed = true
+	return preview, nil
+}
+
+func (s *ImportService) csvStatementLines(ctx context.Context, userID uuid.UUID, data []byte, options domain.CSVImportOptions) ([]statementLine, error) {
+	parser, err := newCSVRowParser(options)
+	if err != nil {
+		return nil, err
+	}
+	categories, err := s.categories(ctx, userID, domain.CategoryKindExpense)
+	if err != nil {
+		return nil, err
+	}
+	reader, err := newImportCSVReader(bytes.NewReader(data), options.Delimiter)
+	if err != nil {
+		return nil, err
+	}
+	header, err := reader.Read()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, fmt.Errorf("%w: file is empty", domain.ErrInvalidInput)
+		}
+		return nil, fmt.Errorf("%w: %v", domain.ErrInvalidInput, err)
+	}
+	if err := parser.mapHeader(header); err != nil {
+		return nil, err
+	}
+
+	var lines []statementLine
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", domain.ErrInvalidInput, err)
+		}
+		if len(lines) == maxImportRows {
+			return nil, fmt.Errorf("%w: file has more than %d rows", domain.ErrInvalidInput, maxImportRows)
+		}
+		line, _ := reader.FieldPos(0)
+		entry := parser.read(record, line, options.DefaultCategoryID, categories)
+		if options.NegativeExpenses {
+			entry.Amount = -entry.Amount
+		}
+		lines = append(lines, entry)
+	}
+	return lines, nil
+}
+
+// ofxStatementLines reads the entries of an OFX card statement, where purchases are
+// debits, so their amounts are negated to be positive like in CSV statements.
+func ofxStatementLines(data []byte, categoryID int) ([]statementLine, error) {
+	transactions, err := parseOFX(data)
+	if err != nil {
+		return nil, err
+	}
+	if len(transactions) > maxImportRows {
+		return nil, fmt.Errorf("%w: file has more than %d entries", domain.ErrInvalidInput, maxImportRows)
+	}
+	lines := make([]statementLine, len(transactions))
+	for i, t := range transactions {
+		line := statementLine{Line: i + 1, Description: t.Description(), CategoryID: categoryID}
+		date, err := t.Date()
+		if err != nil {
+			line.Errors = append(line.Errors, err.Error())
+		}
+		line.Date = date
+		amount, err := t.Money()
+		if err != nil {
+			line.Errors = append(line.Errors, err.Error())
+		}
+		line.Amount = -amount
+		if categoryID == 0 && len(line.Errors) == 0 {
+			line.Errors = append(line.Errors, "no default category_id")
+		}
+		lines[i] = line
+	}
+	return lines, nil
+}
+
+// cardStatementRow turns a statement line into the parcels it creates, from the parcel
+// it lists to the last one of the purchase.
+func cardStatementRow(line statementLine, card domain.CreditCard, options domain.CardStatementImportOptions) domain.ImportRow {
+	row := domain.ImportRow{Line: line.Line, Errors: line.Errors}
+	if len(row.Errors) > 0 {
+		row.Status = domain.ImportRowInvalid
+		return row
+	}
+	if line.Amount <= 0 {
+		row.Status = domain.ImportRowSkipped
+		return row
+	}
+
+	description, parcel, quantity := parseParcel(line.Description, options.BareParcelSuffix)
+	if quantity == 1 && !options.BareParcelSuffix {
+		if _, bareParcel, bareQuantity := parseParcel(line.Description, true); bareQuantity > 1 {
+			row.Warnings = append(row.Warnings, fmt.Sprintf(
+				"read as a single purchase; send bare_parcel_suffix=true to read its suffix as parcel %d of %d", bareParcel, bareQuantity))
+		}
+	}
+	// The purchase date is the one of the first parcel, which every parcel is dated from.
+	purchaseDate := line.Date
+	if !options.PurchaseDates {
+		purchaseDate = line.Date.AddDate(0, -(parcel - 1), 0)
+	}
+	var descriptionPtr *string
+	if description != "" {
+		descriptionPtr = &description
+	}
+	for n := parcel; n <= quantity; n++ {
+		row.CardExpenses = append(row.CardExpenses, domain.CreditCardExpense{
+			ID:                   uuid.New(),
+			UserID:               card.UserID,
+			CategoryID:           line.CategoryID,
+			Amount:               line.Amount * domain.Money(quantity),
+			Currency:             card.Currency,
+			Description:          descriptionPtr,
+			Date:                 domain.AddMonthsClamped(purchaseDate, n-1),
+			CardID:               card.ID,
+			InstallmentAmount:    line.Amount,
+			InstallmentsQuantity: quantity,
+			ParcelNumber:         n,
+		})
+	}
+	row.Status = domain.ImportRowValid
+	return row
+}
+
+// parseParcel splits the parcel notation off a statement description, reading a bare
+// "n/m" suffix as one only when bareSuffix is set. Descriptions
+// without it are a single payment, parcel 1 of 1.
+func parseParcel(description string, bareSuffix bool) (string, int, int) {
+	notation := parcelNotation
+	if bareSuffix {
+		notation = bareParcelNotation
+	}
+	match := notation.FindStringSubmatchIndex(description)
+	if match == nil {
+		return description, 1, 1
+	}
+	parcel, _ := strconv.Atoi(description[match[2]:match[3]])
+	quantity, _ := strconv.Atoi(description[match[4]:match[5]])
+	// Dates such as "12/05" also look like parcels, but never with the parcel after the last one.
+	if parcel < 1 || quantity < 2 || parcel > quantity {
+		return description, 1, 1
+	}
+	return strings.TrimSpace(description[:match[0]]), parcel, quantity
+}
+
+// matchParcel finds a stored parcel that the planned one stands for: same position in
+// a purchase of as many parcels, same value, same description once the parcel notation
+// is cleaned off and a date close enough.
+func matchParcel(planned domain.CreditCardExpense, stored []domain.CreditCardExpense, used map[uuid.UUID]bool) (domain.CreditCardExpense, bool) {
+	description := parcelDescription(planned.Description)
+	for _, candidate := range stored {
+		if used[candidate.ID] ||
+			candidate.InstallmentsQuantity != planned.InstallmentsQuantity ||
+			candidate.ParcelNumber != planned.ParcelNumber ||
+			candidate.InstallmentValue() != planned.InstallmentAmount ||
+			!strings.EqualFold(parcelDescription(candidate.Description), description) {
+			continue
+		}
+		gap := candidate.Date.Sub(planned.Date)
+		if gap < 0 {
+			gap = -gap
+		}
+		if gap <= parcelMatchWindow {
+			return candidate, true
+		}
+	}
+	return domain.CreditCardExpense{}, false
+}
+
+// parcelDescription is the description of a parcel without its parcel notation, so
+// that parcels stored by hand as "STORE 03/10" compare equal to imported ones.
+func parcelDescription(description *string) string {
+	if description == nil {
+		return ""
+	}
+	cleaned, _, _ := parseParcel(*description, true)
+	return cleaned
+}
+
+// ofxImportRow turns an OFX entry into the expense or income it becomes.
+func ofxImportRow(t ofxTransaction, line int, userID uuid.UUID, currency string, options domain.OFXImportOptions) domain.ImportRow {
+	row := domain.ImportRow{Line: line}
+	if t.FITID == "" {
+		row.Errors = append(row.Errors, "missing FITID")
+	}
+	date, err := t.Date()
+	if err != nil {
+		row.Errors = append(row.Errors, err.Error())
+	}
+	amount, err := t.Money()
+	if err != nil {
+		row.Errors = append(row.Errors, err.Error())
+	}
+	switch {
+	case err == nil && amount == 0:
+		row.Status = domain.ImportRowSkipped
+		return row
+	case err == nil && amount < 0 && options.ExpenseCategoryID == 0:
+		row.Errors = append(row.Errors, "debit entries need an expense_category_id")
+	case err == nil && amount > 0 && options.IncomeCategoryID == 0:
+		row.Errors = append(row.Errors, "credit entries need an income_category_id")
+	}
+	if len(row.Errors) > 0 {
+		row.Status = domain.ImportRowInvalid
+		return row
+	}
+
+	externalID := t.ExternalID()
+	var description *string
+	if d := t.Description(); d != "" {
+		description = &d
+	}
+	row.Status = domain.ImportRowValid
+	if amount < 0 {
+		row.Expense = &domain.SimpleExpense{
+			UserID:      userID,
+			CategoryID:  options.ExpenseCategoryID,
+			Amount:      -amount,
+			Currency:    currency,
+			Description: description,
+			Date:        date,
+			ExternalID:  &externalID,
+		}
+		return row
+	}
+	row.Income = &domain.Income{
+		UserID:      userID,
+		CategoryID:  options.IncomeCategoryID,
+		Amount:      amount,
+		Currency:    currency,
+		Description: description,
+		Date:        date,
+		ExternalID:  &externalID,
+	}
+	return row
+}
+
+// commit creates the expenses and incomes of the valid rows in a single transaction
+// and fills the rows with the stored ones.
+func (s *ImportService) commit(ctx context.Context, preview domain.ImportPreview) (domain.ImportPreview, error) {
+	var expenses []domain.SimpleExpense
+	var incomes []domain.Income
+	for _, row := range preview.Rows {
+		if row.Status != domain.ImportRowValid {
+			continue
+		}
+		if row.Expense != nil {
+			expenses = append(expenses, *row.Expense)
+		}
+		if row.Income != nil {
+			incomes = append(incomes, *row.Income)
+		}
+	}
+	createdExpenses, createdIncomes, err := s.repo.InsertImportedEntries(ctx, expenses, incomes)
+	if err != nil {
+		return domain.ImportPreview{}, err
+	}
+	nextExpense, nextIncome := 0, 0
+	for i := range preview.Rows {
+		row := &preview.Rows[i]
+		if row.Status != domain.ImportRowValid {
+			continue
+		}
+		if row.Expense != nil {
+			row.Expense = &createdExpenses[nextExpense]
+			nextExpense++
+		}
+		if row.Income != nil {
+			row.Income = &createdIncomes[nextIncome]
+			nextIncome++
+		}
+	}
+	preview.Committed = true
+	return preview, nil
+}
+
+// checkCategory makes sure an optional category ID is one of the user's categories of the kind.
+func (s *ImportService) checkCategory(ctx context.Context, userID uuid.UUID, categoryID int, kind string) error {
+	if categoryID == 0 {
+		return nil
+	}
+	categories, err := s.categories(ctx, userID, kind)
+	if err != nil {
+		return err
+	}
+	if !categories.has(categoryID) {
+		return fmt.Errorf("%w: category %d is not one of your %s categories", domain.ErrInvalidInput, categoryID, kind)
+	}
+	return nil
+}
+
+// importCategories indexes the user's categories of one kind by ID and by lower-cased name.
+type importCategories struct {
+	byID   map[int]bool
+	byName map[string]int
+}
+
+func (c importCategories) has(id int) bool {
+	return c.byID[id]
+}
+
+// resolve finds a category by its name or, failing that, by its numeric ID.
+func (c importCategories) resolve(value string) (int, bool) {
+	if id, ok := c.byName[strings.ToLower(value)]; ok {
+		return id, true
+	}
+	if id, err := strconv.Atoi(value); err == nil && c.byID[id] {
+		return id, true
+	}
+	return 0, false
+}
+
+func (s *ImportService) categories(ctx context.Context, userID uuid.UUID, kind string) (importCategories, error) {
+	list, err := s.categoryRepo.GetCategoryByUserID(ctx, userID)
+	if err != nil {
+		return importCategories{}, err
+	}
+	categories := importCategories{byID: make(map[int]bool), byName: make(map[string]int)}
+	for _, category := range list {
+		if category.Kind != kind {
+			continue
+		}
+		categories.byID[category.ID] = true
+		categories.byName[strings.ToLower(strings.TrimSpace(category.Name))] = category.ID
+	}
+	return categories, nil
+}
+
+// newImportCSVReader builds a CSV reader with the given delimiter, guessing it from
+// the header line when none is given.
+func newImportCSVReader(r io.Reader, delimiter string) (*csv.Reader, error) {
+	buffered := bufio.NewReader(r)
+	var comma rune
+	if delimiter == "" {
+		firstLine, err := buffered.Peek(4096)
+		if err != nil && !errors.Is(err, io.EOF) && !errors.Is(err, bufio.ErrBufferFull) {
+			return nil, fmt.Errorf("failed to read file: %w", err)
+		}
+		comma = guessDelimiter(string(firstLine))
+	} else {
+		var ok bool
+		comma, ok = importDelimiters[delimiter]
+		if !ok {
+			return nil, fmt.Errorf("%w: unsupported delimiter %q", domain.ErrInvalidInput, delimiter)
+		}
+	}
+
+	reader := csv.NewReader(buffered)
+	reader.Comma = comma
+	reader.FieldsPerRecord = -1
+	reader.LazyQuotes = true
+	reader.TrimLeadingSpace = true
+	return reader, nil
+}
+
+// guessDelimiter picks the delimiter that appears the most in the first line.
+func guessDelimiter(text string) rune {
+	firstLine, _, _ := strings.Cut(text, "\n")
+	best, bestCount := ',', 0
+	for _, candidate := range []rune{',', ';', '\t', '|'} {
+		if count := strings.Count(firstLine, string(candidate)); count > bestCount {
+			best, bestCount = candidate, count
+		}
+	}
+	return best
+}
+
+// csvRowParser turns CSV records into simple expenses following a column mapping.
+type csvRowParser struct {
+	options      domain.CSVImportOptions
+	dateLayout   string
+	decimalComma bool
+	positions    map[string]int
+}
+
+func newCSVRowParser(options domain.CSVImportOptions) (*csvRowParser, error) {
+	if options.DateColumn == "" || options.AmountColumn == "" || options.DescriptionColumn == "" {
+		return nil, fmt.Errorf("%w: the date, amount and description columns are required", domain.ErrInvalidInput)
+	}
+	dateFormat := options.DateFormat
+	if dateFormat == "" {
+		dateFormat = "YYYY-MM-DD"
+	}
+	layout, ok := importDateLayouts[strings.ToUpper(dateFormat)]
+	if !ok {
+		return nil, fmt.Errorf("%w: unsupported date format %q", domain.ErrInvalidInput, options.DateFormat)
+	}
+	switch options.DecimalSeparator {
+	case "", ".", ",":
+	default:
+		return nil, fmt.Errorf("%w: unsupported decimal separator %q", domain.ErrInvalidInput, options.DecimalSeparator)
+	}
+	return &csvRowParser{options: options, dateLayout: layout, decimalComma: options.DecimalSeparator == ","}, nil
+}
+
+// mapHeader finds the mapped columns in the header, ignoring case and surrounding spaces.
+func (p *csvRowParser) mapHeader(header []string) error {
+	names := make(map[string]int, len(header))
+	for i, name := range header {
+		if i == 0 {
+			name = strings.TrimPrefix(name, "\ufeff")
+		}
+		names[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	p.positions = make(map[string]int)
+	for _, column := range []string{p.options.DateColumn, p.options.AmountColumn, p.options.DescriptionColumn, p.options.CategoryColumn} {
+		if column == "" {
+			continue
+		}
+		i, ok := names[strings.ToLower(strings.TrimSpace(column))]
+		if !ok {
+			return fmt.Errorf("%w: file has no %q column", domain.ErrInvalidInput, column)
+		}
+		p.positions[column] = i
+	}
+	return nil
+}
+
+func (p *csvRowParser) field(record []string, column string) string {
+	if i, ok := p.positions[column]; ok && i < len(record) {
+		return strings.TrimSpace(record[i])
+	}
+	return ""
+}
+
+// statementLine is an entry of a bank or card statement before it becomes an expense.
+// Amount keeps the sign it has in the file.
+type statementLine struct {
+	Line        int
+	Date        time.Time
+	Amount      domain.Money
+	Description string
+	CategoryID  int
+	Errors      []string
+}
+
+// read takes the mapped fields of a record, falling back to defaultCategoryID when the
+// record names no category.
+func (p *csvRowParser) read(record []string, line int, defaultCategoryID int, categories importCategories) statementLine {
+	entry := statementLine{Line: line, Description: p.field(record, p.options.DescriptionColumn), CategoryID: defaultCategoryID}
+
+	date, err := time.Parse(p.dateLayout, p.field(record, p.options.DateColumn))
+	if err != nil {
+		entry.Errors = append(entry.Errors, fmt.Sprintf("invalid date %q", p.field(record, p.options.DateColumn)))
+	}
+	entry.Date = date
+
+	amount, err := parseImportAmount(p.field(record, p.options.AmountColumn), p.decimalComma)
+	if err != nil {
+		entry.Errors = append(entry.Errors, err.Error())
+	}
+	entry.Amount = amount
+
+	if p.options.CategoryColumn != "" {
+		if name := p.field(record, p.options.CategoryColumn); name != "" {
+			id, ok := categories.resolve(name)
+			if !ok {
+				entry.Errors = append(entry.Errors, fmt.Sprintf("unknown category %q", name))
+			}
+			entry.CategoryID = id
+		}
+	}
+	if entry.CategoryID == 0 && len(entry.Errors) == 0 {
+		entry.Errors = append(entry.Errors, "no category and no default category_id")
+	}
+	return entry
+}
+
+func (p *csvRowParser) parse(record []string, line int, defaultCategoryID int, categories importCategories) domain.ImportRow {
+	entry := p.read(record, line, defaultCategoryID, categories)
+	row := domain.ImportRow{Line: line, Errors: entry.Errors}
+
+	amount := entry.Amount
+	if p.options.NegativeExpenses {
+		if amount > 0 {
+			row.Status = domain.ImportRowSkipped
+			row.Errors = nil
+			return row
+		}
+		amount = -amount
+	}
+	if amount <= 0 && len(row.Errors) == 0 {
+		row.Errors = append(row.Errors, "amount must be greater than zero")
+	}
+
+	if len(row.Errors) > 0 {
+		row.Status = domain.ImportRowInvalid
+		return row
+	}
+	expense := domain.SimpleExpense{CategoryID: entry.CategoryID, Amount: amount, Date: entry.Date}
+	if entry.Description != "" {
+		expense.Description = &entry.Description
+	}
+	row.Status = domain.ImportRowValid
+	row.Expense = &expense
+	return row
+}
+
+// parseImportAmount reads an amount as banks export it, such as "-1.234,56" with a
+// decimal comma or "R$ 1,234.56" with a decimal point.
+func parseImportAmount(value string, decimalComma bool) (domain.Money, error) {
+	cleaned := strings.NewReplacer("R$", "", "$", "", " ", "", "\u00a0", "").Replace(value)
+	if decimalComma {
+		cleaned = strings.ReplaceAll(cleaned, ".", "")
+		cleaned = strings.ReplaceAll(cleaned, ",", ".")
+	} else {
+		cleaned = strings.ReplaceAll(cleaned, ",", "")
+	}
+	if cleaned == "" {
+		return 0, fmt.Errorf("invalid amount %q", value)
+	}
+	amount, err := domain.ParseMoney(cleaned)
+	if err != nil {
+		return 0, fmt.Errorf("invalid amount %q", value)
+	}
+	return amount, nil
+}
@@ -0,0 +1,310 @@
+package services
+
+import (
+	"errors"
+	"github.com/google/uuid"
+	"github.com/misalima/my-budget-planner-backend/internal/core/domain"
+	"testing"
+	"time"
+)
+
+func TestNewCSVRowParser(t *testing.T) {
+	columns := domain.CSVImportOptions{DateColumn: "Data", AmountColumn: "Valor", DescriptionColumn: "Histórico"}
+	tests := []struct {
+		name    string
+		options func(domain.CSVImportOptions) domain.CSVImportOptions
+		layout  string
+		wantErr bool
+	}{
+		{name: "default date format", options: func(o domain.CSVImportOptions) domain.CSVImportOptions { return o }, layout: "2006-01-02"},
+		{name: "lower-case date format", options: func(o domain.CSVImportOptions) domain.CSVImportOptions {
+			o.DateFormat = "dd/mm/yyyy"
+			return o
+		}, layout: "02/01/2006"},
+		{name: "missing amount column", options: func(o domain.CSVImportOptions) domain.CSVImportOptions {
+			o.AmountColumn = ""
+			return o
+		}, wantErr: true},
+		{name: "unsupported date format", options: func(o domain.CSVImportOptions) domain.CSVImportOptions {
+			o.DateFormat = "YYYY/DD/MM"
+			return o
+		}, wantErr: true},
+		{name: "unsupported decimal separator", options: func(o domain.CSVImportOptions) domain.CSVImportOptions {
+			o.DecimalSeparator = ";"
+			return o
+		}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			parser, err := newCSVRowParser(tt.options(columns))
+			if tt.wantErr {
+				if !errors.Is(err, domain.ErrInvalidInput) {
+					t.Fatalf("got error %v, want ErrInvalidInput", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if parser.dateLayout != tt.layout {
+				t.Errorf("got layout %q, want %q", parser.dateLayout, tt.layout)
+			}
+		})
+	}
+}
+
+func TestCSVRowParserMapHeader(t *testing.T) {
+	options := domain.CSVImportOptions{DateColumn: "Data", AmountColumn: "Valor", DescriptionColumn: "Histórico", CategoryColumn: "Categoria"}
+	tests := []struct {
+		name    string
+		header  []string
+		want    map[string]int
+		wantErr bool
+	}{
+		{
+			name:   "same order",
+			header: []string{"Data", "Valor", "Histórico", "Categoria"},
+			want:   map[string]int{"Data": 0, "Valor": 1, "Histórico": 2, "Categoria": 3},
+		},
+		{
+			name:   "any case, spaces and extra columns",
+			header: []string{" saldo ", "CATEGORIA", " histórico", "valor ", "data"},
+			want:   map[string]int{"Data": 4, "Valor": 3, "Histórico": 2, "Categoria": 1},
+		},
+		{
+			name:   "byte order mark on the first column",
+			header: []string{"\ufeffData", "Valor", "Histórico", "Categoria"},
+			want:   map[string]int{"Data": 0, "Valor": 1, "Histórico": 2, "Categoria": 3},
+		},
+		{
+			name:    "missing mapped column",
+			header:  []string{"Data", "Valor", "Histórico"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			parser, err := newCSVRowParser(options)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			err = parser.mapHeader(tt.header)
+			if tt.wantErr {
+				if !errors.Is(err, domain.ErrInvalidInput) {
+					t.Fatalf("got error %v, want ErrInvalidInput", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			for column, position := range tt.want {
+				if got, ok := parser.positions[column]; !ok || got != position {
+					t.Errorf("column %q at %d, want %d", column, got, position)
+				}
+			}
+		})
+	}
+}
+
+func TestCSVRowParserParse(t *testing.T) {
+	categories := importCategories{
+		byID:   map[int]bool{3: true, 7: true},
+		byName: map[string]int{"mercado": 3, "transporte": 7},
+	}
+	date := time.Date(2025, time.March, 4, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name              string
+		options           domain.CSVImportOptions
+		record            []string
+		defaultCategoryID int
+		wantStatus        string
+		wantAmount        domain.Money
+		wantCategoryID    int
+	}{
+		{
+			name:           "decimal comma with category name",
+			options:        domain.CSVImportOptions{DateFormat: "DD/MM/YYYY", DecimalSeparator: ","},
+			record:         []string{"04/03/2025", "1.234,56", "PADARIA", "Mercado"},
+			wantStatus:     domain.ImportRowValid,
+			wantAmount:     123456,
+			wantCategoryID: 3,
+		},
+		{
+			name:           "category by id",
+			options:        domain.CSVImportOptions{},
+			record:         []string{"2025-03-04", "R$ 12.50", "UBER", "7"},
+			wantStatus:     domain.ImportRowValid,
+			wantAmount:     1250,
+			wantCategoryID: 7,
+		},
+		{
+			name:              "default category when the column is empty",
+			options:           domain.CSVImportOptions{},
+			record:            []string{"2025-03-04", "1,000.00", "ALUGUEL", ""},
+			defaultCategoryID: 3,
+			wantStatus:        domain.ImportRowValid,
+			wantAmount:        100000,
+			wantCategoryID:    3,
+		},
+		{
+			name:           "negative expenses read as positive",
+			options:        domain.CSVImportOptions{NegativeExpenses: true},
+			record:         []string{"2025-03-04", "-45.90", "FARMACIA", "mercado"},
+			wantStatus:     domain.ImportRowValid,
+			wantAmount:     4590,
+			wantCategoryID: 3,
+		},
+		{
+			name:       "credits skipped with negative expenses",
+			options:    domain.CSVImportOptions{NegativeExpenses: true},
+			record:     []string{"2025-03-04", "3000.00", "SALARIO", ""},
+			wantStatus: domain.ImportRowSkipped,
+		},
+		{
+			name:       "unknown category",
+			options:    domain.CSVImportOptions{},
+			record:     []string{"2025-03-04", "10.00", "CINEMA", "Lazer"},
+			wantStatus: domain.ImportRowInvalid,
+		},
+		{
+			name:       "invalid date",
+			options:    domain.CSVImportOptions{},
+			record:     []string{"04/03/2025", "10.00", "CINEMA", "mercado"},
+			wantStatus: domain.ImportRowInvalid,
+		},
+		{
+			name:       "zero amount",
+			options:    domain.CSVImportOptions{},
+			record:     []string{"2025-03-04", "0,00", "ESTORNO", "mercado"},
+			wantStatus: domain.ImportRowInvalid,
+		},
+		{
+			name:       "no category at all",
+			options:    domain.CSVImportOptions{},
+			record:     []string{"2025-03-04", "10.00", "CINEMA"},
+			wantStatus: domain.ImportRowInvalid,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			options := tt.options
+			options.DateColumn, options.AmountColumn, options.DescriptionColumn, options.CategoryColumn = "date", "amount", "description", "category"
+			parser, err := newCSVRowParser(options)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if err := parser.mapHeader([]string{"date", "amount", "description", "category"}); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			row := parser.parse(tt.record, 2, tt.defaultCategoryID, categories)
+			if row.Status != tt.wantStatus {
+				t.Fatalf("got status %q (errors %v), want %q", row.Status, row.Errors, tt.wantStatus)
+			}
+			if tt.wantStatus != domain.ImportRowValid {
+				return
+			}
+			if row.Expense.Amount != tt.wantAmount {
+				t.Errorf("got amount %v, want %v", row.Expense.Amount, tt.wantAmount)
+			}
+			if row.Expense.CategoryID != tt.wantCategoryID {
+				t.Errorf("got category %d, want %d", row.Expense.CategoryID, tt.wantCategoryID)
+			}
+			if !row.Expense.Date.Equal(date) {
+				t.Errorf("got date %v, want %v", row.Expense.Date, date)
+			}
+		})
+	}
+}
+
+func TestParseParcel(t *testing.T) {
+	tests := []struct {
+		description     string
+		bareSuffix      bool
+		wantDescription string
+		wantParcel      int
+		wantQuantity    int
+	}{
+		{description: "STORE 03/10", bareSuffix: true, wantDescription: "STORE", wantParcel: 3, wantQuantity: 10},
+		{description: "STORE 3/10", bareSuffix: true, wantDescription: "STORE", wantParcel: 3, wantQuantity: 10},
+		{description: "STORE - Parcela 3/10", wantDescription: "STORE", wantParcel: 3, wantQuantity: 10},
+		{description: "STORE PARC 01/12", wantDescription: "STORE", wantParcel: 1, wantQuantity: 12},
+		{description: "STORE parc.2 / 4", wantDescription: "STORE", wantParcel: 2, wantQuantity: 4},
+		{description: "STORE - Parcela 3/10", bareSuffix: true, wantDescription: "STORE", wantParcel: 3, wantQuantity: 10},
+		{description: "STORE 10/10", bareSuffix: true, wantDescription: "STORE", wantParcel: 10, wantQuantity: 10},
+		{description: "STORE 03/10", wantDescription: "STORE 03/10", wantParcel: 1, wantQuantity: 1},
+		{description: "UBER 05/11", wantDescription: "UBER 05/11", wantParcel: 1, wantQuantity: 1},
+		{description: "STORE", bareSuffix: true, wantDescription: "STORE", wantParcel: 1, wantQuantity: 1},
+		{description: "STORE 03/10 SP", bareSuffix: true, wantDescription: "STORE 03/10 SP", wantParcel: 1, wantQuantity: 1},
+		{description: "PAYMENT 12/05", bareSuffix: true, wantDescription: "PAYMENT 12/05", wantParcel: 1, wantQuantity: 1},
+		{description: "STORE 00/10", bareSuffix: true, wantDescription: "STORE 00/10", wantParcel: 1, wantQuantity: 1},
+		{description: "STORE 1/1", bareSuffix: true, wantDescription: "STORE 1/1", wantParcel: 1, wantQuantity: 1},
+		{description: "STORE 100/200", bareSuffix: true, wantDescription: "STORE 100/200", wantParcel: 1, wantQuantity: 1},
+	}
+	for _, tt := range tests {
+		description, parcel, quantity := parseParcel(tt.description, tt.bareSuffix)
+		if description != tt.wantDescription || parcel != tt.wantParcel || quantity != tt.wantQuantity {
+			t.Errorf("parseParcel(%q, %v) = %q, %d, %d, want %q, %d, %d",
+				tt.description, tt.bareSuffix, description, parcel, quantity, tt.wantDescription, tt.wantParcel, tt.wantQuantity)
+		}
+	}
+}
+
+func TestMatchParcel(t *testing.T) {
+	date := time.Date(2025, time.March, 4, 0, 0, 0, 0, time.UTC)
+	description := func(s string) *string { return &s }
+	planned := domain.CreditCardExpense{
+		Description: description("STORE"), Date: date,
+		Amount: 3000, InstallmentAmount: 300, InstallmentsQuantity: 10, ParcelNumber: 3,
+	}
+	stored := func(desc *string, gap time.Duration) domain.CreditCardExpense {
+		return domain.CreditCardExpense{
+			ID: uuid.New(), Description: desc, Date: date.Add(gap),
+			Amount: 3000, InstallmentAmount: 300, InstallmentsQuantity: 10, ParcelNumber: 3,
+		}
+	}
+	tests := []struct {
+		name      string
+		candidate domain.CreditCardExpense
+		used      bool
+		wantMatch bool
+	}{
+		{name: "same parcel", candidate: stored(description("STORE"), 0), wantMatch: true},
+		{name: "description in another case", candidate: stored(description("store"), 24*time.Hour), wantMatch: true},
+		{name: "description stored with its parcel notation", candidate: stored(description("STORE 03/10"), 0), wantMatch: true},
+		{name: "another purchase of the same value", candidate: stored(description("UBER"), 0)},
+		{name: "no description", candidate: stored(nil, 0)},
+		{name: "too far apart", candidate: stored(description("STORE"), parcelMatchWindow+24*time.Hour)},
+		{name: "already matched", candidate: stored(description("STORE"), 0), used: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			used := map[uuid.UUID]bool{tt.candidate.ID: tt.used}
+			match, ok := matchParcel(planned, []domain.CreditCardExpense{tt.candidate}, used)
+			if ok != tt.wantMatch {
+				t.Fatalf("got match %v, want %v", ok, tt.wantMatch)
+			}
+			if ok && match.ID != tt.candidate.ID {
+				t.Errorf("matched %s, want %s", match.ID, tt.candidate.ID)
+			}
+		})
+	}
+}
+
+func TestCardStatementRowBareParcelSuffix(t *testing.T) {
+	card := domain.CreditCard{ID: uuid.New(), UserID: uuid.New(), Currency: "BRL"}
+	line := statementLine{Line: 2, Date: time.Date(2025, time.March, 4, 0, 0, 0, 0, time.UTC), Description: "UBER 05/11", Amount: 2500, CategoryID: 3}
+
+	row := cardStatementRow(line, card, domain.CardStatementImportOptions{})
+	if row.Status != domain.ImportRowValid || len(row.CardExpenses) != 1 || len(row.Warnings) != 1 {
+		t.Fatalf("got status %q, %d parcels and warnings %v, want one parcel and a warning", row.Status, len(row.CardExpenses), row.Warnings)
+	}
+	if got := *row.CardExpenses[0].Description; got != "UBER 05/11" {
+		t.Errorf("got description %q, want it untouched", got)
+	}
+
+	row = cardStatementRow(line, card, domain.CardStatementImportOptions{BareParcelSuffix: true})
+	if row.Status != domain.ImportRowValid || len(row.CardExpenses) != 7 || len(row.Warnings) != 0 {
+		t.Fatalf("got status %q, %d parcels and warnings %v, want parcels 5 to 11", row.Status, len(row.CardExpenses), row.Warnings)
+	}
+}
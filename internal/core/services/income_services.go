@@ -0,0 +1,217 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/misalima/my-budget-planner-backend/internal/core/domain"
+	"github.com/misalima/my-budget-planner-backend/internal/core/interfaces/irepository"
+	"time"
+)
+
+type IncomeService struct {
+	repo                  irepository.IncomeLoader
+	categoryRepo          irepository.CategoryLoader
+	simpleExpenseRepo     irepository.SimpleExpenseLoader
+	recurringExpenseRepo  irepository.RecurringExpenseLoader
+	creditCardExpenseRepo irepository.CreditCardExpenseLoader
+	userRepo              irepository.UserLoader
+	exchangeRateRepo      irepository.ExchangeRateLoader
+}
+
+func NewIncomeService(
+	repo irepository.IncomeLoader,
+	categoryRepo irepository.CategoryLoader,
+	simpleExpenseRepo irepository.SimpleExpenseLoader,
+	recurringExpenseRepo irepository.RecurringExpenseLoader,
+	creditCardExpenseRepo irepository.CreditCardExpenseLoader,
+	userRepo irepository.UserLoader,
+	exchangeRateRepo irepository.ExchangeRateLoader,
+) *IncomeService {
+	return &IncomeService{
+		repo:                  repo,
+		categoryRepo:          categoryRepo,
+		simpleExpenseRepo:     simpleExpenseRepo,
+		recurringExpenseRepo:  recurringExpenseRepo,
+		creditCardExpenseRepo: creditCardExpenseRepo,
+		userRepo:              userRepo,
+		exchangeRateRepo:      exchangeRateRepo,
+	}
+}
+
+func (s *IncomeService) CreateIncome(ctx context.Context, income domain.Income) (domain.Income, error) {
+	currency, err := currencyOrBase(ctx, s.userRepo, income.UserID, income.Currency)
+	if err != nil {
+		return domain.Income{}, err
+	}
+	income.Currency = currency
+	if err := s.validateIncome(ctx, income); err != nil {
+		return domain.Income{}, err
+	}
+	return s.repo.InsertIncome(ctx, income)
+}
+
+func (s *IncomeService) UpdateIncome(ctx context.Context, income domain.Income) (domain.Income, error) {
+	if _, err := s.GetIncomeByID(ctx, income.ID, income.UserID); err != nil {
+		return domain.Income{}, err
+	}
+	currency, err := currencyOrBase(ctx, s.userRepo, income.UserID, income.Currency)
+	if err != nil {
+		return domain.Income{}, err
+	}
+	income.Currency = currency
+	if err := s.validateIncome(ctx, income); err != nil {
+		return domain.Income{}, err
+	}
+	return s.repo.UpdateIncome(ctx, income)
+}
+
+func (s *IncomeService) DeleteIncome(ctx context.Context, id uuid.UUID, userID uuid.UUID) error {
+	if _, err := s.GetIncomeByID(ctx, id, userID); err != nil {
+		return err
+	}
+	return s.repo.DeleteIncome(ctx, id)
+}
+
+func (s *IncomeService) GetIncomeByID(ctx context.Context, id uuid.UUID, userID uuid.UUID) (domain.Income, error) {
+	income, err := s.repo.FindIncomeByID(ctx, id)
+	if err != nil {
+		return income, err
+	}
+	if income.UserID != userID {
+		return domain.Income{}, domain.ErrNotFound
+	}
+	return income, nil
+}
+
+func (s *IncomeService) ListIncomes(ctx context.Context, userID uuid.UUID, filters irepository.IncomeFilters) ([]domain.Income, error) {
+	return s.repo.FindIncomes(ctx, userID, filters)
+}
+
+// GetIncomeSummary totals every income received inside the range, counting each
+// occurrence of a recurring income. Amounts are converted into the user's base
+// currency at the rate of the day each occurrence was received.
+func (s *IncomeService) GetIncomeSummary(ctx context.Context, userID uuid.UUID, startDate, endDate time.Time) (domain.IncomeSummary, error) {
+	converter, err := newBaseCurrencyConverter(ctx, s.userRepo, s.exchangeRateRepo, userID)
+	if err != nil {
+		return domain.IncomeSummary{}, err
+	}
+	return s.incomeSummary(ctx, converter, userID, startDate, endDate)
+}
+
+func (s *IncomeService) incomeSummary(ctx context.Context, converter *currencyConverter, userID uuid.UUID, startDate, endDate time.Time) (domain.IncomeSummary, error) {
+	incomes, err := s.repo.FindIncomesByDateRange(ctx, userID, startDate, endDate)
+	if err != nil {
+		return domain.IncomeSummary{}, err
+	}
+	summary := domain.IncomeSummary{Currency: converter.target, ByCategory: make(map[int]domain.Money)}
+	for _, income := range incomes {
+		for _, date := range income.OccurrencesBetween(startDate, endDate) {
+			amount, err := converter.convert(ctx, income.Amount, income.Currency, date)
+			if err != nil {
+				return domain.IncomeSummary{}, err
+			}
+			summary.TotalAmount += amount
+			summary.TotalCount++
+			summary.ByCategory[income.CategoryID] += amount
+			if income.IsRecurring() {
+				summary.RecurringTotal += amount
+			} else {
+				summary.OneOffTotal += amount
+			}
+		}
+	}
+	if summary.TotalCount > 0 {
+		summary.AverageAmount = summary.TotalAmount.DivideBy(summary.TotalCount)
+	}
+	return summary, nil
+}
+
+// GetCashFlow compares the incomes of the range with its expenses. Recurring expenses
+// count through their stored occurrences and card purchases count by installment, the
+// same way budgets consume them.
+func (s *IncomeService) GetCashFlow(ctx context.Context, userID uuid.UUID, startDate, endDate time.Time) (domain.CashFlow, error) {
+	if endDate.Before(startDate) {
+		return domain.CashFlow{}, fmt.Errorf("%w: end date must not be before start date", domain.ErrInvalidInput)
+	}
+	converter, err := newBaseCurrencyConverter(ctx, s.userRepo, s.exchangeRateRepo, userID)
+	if err != nil {
+		return domain.CashFlow{}, err
+	}
+	incomeSummary, err := s.incomeSummary(ctx, converter, userID, startDate, endDate)
+	if err != nil {
+		return domain.CashFlow{}, err
+	}
+	simpleExpenses, err := s.simpleExpenseRepo.FindSimpleExpensesByDateRange(ctx, userID, startDate, endDate)
+	if err != nil {
+		return domain.CashFlow{}, err
+	}
+	recurringExpenses, err := s.recurringExpenseRepo.FindGeneratedRecurringExpensesByDateRange(ctx, userID, startDate, endDate)
+	if err != nil {
+		return domain.CashFlow{}, err
+	}
+	creditCardExpenses, err := s.creditCardExpenseRepo.FindCreditCardExpensesByDateRange(ctx, userID, startDate, endDate)
+	if err != nil {
+		return domain.CashFlow{}, err
+	}
+
+	flow := domain.CashFlow{StartDate: startDate, EndDate: endDate, Currency: converter.target, IncomeTotal: incomeSummary.TotalAmount}
+	for _, e := range simpleExpenses {
+		amount, err := converter.convert(ctx, e.Amount, e.Currency, e.Date)
+		if err != nil {
+			return domain.CashFlow{}, err
+		}
+		flow.SimpleExpensesTotal += amount
+	}
+	for _, e := range recurringExpenses {
+		amount, err := converter.convert(ctx, e.Amount, e.Currency, e.Date)
+		if err != nil {
+			return domain.CashFlow{}, err
+		}
+		flow.RecurringExpensesTotal += amount
+	}
+	for _, e := range creditCardExpenses {
+		amount, err := converter.convert(ctx, e.InstallmentValue(), e.Currency, e.Date)
+		if err != nil {
+			return domain.CashFlow{}, err
+		}
+		flow.CreditCardExpensesTotal += amount
+	}
+	flow.ExpensesTotal = flow.SimpleExpensesTotal + flow.RecurringExpensesTotal + flow.CreditCardExpensesTotal
+	flow.NetCashFlow = flow.IncomeTotal - flow.ExpensesTotal
+	return flow, nil
+}
+
+func (s *IncomeService) validateIncome(ctx context.Context, income domain.Income) error {
+	if income.Amount <= 0 {
+		return fmt.Errorf("%w: income amount must be greater than zero", domain.ErrInvalidInput)
+	}
+	if income.Date.IsZero() {
+		return fmt.Errorf("%w: income date is required", domain.ErrInvalidInput)
+	}
+	if income.Frequency != nil && !domain.IsValidFrequency(*income.Frequency) {
+		return fmt.Errorf("%w: unknown frequency %q", domain.ErrInvalidInput, *income.Frequency)
+	}
+	if income.EndDate != nil {
+		if income.Frequency == nil {
+			return fmt.Errorf("%w: only recurring incomes can have an end date", domain.ErrInvalidInput)
+		}
+		if income.EndDate.Before(income.Date) {
+			return fmt.Errorf("%w: income end date must not be before its date", domain.ErrInvalidInput)
+		}
+	}
+
+	categories, err := s.categoryRepo.GetCategoryByUserID(ctx, income.UserID)
+	if err != nil {
+		return err
+	}
+	for _, c := range categories {
+		if c.ID == income.CategoryID {
+			if c.Kind != domain.CategoryKindIncome {
+				return fmt.Errorf("%w: category %d is not an income category", domain.ErrInvalidInput, c.ID)
+			}
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: category %d does not exist", domain.ErrInvalidInput, income.CategoryID)
+}
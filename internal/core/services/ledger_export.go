@@ -0,0 +1,237 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/misalima/my-budget-planner-backend/internal/core/domain"
+	"github.com/misalima/my-budget-planner-backend/internal/core/interfaces/irepository"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+)
+
+const (
+	// ledgerCashAccount pays for every expense that is not charged to a card.
+	ledgerCashAccount  = "Assets:Checking"
+	ledgerExpensesRoot = "Expenses"
+	ledgerCardsRoot    = "Liabilities:CreditCard"
+)
+
+// ledgerEntry is one balanced transaction of a ledger export: Amount moves from the
+// paying account to the expense account.
+type ledgerEntry struct {
+	ID          uuid.UUID
+	Date        time.Time
+	CreatedAt   time.Time
+	Description string
+	Expense     string
+	Source      string
+	Amount      domain.Money
+	Currency    string
+}
+
+// ExportLedger renders the simple expenses, recurring expense occurrences and card
+// installments of the user dated within the range as a plain-text accounting journal.
+// Categories become expense accounts and credit cards liability accounts; expenses
+// not charged to a card are paid from Assets:Checking.
+func (s *ExportService) ExportLedger(ctx context.Context, userID uuid.UUID, options domain.LedgerExportOptions) ([]byte, error) {
+	if !domain.IsValidLedgerFormat(options.Format) {
+		return nil, fmt.Errorf("%w: unknown ledger format %q", domain.ErrInvalidInput, options.Format)
+	}
+	if options.EndDate.Before(options.StartDate) {
+		return nil, fmt.Errorf("%w: end date is before start date", domain.ErrInvalidInput)
+	}
+	user, err := s.userRepo.GetUserByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	categories, err := s.categoryRepo.GetCategoryByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	expenseAccounts := make(map[int]string, len(categories))
+	for _, c := range categories {
+		expenseAccounts[c.ID] = ledgerExpensesRoot + ":" + ledgerAccountName(c.Name)
+	}
+	cards, err := s.cardRepo.FetchAllByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	cardAccounts := make(map[uuid.UUID]string, len(cards))
+	for _, cc := range cards {
+		cardAccounts[cc.ID] = ledgerCardsRoot + ":" + ledgerAccountName(cc.CardName)
+	}
+	expenseAccount := func(categoryID int) string {
+		if account, ok := expenseAccounts[categoryID]; ok {
+			return account
+		}
+		return fmt.Sprintf("%s:Category-%d", ledgerExpensesRoot, categoryID)
+	}
+	sourceAccount := func(cardID *uuid.UUID) string {
+		if cardID == nil {
+			return ledgerCashAccount
+		}
+		if account, ok := cardAccounts[*cardID]; ok {
+			return account
+		}
+		return ledgerCardsRoot + ":" + cardID.String()[:8]
+	}
+
+	var entries []ledgerEntry
+	simpleExpenses, err := s.simpleExpenseRepo.FindSimpleExpenses(ctx, userID, irepository.SimpleExpenseFilters{
+		StartDate: &options.StartDate,
+		EndDate:   &options.EndDate,
+	})
+	if err != nil {
+		return nil, err
+	}
+	for _, e := range simpleExpenses {
+		entries = append(entries, ledgerEntry{
+			ID: e.ID, Date: e.Date, CreatedAt: e.CreatedAt, Description: ledgerDescription(e.Description, "Expense"),
+			Expense: expenseAccount(e.CategoryID), Source: ledgerCashAccount, Amount: e.Amount, Currency: e.Currency,
+		})
+	}
+
+	// Templates are not expenses themselves: only their stored occurrences, by the date
+	// each one falls due, are written.
+	recurringExpenses, err := s.recurringExpenseRepo.FindGeneratedRecurringExpensesByDateRange(ctx, userID, options.StartDate, options.EndDate)
+	if err != nil {
+		return nil, err
+	}
+	for _, e := range recurringExpenses {
+		entries = append(entries, ledgerEntry{
+			ID: e.ID, Date: e.Date, CreatedAt: e.CreatedAt, Description: ledgerDescription(e.Description, "Recurring expense"),
+			Expense: expenseAccount(e.CategoryID), Source: sourceAccount(e.CardID), Amount: e.Amount, Currency: e.Currency,
+		})
+	}
+
+	cardExpenses, err := s.creditCardExpenseRepo.FindCreditCardExpenses(ctx, userID, irepository.CreditCardExpenseFilters{
+		StartDate: &options.StartDate,
+		EndDate:   &options.EndDate,
+	})
+	if err != nil {
+		return nil, err
+	}
+	for _, e := range cardExpenses {
+		description := ledgerDescription(e.Description, "Credit card expense")
+		if e.InstallmentsQuantity > 1 {
+			description = fmt.Sprintf("%s (%d/%d)", description, e.ParcelNumber, e.InstallmentsQuantity)
+		}
+		entries = append(entries, ledgerEntry{
+			ID: e.ID, Date: e.Date, CreatedAt: e.CreatedAt, Description: description,
+			Expense: expenseAccount(e.CategoryID), Source: sourceAccount(&e.CardID), Amount: e.InstallmentValue(), Currency: e.Currency,
+		})
+	}
+
+	sort.SliceStable(entries, func(i, j int) bool {
+		a, b := entries[i], entries[j]
+		if !a.Date.Equal(b.Date) {
+			return a.Date.Before(b.Date)
+		}
+		if !a.CreatedAt.Equal(b.CreatedAt) {
+			return a.CreatedAt.Before(b.CreatedAt)
+		}
+		return a.ID.String() < b.ID.String()
+	})
+
+	if options.Format == domain.LedgerFormatBeancount {
+		return writeBeancount(entries, user.BaseCurrency, options), nil
+	}
+	return writeLedgerJournal(entries, options), nil
+}
+
+// ledgerAccounts lists every account the entries use, sorted.
+func ledgerAccounts(entries []ledgerEntry) []string {
+	seen := make(map[string]bool)
+	var accounts []string
+	for _, e := range entries {
+		for _, account := range []string{e.Expense, e.Source} {
+			if !seen[account] {
+				seen[account] = true
+				accounts = append(accounts, account)
+			}
+		}
+	}
+	sort.Strings(accounts)
+	return accounts
+}
+
+// writeLedgerJournal writes the entries as a journal both ledger and hledger read.
+func writeLedgerJournal(entries []ledgerEntry, options domain.LedgerExportOptions) []byte {
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "; My Budget Planner export from %s to %s\n\n",
+		options.StartDate.Format("2006-01-02"), options.EndDate.Format("2006-01-02"))
+	accounts := ledgerAccounts(entries)
+	for _, account := range accounts {
+		fmt.Fprintf(&buf, "account %s\n", account)
+	}
+	if len(accounts) > 0 {
+		buf.WriteString("\n")
+	}
+	for _, e := range entries {
+		fmt.Fprintf(&buf, "%s %s\n", e.Date.Format("2006-01-02"), e.Description)
+		fmt.Fprintf(&buf, "    ; id: %s\n", e.ID)
+		fmt.Fprintf(&buf, "    %s    %s %s\n", e.Expense, e.Amount, e.Currency)
+		fmt.Fprintf(&buf, "    %s    %s %s\n\n", e.Source, -e.Amount, e.Currency)
+	}
+	return buf.Bytes()
+}
+
+// writeBeancount writes the entries as a beancount file. Beancount only accepts
+// postings to accounts opened before them, so every account is opened on the first
+// day of the range.
+func writeBeancount(entries []ledgerEntry, baseCurrency string, options domain.LedgerExportOptions) []byte {
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "; My Budget Planner export from %s to %s\n\n",
+		options.StartDate.Format("2006-01-02"), options.EndDate.Format("2006-01-02"))
+	fmt.Fprintf(&buf, "option \"operating_currency\" %q\n\n", baseCurrency)
+	accounts := ledgerAccounts(entries)
+	for _, account := range accounts {
+		fmt.Fprintf(&buf, "%s open %s\n", options.StartDate.Format("2006-01-02"), account)
+	}
+	if len(accounts) > 0 {
+		buf.WriteString("\n")
+	}
+	for _, e := range entries {
+		fmt.Fprintf(&buf, "%s * %s\n", e.Date.Format("2006-01-02"), beancountString(e.Description))
+		fmt.Fprintf(&buf, "  id: %s\n", beancountString(e.ID.String()))
+		fmt.Fprintf(&buf, "  %s  %s %s\n", e.Expense, e.Amount, e.Currency)
+		fmt.Fprintf(&buf, "  %s  %s %s\n\n", e.Source, -e.Amount, e.Currency)
+	}
+	return buf.Bytes()
+}
+
+func beancountString(value string) string {
+	value = strings.ReplaceAll(value, `\`, `\\`)
+	return `"` + strings.ReplaceAll(value, `"`, `\"`) + `"`
+}
+
+// ledgerAccountName turns a category or card name into an account name component
+// every format accepts: words of letters and digits, capitalized and joined by dashes.
+func ledgerAccountName(name string) string {
+	words := strings.FieldsFunc(name, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	for i, word := range words {
+		runes := []rune(word)
+		runes[0] = unicode.ToUpper(runes[0])
+		words[i] = string(runes)
+	}
+	if len(words) == 0 {
+		return "Unnamed"
+	}
+	return strings.Join(words, "-")
+}
+
+// ledgerDescription keeps the description of an entry on a single line, falling back
+// to the kind of entry when there is none.
+func ledgerDescription(description *string, fallback string) string {
+	if description == nil || strings.TrimSpace(*description) == "" {
+		return fallback
+	}
+	return strings.Join(strings.Fields(*description), " ")
+}
@@ -0,0 +1,134 @@
+package services
+
+import (
+	"github.com/google/uuid"
+	"github.com/misalima/my-budget-planner-backend/internal/core/domain"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestLedgerAccountName(t *testing.T) {
+	tests := []struct {
+		name string
+		want string
+	}{
+		{name: "Mercado", want: "Mercado"},
+		{name: "mercado", want: "Mercado"},
+		{name: "casa e contas", want: "Casa-E-Contas"},
+		{name: "  Saúde / farmácia  ", want: "Saúde-Farmácia"},
+		{name: "Nubank: Roxinho", want: "Nubank-Roxinho"},
+		{name: "2nd card", want: "2nd-Card"},
+		{name: "iPhone", want: "IPhone"},
+		{name: "", want: "Unnamed"},
+		{name: " -:/ ", want: "Unnamed"},
+	}
+	for _, tt := range tests {
+		if got := ledgerAccountName(tt.name); got != tt.want {
+			t.Errorf("ledgerAccountName(%q) = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestBeancountString(t *testing.T) {
+	tests := []struct {
+		value string
+		want  string
+	}{
+		{value: "PADARIA", want: `"PADARIA"`},
+		{value: "", want: `""`},
+		{value: `say "hi"`, want: `"say \"hi\""`},
+		{value: `C:\path`, want: `"C:\\path"`},
+		{value: `ends with \`, want: `"ends with \\"`},
+		{value: `\"`, want: `"\\\""`},
+	}
+	for _, tt := range tests {
+		if got := beancountString(tt.value); got != tt.want {
+			t.Errorf("beancountString(%q) = %s, want %s", tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestLedgerJournalsBalance(t *testing.T) {
+	date := time.Date(2025, time.March, 4, 0, 0, 0, 0, time.UTC)
+	entries := []ledgerEntry{
+		{ID: uuid.New(), Date: date, Description: "PADARIA", Expense: "Expenses:Mercado", Source: ledgerCashAccount, Amount: 4590, Currency: "BRL"},
+		{ID: uuid.New(), Date: date, Description: `say "hi"`, Expense: "Expenses:Lazer", Source: "Liabilities:CreditCard:Nubank", Amount: 1, Currency: "BRL"},
+		{ID: uuid.New(), Date: date.AddDate(0, 0, 1), Description: "RENT", Expense: "Expenses:Casa", Source: ledgerCashAccount, Amount: 250000, Currency: "USD"},
+	}
+	options := domain.LedgerExportOptions{StartDate: date, EndDate: date.AddDate(0, 1, 0)}
+
+	tests := []struct {
+		name    string
+		journal []byte
+		indent  string
+	}{
+		{name: "ledger", journal: writeLedgerJournal(entries, options), indent: "    "},
+		{name: "beancount", journal: writeBeancount(entries, "BRL", options), indent: "  "},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			transactions := ledgerTransactions(t, string(tt.journal), tt.indent)
+			if len(transactions) != len(entries) {
+				t.Fatalf("got %d transactions, want %d", len(transactions), len(entries))
+			}
+			for i, postings := range transactions {
+				if len(postings) != 2 {
+					t.Fatalf("transaction %d has %d postings, want 2", i, len(postings))
+				}
+				total := make(map[string]domain.Money)
+				for _, p := range postings {
+					total[p.currency] += p.amount
+				}
+				for currency, sum := range total {
+					if sum != 0 {
+						t.Errorf("transaction %d does not balance: %s %s", i, sum, currency)
+					}
+				}
+				if postings[0].amount != entries[i].Amount || postings[0].account != entries[i].Expense {
+					t.Errorf("transaction %d posts %s to %s, want %s to %s",
+						i, postings[0].amount, postings[0].account, entries[i].Amount, entries[i].Expense)
+				}
+			}
+		})
+	}
+}
+
+type ledgerPosting struct {
+	account  string
+	amount   domain.Money
+	currency string
+}
+
+// ledgerTransactions reads back the postings of every transaction of a rendered journal:
+// the lines indented by indent that hold an account, an amount and a currency.
+func ledgerTransactions(t *testing.T, journal, indent string) [][]ledgerPosting {
+	t.Helper()
+	var transactions [][]ledgerPosting
+	var current []ledgerPosting
+	for _, line := range strings.Split(journal, "\n") {
+		if line == "" {
+			if current != nil {
+				transactions = append(transactions, current)
+				current = nil
+			}
+			continue
+		}
+		if !strings.HasPrefix(line, indent) {
+			continue
+		}
+		fields := strings.Fields(line)
+		if len(fields) != 3 || !strings.Contains(fields[0], ":") {
+			continue
+		}
+		amount, err := domain.ParseMoney(fields[1])
+		if err != nil {
+			t.Fatalf("invalid amount in %q: %v", line, err)
+		}
+		current = append(current, ledgerPosting{account: fields[0], amount: amount, currency: fields[2]})
+	}
+	if current != nil {
+		transactions = append(transactions, current)
+	}
+	return transactions
+}
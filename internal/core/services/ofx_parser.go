@@ -0,0 +1,160 @@
+package services
+
+import (
+	"fmt"
+	"github.com/misalima/my-budget-planner-backend/internal/core/domain"
+	"html"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// ofxTransaction is one STMTTRN entry of an OFX file, with the account and default
+// currency of the statement it belongs to.
+type ofxTransaction struct {
+	Account  string
+	Currency string
+	Type     string
+	FITID    string
+	Posted   string
+	Amount   string
+	Name     string
+	Memo     string
+}
+
+// ExternalID identifies the entry among every file of the user: FITIDs are only
+// unique within an account.
+func (t ofxTransaction) ExternalID() string {
+	if t.Account == "" {
+		return t.FITID
+	}
+	return t.Account + ":" + t.FITID
+}
+
+// Description joins the payee name and the memo, which banks fill in differently.
+func (t ofxTransaction) Description() string {
+	switch {
+	case t.Name == "":
+		return t.Memo
+	case t.Memo == "" || t.Memo == t.Name:
+		return t.Name
+	default:
+		return t.Name + " - " + t.Memo
+	}
+}
+
+// Date reads DTPOSTED, whose first eight digits are the date in the bank's time zone.
+func (t ofxTransaction) Date() (time.Time, error) {
+	if len(t.Posted) < 8 {
+		return time.Time{}, fmt.Errorf("invalid posted date %q", t.Posted)
+	}
+	date, err := time.Parse("20060102", t.Posted[:8])
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid posted date %q", t.Posted)
+	}
+	return date, nil
+}
+
+// Money reads TRNAMT. Some Brazilian banks write it with a decimal comma.
+func (t ofxTransaction) Money() (domain.Money, error) {
+	amount := t.Amount
+	if !strings.Contains(amount, ".") {
+		amount = strings.Replace(amount, ",", ".", 1)
+	}
+	if amount == "" {
+		return 0, fmt.Errorf("missing amount")
+	}
+	money, err := domain.ParseMoney(amount)
+	if err != nil {
+		return 0, fmt.Errorf("invalid amount %q", t.Amount)
+	}
+	return money, nil
+}
+
+// parseOFX reads the statement transactions of an OFX or QFX file, in either the SGML
+// variant (OFX 1.x) or the XML one (OFX 2.x). SGML leaf elements have no closing tag,
+// so the value of an element is the text up to the next tag in both variants.
+func parseOFX(data []byte) ([]ofxTransaction, error) {
+	text := decodeOFXText(data)
+	start := strings.Index(strings.ToUpper(text), "<OFX>")
+	if start < 0 {
+		return nil, fmt.Errorf("%w: not an OFX file", domain.ErrInvalidInput)
+	}
+	text = text[start:]
+
+	var (
+		transactions      []ofxTransaction
+		current           *ofxTransaction
+		account, currency string
+	)
+	for {
+		open := strings.IndexByte(text, '<')
+		if open < 0 {
+			break
+		}
+		end := strings.IndexByte(text[open:], '>')
+		if end < 0 {
+			return nil, fmt.Errorf("%w: unterminated OFX tag", domain.ErrInvalidInput)
+		}
+		tag := strings.ToUpper(strings.TrimSpace(text[open+1 : open+end]))
+		text = text[open+end+1:]
+
+		value := text
+		if next := strings.IndexByte(text, '<'); next >= 0 {
+			value = text[:next]
+		}
+		value = strings.TrimSpace(html.UnescapeString(value))
+
+		switch {
+		case tag == "STMTTRN":
+			current = &ofxTransaction{Account: account, Currency: currency}
+		case tag == "/STMTTRN":
+			if current != nil {
+				transactions = append(transactions, *current)
+				current = nil
+			}
+		case strings.HasPrefix(tag, "/") || value == "":
+		case current == nil:
+			// ACCTID also shows up inside transfers; only the statement's own one counts.
+			switch tag {
+			case "ACCTID":
+				account = value
+			case "CURDEF":
+				currency = value
+			}
+		default:
+			switch tag {
+			case "TRNTYPE":
+				current.Type = value
+			case "FITID":
+				current.FITID = value
+			case "DTPOSTED":
+				current.Posted = value
+			case "TRNAMT":
+				current.Amount = value
+			case "NAME":
+				current.Name = value
+			case "MEMO":
+				current.Memo = value
+			}
+		}
+	}
+
+	if current != nil {
+		return nil, fmt.Errorf("%w: unterminated STMTTRN", domain.ErrInvalidInput)
+	}
+	return transactions, nil
+}
+
+// decodeOFXText returns the file as UTF-8. Files that are not valid UTF-8 are read as
+// Latin-1, the CHARSET:1252 most SGML files declare.
+func decodeOFXText(data []byte) string {
+	if utf8.Valid(data) {
+		return string(data)
+	}
+	runes := make([]rune, len(data))
+	for i, b := range data {
+		runes[i] = rune(b)
+	}
+	return string(runes)
+}
@@ -0,0 +1,183 @@
+package services
+
+import (
+	"errors"
+	"github.com/misalima/my-budget-planner-backend/internal/core/domain"
+	"reflect"
+	"testing"
+)
+
+const sgmlOFX = `OFXHEADER:100
+DATA:OFXSGML
+VERSION:102
+CHARSET:1252
+
+<OFX>
+<BANKMSGSRSV1><STMTTRNRS><STMTRS>
+<CURDEF>BRL
+<BANKACCTFROM>
+<BANKID>0341
+<ACCTID>12345-6
+</BANKACCTFROM>
+<BANKTRANLIST>
+<STMTTRN>
+<TRNTYPE>DEBIT
+<DTPOSTED>20250304120000[-3:BRT]
+<TRNAMT>-45,90
+<FITID>202503040001
+<NAME>PADARIA &amp; CAFE
+<MEMO>COMPRA CARTAO
+</STMTTRN>
+<STMTTRN>
+<TRNTYPE>CREDIT
+<DTPOSTED>20250305
+<TRNAMT>3000.00
+<FITID>202503050001
+<MEMO>SALARIO
+</STMTTRN>
+</BANKTRANLIST>
+</STMTRS></STMTTRNRS></BANKMSGSRSV1>
+</OFX>
+`
+
+const xmlOFX = `<?xml version="1.0" encoding="UTF-8"?>
+<?OFX OFXHEADER="200" VERSION="220"?>
+<ofx>
+  <BANKMSGSRSV1><STMTTRNRS><STMTRS>
+    <CURDEF>USD</CURDEF>
+    <BANKACCTFROM><ACCTID>987</ACCTID></BANKACCTFROM>
+    <BANKTRANLIST>
+      <STMTTRN>
+        <TRNTYPE>XFER</TRNTYPE>
+        <DTPOSTED>20250110</DTPOSTED>
+        <TRNAMT>-12.5</TRNAMT>
+        <FITID>T1</FITID>
+        <NAME>RENT</NAME>
+        <MEMO>RENT</MEMO>
+        <BANKACCTTO><ACCTID>555</ACCTID></BANKACCTTO>
+      </STMTTRN>
+    </BANKTRANLIST>
+  </STMTRS></STMTTRNRS></BANKMSGSRSV1>
+</ofx>
+`
+
+func TestParseOFX(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    []byte
+		want    []ofxTransaction
+		wantErr bool
+	}{
+		{
+			name: "SGML without closing tags",
+			data: []byte(sgmlOFX),
+			want: []ofxTransaction{
+				{Account: "12345-6", Currency: "BRL", Type: "DEBIT", FITID: "202503040001", Posted: "20250304120000[-3:BRT]", Amount: "-45,90", Name: "PADARIA & CAFE", Memo: "COMPRA CARTAO"},
+				{Account: "12345-6", Currency: "BRL", Type: "CREDIT", FITID: "202503050001", Posted: "20250305", Amount: "3000.00", Memo: "SALARIO"},
+			},
+		},
+		{
+			name: "XML with lower-case root and a transfer account",
+			data: []byte(xmlOFX),
+			want: []ofxTransaction{
+				{Account: "987", Currency: "USD", Type: "XFER", FITID: "T1", Posted: "20250110", Amount: "-12.5", Name: "RENT", Memo: "RENT"},
+			},
+		},
+		{
+			name: "Latin-1 text",
+			data: []byte("<OFX><STMTTRN><FITID>1<NAME>A\xc7OUGUE</STMTTRN></OFX>"),
+			want: []ofxTransaction{{FITID: "1", Name: "AÇOUGUE"}},
+		},
+		{
+			name: "no transactions",
+			data: []byte("<OFX><BANKTRANLIST></BANKTRANLIST></OFX>"),
+		},
+		{name: "not an OFX file", data: []byte("date,amount\n2025-01-01,10"), wantErr: true},
+		{name: "unterminated tag", data: []byte("<OFX><STMTTRN><FITID"), wantErr: true},
+		{name: "unterminated transaction", data: []byte("<OFX><STMTTRN><FITID>1</OFX>"), wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseOFX(tt.data)
+			if tt.wantErr {
+				if !errors.Is(err, domain.ErrInvalidInput) {
+					t.Fatalf("got error %v, want ErrInvalidInput", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestOFXTransactionFields(t *testing.T) {
+	tests := []struct {
+		name            string
+		transaction     ofxTransaction
+		wantExternalID  string
+		wantDescription string
+		wantDate        string
+		wantAmount      domain.Money
+		wantErr         bool
+	}{
+		{
+			name:            "decimal comma and name with memo",
+			transaction:     ofxTransaction{Account: "1", FITID: "A", Posted: "20250304120000[-3:BRT]", Amount: "-45,90", Name: "PADARIA", Memo: "COMPRA"},
+			wantExternalID:  "1:A",
+			wantDescription: "PADARIA - COMPRA",
+			wantDate:        "2025-03-04",
+			wantAmount:      -4590,
+		},
+		{
+			name:            "no account and memo only",
+			transaction:     ofxTransaction{FITID: "B", Posted: "20250305", Amount: "3000.00", Memo: "SALARIO"},
+			wantExternalID:  "B",
+			wantDescription: "SALARIO",
+			wantDate:        "2025-03-05",
+			wantAmount:      300000,
+		},
+		{
+			name:            "memo repeating the name",
+			transaction:     ofxTransaction{FITID: "C", Posted: "20250306", Amount: "1.5", Name: "RENT", Memo: "RENT"},
+			wantExternalID:  "C",
+			wantDescription: "RENT",
+			wantDate:        "2025-03-06",
+			wantAmount:      150,
+		},
+		{name: "short date", transaction: ofxTransaction{Posted: "202503", Amount: "1.00"}, wantErr: true},
+		{name: "missing amount", transaction: ofxTransaction{Posted: "20250306"}, wantErr: true},
+		{name: "invalid amount", transaction: ofxTransaction{Posted: "20250306", Amount: "1.234,56"}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			date, dateErr := tt.transaction.Date()
+			amount, amountErr := tt.transaction.Money()
+			if tt.wantErr {
+				if dateErr == nil && amountErr == nil {
+					t.Fatalf("got date %v and amount %v, want an error", date, amount)
+				}
+				return
+			}
+			if dateErr != nil || amountErr != nil {
+				t.Fatalf("unexpected errors: %v, %v", dateErr, amountErr)
+			}
+			if got := date.Format("2006-01-02"); got != tt.wantDate {
+				t.Errorf("got date %s, want %s", got, tt.wantDate)
+			}
+			if amount != tt.wantAmount {
+				t.Errorf("got amount %v, want %v", amount, tt.wantAmount)
+			}
+			if got := tt.transaction.ExternalID(); got != tt.wantExternalID {
+				t.Errorf("got external id %q, want %q", got, tt.wantExternalID)
+			}
+			if got := tt.transaction.Description(); got != tt.wantDescription {
+				t.Errorf("got description %q, want %q", got, tt.wantDescription)
+			}
+		})
+	}
+}
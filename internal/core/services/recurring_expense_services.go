@@ -2,6 +2,7 @@ package services
 
 import (
 	"context"
+	"fmt"
 	"github.com/google/uuid"
 	"github.com/misalima/my-budget-planner-backend/internal/core/domain"
 	"github.com/misalima/my-budget-planner-backend/internal/core/interfaces/irepository"
@@ -9,19 +10,61 @@ import (
 )
 
 type RecurringExpenseService struct {
-	repo irepository.RecurringExpenseLoader
+	repo             irepository.RecurringExpenseLoader
+	userRepo         irepository.UserLoader
+	exchangeRateRepo irepository.ExchangeRateLoader
 }
 
-func NewRecurringExpenseService(repo irepository.RecurringExpenseLoader) *RecurringExpenseService {
-	return &RecurringExpenseService{repo: repo}
+func NewRecurringExpenseService(repo irepository.RecurringExpenseLoader, userRepo irepository.UserLoader, exchangeRateRepo irepository.ExchangeRateLoader) *RecurringExpenseService {
+	return &RecurringExpenseService{repo: repo, userRepo: userRepo, exchangeRateRepo: exchangeRateRepo}
 }
 
 func (s *RecurringExpenseService) CreateRecurringExpense(ctx context.Context, expense domain.RecurringExpense) (domain.RecurringExpense, error) {
+	if !domain.IsValidFrequency(expense.Frequency) {
+		return domain.RecurringExpense{}, fmt.Errorf("%w: unknown frequency %q", domain.ErrInvalidInput, expense.Frequency)
+	}
+	currency, err := currencyOrBase(ctx, s.userRepo, expense.UserID, expense.Currency)
+	if err != nil {
+		return domain.RecurringExpense{}, err
+	}
+	expense.Currency = currency
 	return s.repo.InsertRecurringExpense(ctx, expense)
 }
 
 func (s *RecurringExpenseService) UpdateRecurringExpense(ctx context.Context, expense domain.RecurringExpense) (domain.RecurringExpense, error) {
-	return s.repo.UpdateRecurringExpense(ctx, expense)
+	if expense.Frequency != "" && !domain.IsValidFrequency(expense.Frequency) {
+		return domain.RecurringExpense{}, fmt.Errorf("%w: unknown frequency %q", domain.ErrInvalidInput, expense.Frequency)
+	}
+	if expense.Currency != "" {
+		currency, err := currencyOrBase(ctx, s.userRepo, expense.UserID, expense.Currency)
+		if err != nil {
+			return domain.RecurringExpense{}, err
+		}
+		expense.Currency = currency
+	}
+	updated, err := s.repo.UpdateRecurringExpense(ctx, expense)
+	if err != nil {
+		return domain.RecurringExpense{}, err
+	}
+	if updated.IsTemplate() {
+		if err := s.regenerateOccurrences(ctx, updated); err != nil {
+			return domain.RecurringExpense{}, err
+		}
+	}
+	return updated, nil
+}
+
+// regenerateOccurrences applies an edited template to the occurrences already generated
+// from today on: they are replaced, in a single transaction, by the ones the template now
+// has up to the day it was generated through. Past occurrences are kept as they happened.
+func (s *RecurringExpenseService) regenerateOccurrences(ctx context.Context, template domain.RecurringExpense) error {
+	now := time.Now().UTC()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	if template.GeneratedThrough == nil || template.GeneratedThrough.Before(today) {
+		return nil
+	}
+	occurrences := generateOccurrences(template, today, *template.GeneratedThrough)
+	return s.repo.ReplaceGeneratedRecurringExpensesFrom(ctx, template.ID, today, occurrences)
 }
 
 func (s *RecurringExpenseService) DeleteRecurringExpense(ctx context.Context, id uuid.UUID, userID uuid.UUID) error {
@@ -50,27 +93,77 @@ func (s *RecurringExpenseService) ListRecurringExpenses(ctx context.Context, use
 	return s.repo.FindRecurringExpenses(ctx, userID, filters)
 }
 
-func (s *RecurringExpenseService) GenerateRecurringExpenses(ctx context.Context, userID uuid.UUID, targetDate time.Time) error {
-	// Lógica de geração pode ser implementada conforme a regra de negócio
-	return nil
+// GenerateRecurringExpenses materializes the occurrences of the user's recurring expenses
+// up to targetDate. Each template only generates the days after the one it was last
+// generated through, so occurrences the user deleted or moved are not generated again and
+// the call can be repeated safely. It returns how many new occurrences were stored.
+func (s *RecurringExpenseService) GenerateRecurringExpenses(ctx context.Context, userID uuid.UUID, targetDate time.Time) (int, error) {
+	expenses, err := s.repo.FindRecurringExpensesByUser(ctx, userID)
+	if err != nil {
+		return 0, err
+	}
+	total := 0
+	for _, template := range expenses {
+		if !template.IsTemplate() {
+			continue
+		}
+		from := template.StartDate
+		if template.GeneratedThrough != nil {
+			if !targetDate.After(*template.GeneratedThrough) {
+				continue
+			}
+			from = template.GeneratedThrough.AddDate(0, 0, 1)
+		}
+		inserted, err := s.repo.StoreGeneratedRecurringExpenses(ctx, template.ID, generateOccurrences(template, from, targetDate), targetDate)
+		if err != nil {
+			return total, err
+		}
+		total += inserted
+	}
+	return total, nil
+}
+
+// generateOccurrences builds the occurrences of the template that fall inside [from, to].
+func generateOccurrences(template domain.RecurringExpense, from, to time.Time) []domain.RecurringExpense {
+	var occurrences []domain.RecurringExpense
+	for _, date := range template.OccurrencesBetween(from, to) {
+		occurrence := template
+		occurrence.ID = uuid.Nil
+		occurrence.Date = date
+		occurrence.TemplateID = &template.ID
+		occurrence.GeneratedThrough = nil
+		occurrences = append(occurrences, occurrence)
+	}
+	return occurrences
 }
 
+// GetRecurringExpenseSummary totals the recurring expenses of the range in the user's
+// base currency, converting each one at the rate of its date.
 func (s *RecurringExpenseService) GetRecurringExpenseSummary(ctx context.Context, userID uuid.UUID, startDate, endDate time.Time) (domain.RecurringExpenseSummary, error) {
 	expenses, err := s.repo.FindRecurringExpensesByDateRange(ctx, userID, startDate, endDate)
 	if err != nil {
 		return domain.RecurringExpenseSummary{}, err
 	}
+	converter, err := newBaseCurrencyConverter(ctx, s.userRepo, s.exchangeRateRepo, userID)
+	if err != nil {
+		return domain.RecurringExpenseSummary{}, err
+	}
 	var summary domain.RecurringExpenseSummary
-	summary.ByFrequency = make(map[string]float64)
-	summary.ByCategory = make(map[int]float64)
+	summary.Currency = converter.target
+	summary.ByFrequency = make(map[string]domain.Money)
+	summary.ByCategory = make(map[int]domain.Money)
 	for _, e := range expenses {
-		summary.TotalAmount += e.Amount
+		amount, err := converter.convert(ctx, e.Amount, e.Currency, e.Date)
+		if err != nil {
+			return domain.RecurringExpenseSummary{}, err
+		}
+		summary.TotalAmount += amount
 		summary.TotalCount++
-		summary.ByFrequency[e.Frequency] += e.Amount
-		summary.ByCategory[e.CategoryID] += e.Amount
+		summary.ByFrequency[e.Frequency] += amount
+		summary.ByCategory[e.CategoryID] += amount
 	}
 	if summary.TotalCount > 0 {
-		summary.AverageAmount = summary.TotalAmount / float64(summary.TotalCount)
+		summary.AverageAmount = summary.TotalAmount.DivideBy(summary.TotalCount)
 	}
 	return summary, nil
 }
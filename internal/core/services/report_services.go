@@ -0,0 +1,209 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/misalima/my-budget-planner-backend/internal/core/domain"
+	"github.com/misalima/my-budget-planner-backend/internal/core/interfaces/irepository"
+	"sort"
+	"time"
+)
+
+const topExpensesInReport = 10
+
+type ReportService struct {
+	userRepo              irepository.UserLoader
+	categoryRepo          irepository.CategoryLoader
+	simpleExpenseRepo     irepository.SimpleExpenseLoader
+	recurringExpenseRepo  irepository.RecurringExpenseLoader
+	creditCardExpenseRepo irepository.CreditCardExpenseLoader
+	incomeRepo            irepository.IncomeLoader
+	exchangeRateRepo      irepository.ExchangeRateLoader
+}
+
+func NewReportService(
+	userRepo irepository.UserLoader,
+	categoryRepo irepository.CategoryLoader,
+	simpleExpenseRepo irepository.SimpleExpenseLoader,
+	recurringExpenseRepo irepository.RecurringExpenseLoader,
+	creditCardExpenseRepo irepository.CreditCardExpenseLoader,
+	incomeRepo irepository.IncomeLoader,
+	exchangeRateRepo irepository.ExchangeRateLoader,
+) *ReportService {
+	return &ReportService{
+		userRepo:              userRepo,
+		categoryRepo:          categoryRepo,
+		simpleExpenseRepo:     simpleExpenseRepo,
+		recurringExpenseRepo:  recurringExpenseRepo,
+		creditCardExpenseRepo: creditCardExpenseRepo,
+		incomeRepo:            incomeRepo,
+		exchangeRateRepo:      exchangeRateRepo,
+	}
+}
+
+// GetMonthlyReport builds the report of the given calendar month. Expenses are counted
+// the same way budgets consume them: recurring expenses count through their stored
+// occurrences and card purchases count by installment. Every amount is converted into the user's base currency
+// at the rate of its own date.
+func (s *ReportService) GetMonthlyReport(ctx context.Context, userID uuid.UUID, year int, month time.Month) (domain.MonthlyReport, error) {
+	user, err := s.userRepo.GetUserByID(ctx, userID)
+	if err != nil {
+		return domain.MonthlyReport{}, err
+	}
+	categories, err := s.categoryRepo.GetCategoryByUserID(ctx, userID)
+	if err != nil {
+		return domain.MonthlyReport{}, err
+	}
+	categoryNames := make(map[int]string, len(categories))
+	for _, c := range categories {
+		categoryNames[c.ID] = c.Name
+	}
+
+	start := time.Date(year, month, 1, 0, 0, 0, 0, time.UTC)
+	end := start.AddDate(0, 1, -1)
+	previousStart := start.AddDate(0, -1, 0)
+	previousEnd := start.AddDate(0, 0, -1)
+
+	current, err := s.monthExpenses(ctx, userID, start, end)
+	if err != nil {
+		return domain.MonthlyReport{}, err
+	}
+	previous, err := s.monthExpenses(ctx, userID, previousStart, previousEnd)
+	if err != nil {
+		return domain.MonthlyReport{}, err
+	}
+	incomes, err := s.incomeRepo.FindIncomesByDateRange(ctx, userID, start, end)
+	if err != nil {
+		return domain.MonthlyReport{}, err
+	}
+	converter := newCurrencyConverter(s.exchangeRateRepo, user.BaseCurrency)
+	if err := convertTransactions(ctx, converter, current); err != nil {
+		return domain.MonthlyReport{}, err
+	}
+	if err := convertTransactions(ctx, converter, previous); err != nil {
+		return domain.MonthlyReport{}, err
+	}
+
+	report := domain.MonthlyReport{
+		Month:            start.Format("2006-01"),
+		StartDate:        start,
+		EndDate:          end,
+		Currency:         converter.target,
+		ExpenditureLimit: user.ExpenditureLimit,
+		ByCategory:       []domain.CategoryTotal{},
+		TopExpenses:      []domain.ReportedExpense{},
+	}
+
+	byCategory := make(map[int]*domain.CategoryTotal)
+	categoryTotal := func(id int) *domain.CategoryTotal {
+		if total, ok := byCategory[id]; ok {
+			return total
+		}
+		total := &domain.CategoryTotal{CategoryID: id, CategoryName: categoryNames[id]}
+		byCategory[id] = total
+		return total
+	}
+	for _, e := range current {
+		switch e.Type {
+		case domain.TransactionTypeSimpleExpense:
+			report.SimpleExpensesTotal += e.Amount
+		case domain.TransactionTypeRecurringExpense:
+			report.RecurringExpensesTotal += e.Amount
+		case domain.TransactionTypeCreditCardExpense:
+			report.CreditCardExpensesTotal += e.Amount
+		}
+		report.TotalExpenses += e.Amount
+		categoryTotal(e.CategoryID).Amount += e.Amount
+	}
+	for _, e := range previous {
+		report.PreviousMonth.TotalExpenses += e.Amount
+		categoryTotal(e.CategoryID).PreviousAmount += e.Amount
+	}
+	for _, income := range incomes {
+		for _, date := range income.OccurrencesBetween(start, end) {
+			amount, err := converter.convert(ctx, income.Amount, income.Currency, date)
+			if err != nil {
+				return domain.MonthlyReport{}, err
+			}
+			report.IncomeTotal += amount
+		}
+	}
+	report.NetCashFlow = report.IncomeTotal - report.TotalExpenses
+
+	if user.ExpenditureLimit > 0 {
+		used := report.TotalExpenses.Float64() / user.ExpenditureLimit.Float64() * 100
+		report.LimitUsedPercentage = &used
+	}
+
+	report.PreviousMonth.Month = previousStart.Format("2006-01")
+	report.PreviousMonth.Difference = report.TotalExpenses - report.PreviousMonth.TotalExpenses
+	if report.PreviousMonth.TotalExpenses > 0 {
+		change := report.PreviousMonth.Difference.Float64() / report.PreviousMonth.TotalExpenses.Float64() * 100
+		report.PreviousMonth.PercentageChange = &change
+	}
+
+	for _, total := range byCategory {
+		total.Difference = total.Amount - total.PreviousAmount
+		if report.TotalExpenses > 0 {
+			total.Percentage = total.Amount.Float64() / report.TotalExpenses.Float64() * 100
+		}
+		report.ByCategory = append(report.ByCategory, *total)
+	}
+	sort.Slice(report.ByCategory, func(i, j int) bool {
+		a, b := report.ByCategory[i], report.ByCategory[j]
+		if a.Amount != b.Amount {
+			return a.Amount > b.Amount
+		}
+		return a.CategoryID < b.CategoryID
+	})
+
+	sortTransactions(current, domain.TransactionSortAmount, false)
+	for i := 0; i < len(current) && i < topExpensesInReport; i++ {
+		report.TopExpenses = append(report.TopExpenses, domain.ReportedExpense{
+			Transaction:  current[i],
+			CategoryName: categoryNames[current[i].CategoryID],
+		})
+	}
+	return report, nil
+}
+
+// monthExpenses lists every expense falling due inside [start, end] as ledger entries,
+// the same ones the transaction ledger lists: recurring expenses count through their
+// stored occurrences, as the user left them.
+func (s *ReportService) monthExpenses(ctx context.Context, userID uuid.UUID, start, end time.Time) ([]domain.Transaction, error) {
+	simpleExpenses, err := s.simpleExpenseRepo.FindSimpleExpensesByDateRange(ctx, userID, start, end)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load simple expenses for report: %w", err)
+	}
+	recurringExpenses, err := s.recurringExpenseRepo.FindGeneratedRecurringExpensesByDateRange(ctx, userID, start, end)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load recurring expenses for report: %w", err)
+	}
+	creditCardExpenses, err := s.creditCardExpenseRepo.FindCreditCardExpensesByDateRange(ctx, userID, start, end)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load credit card expenses for report: %w", err)
+	}
+
+	expenses := []domain.Transaction{}
+	for _, e := range simpleExpenses {
+		expenses = append(expenses, domain.Transaction{
+			ID: e.ID, OccurrenceID: e.ID.String(), Type: domain.TransactionTypeSimpleExpense, CategoryID: e.CategoryID, Amount: e.Amount, Currency: e.Currency,
+			Description: e.Description, Date: e.Date, CreatedAt: e.CreatedAt,
+		})
+	}
+	for _, e := range recurringExpenses {
+		expenses = append(expenses, domain.Transaction{
+			ID: e.ID, OccurrenceID: e.ID.String(), Type: domain.TransactionTypeRecurringExpense, CategoryID: e.CategoryID, Amount: e.Amount, Currency: e.Currency,
+			Description: e.Description, Date: e.Date, CardID: e.CardID, CreatedAt: e.CreatedAt,
+		})
+	}
+	for _, e := range creditCardExpenses {
+		expenses = append(expenses, domain.Transaction{
+			ID: e.ID, OccurrenceID: e.ID.String(), Type: domain.TransactionTypeCreditCardExpense, CategoryID: e.CategoryID, Amount: e.InstallmentValue(), Currency: e.Currency,
+			Description: e.Description, Date: e.Date, CardID: &e.CardID,
+			ParcelNumber: &e.ParcelNumber, InstallmentsQuantity: &e.InstallmentsQuantity, CreatedAt: e.CreatedAt,
+		})
+	}
+	return expenses, nil
+}
@@ -9,18 +9,32 @@ import (
 )
 
 type SimpleExpenseService struct {
-	repo irepository.SimpleExpenseLoader
+	repo             irepository.SimpleExpenseLoader
+	userRepo         irepository.UserLoader
+	exchangeRateRepo irepository.ExchangeRateLoader
 }
 
-func NewSimpleExpenseService(repo irepository.SimpleExpenseLoader) *SimpleExpenseService {
-	return &SimpleExpenseService{repo: repo}
+func NewSimpleExpenseService(repo irepository.SimpleExpenseLoader, userRepo irepository.UserLoader, exchangeRateRepo irepository.ExchangeRateLoader) *SimpleExpenseService {
+	return &SimpleExpenseService{repo: repo, userRepo: userRepo, exchangeRateRepo: exchangeRateRepo}
 }
 
 func (s *SimpleExpenseService) CreateSimpleExpense(ctx context.Context, expense domain.SimpleExpense) (domain.SimpleExpense, error) {
+	currency, err := currencyOrBase(ctx, s.userRepo, expense.UserID, expense.Currency)
+	if err != nil {
+		return domain.SimpleExpense{}, err
+	}
+	expense.Currency = currency
 	return s.repo.InsertSimpleExpense(ctx, expense)
 }
 
 func (s *SimpleExpenseService) UpdateSimpleExpense(ctx context.Context, expense domain.SimpleExpense) (domain.SimpleExpense, error) {
+	if expense.Currency != "" {
+		currency, err := currencyOrBase(ctx, s.userRepo, expense.UserID, expense.Currency)
+		if err != nil {
+			return domain.SimpleExpense{}, err
+		}
+		expense.Currency = currency
+	}
 	return s.repo.UpdateSimpleExpense(ctx, expense)
 }
 
@@ -50,20 +64,31 @@ func (s *SimpleExpenseService) ListSimpleExpenses(ctx context.Context, userID uu
 	return s.repo.FindSimpleExpenses(ctx, userID, filters)
 }
 
+// GetSimpleExpenseSummary totals the expenses of the range in the user's base currency,
+// converting each one at the rate of its date.
 func (s *SimpleExpenseService) GetSimpleExpenseSummary(ctx context.Context, userID uuid.UUID, startDate, endDate time.Time) (domain.SimpleExpenseSummary, error) {
 	expenses, err := s.repo.FindSimpleExpensesByDateRange(ctx, userID, startDate, endDate)
 	if err != nil {
 		return domain.SimpleExpenseSummary{}, err
 	}
+	converter, err := newBaseCurrencyConverter(ctx, s.userRepo, s.exchangeRateRepo, userID)
+	if err != nil {
+		return domain.SimpleExpenseSummary{}, err
+	}
 	var summary domain.SimpleExpenseSummary
-	summary.ByCategory = make(map[int]float64)
+	summary.Currency = converter.target
+	summary.ByCategory = make(map[int]domain.Money)
 	for _, e := range expenses {
-		summary.TotalAmount += e.Amount
+		amount, err := converter.convert(ctx, e.Amount, e.Currency, e.Date)
+		if err != nil {
+			return domain.SimpleExpenseSummary{}, err
+		}
+		summary.TotalAmount += amount
 		summary.TotalCount++
-		summary.ByCategory[e.CategoryID] += e.Amount
+		summary.ByCategory[e.CategoryID] += amount
 	}
 	if summary.TotalCount > 0 {
-		summary.AverageAmount = summary.TotalAmount / float64(summary.TotalCount)
+		summary.AverageAmount = summary.TotalAmount.DivideBy(summary.TotalCount)
 	}
 	return summary, nil
 }
@@ -0,0 +1,248 @@
+package services
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/sha256"
+	"encoding/base32"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/misalima/my-budget-planner-backend/internal/api/http/auth"
+	"github.com/misalima/my-budget-planner-backend/internal/core/domain"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	// TOTP parameters, the defaults of RFC 6238 that every authenticator app supports.
+	totpDigits     = 6
+	totpPeriod     = 30 // seconds
+	totpSecretSize = 20 // bytes, the size of an HMAC-SHA1 key
+	// totpSkew is how many steps before and after the current one are accepted, to
+	// tolerate clock drift and codes typed as they change.
+	totpSkew   = 1
+	totpIssuer = "My Budget Planner"
+
+	recoveryCodeCount = 10
+	// maxTOTPAttempts wrong codes in a row lock two-factor login for totpLockDuration.
+	maxTOTPAttempts  = 5
+	totpLockDuration = 15 * time.Minute
+)
+
+var totpEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// StartTOTPEnrollment generates a new TOTP secret for the user. Two-factor authentication
+// stays off until ConfirmTOTP receives a code generated from it, and starting again
+// replaces the secret of an enrollment that was not confirmed.
+func (s *AuthService) StartTOTPEnrollment(ctx context.Context, userId uuid.UUID) (domain.TOTPEnrollment, error) {
+	user, err := s.userRepo.GetUserByID(ctx, userId)
+	if err != nil {
+		return domain.TOTPEnrollment{}, err
+	}
+
+	secret := make([]byte, totpSecretSize)
+	if _, err := rand.Read(secret); err != nil {
+		return domain.TOTPEnrollment{}, fmt.Errorf("failed to generate two-factor secret: %w", err)
+	}
+	totp := domain.TOTP{
+		UserID:    userId,
+		Secret:    totpEncoding.EncodeToString(secret),
+		CreatedAt: time.Now(),
+	}
+	if err := s.totpRepo.StoreTOTP(ctx, totp); err != nil {
+		return domain.TOTPEnrollment{}, err
+	}
+
+	return domain.TOTPEnrollment{Secret: totp.Secret, URI: totpURI(user.Email, totp.Secret)}, nil
+}
+
+// ConfirmTOTP turns two-factor authentication on once the code matches the secret of the
+// enrollment, and returns the recovery codes. Only their hashes are stored, so this is
+// the only time they are shown.
+func (s *AuthService) ConfirmTOTP(ctx context.Context, userId uuid.UUID, code string) ([]string, error) {
+	totp, err := s.totpRepo.GetTOTP(ctx, userId)
+	if errors.Is(err, domain.ErrNotFound) {
+		return nil, fmt.Errorf("%w: two-factor enrollment has not been started", domain.ErrInvalidInput)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if totp.Enabled() {
+		return nil, domain.ErrTOTPAlreadyEnabled
+	}
+
+	step, ok := matchTOTP(totp.Secret, normalizeTOTPCode(code), time.Now(), totp.LastUsedStep)
+	if !ok {
+		return nil, domain.ErrInvalidTOTPCode
+	}
+
+	codes := make([]string, recoveryCodeCount)
+	hashes := make([]string, recoveryCodeCount)
+	for i := range codes {
+		if codes[i], err = generateRecoveryCode(); err != nil {
+			return nil, err
+		}
+		hashes[i] = recoveryCodeHash(codes[i])
+	}
+	if err := s.totpRepo.ConfirmTOTP(ctx, userId, step, hashes, time.Now()); err != nil {
+		return nil, err
+	}
+	return codes, nil
+}
+
+// DisableTOTP turns two-factor authentication off, or cancels an enrollment that was not
+// confirmed. Once it is on, this takes a valid code or recovery code, so that a stolen
+// access token is not enough.
+func (s *AuthService) DisableTOTP(ctx context.Context, userId uuid.UUID, code string) error {
+	totp, err := s.totpRepo.GetTOTP(ctx, userId)
+	if err != nil {
+		return err
+	}
+	if totp.Enabled() {
+		if err := s.verifySecondFactor(ctx, totp, code); err != nil {
+			return err
+		}
+	}
+	return s.totpRepo.DeleteTOTP(ctx, userId)
+}
+
+// CompleteMFALogin exchanges the MFA token returned by Login, together with a TOTP code
+// or an unused recovery code, for the token pair.
+func (s *AuthService) CompleteMFALogin(mfaToken, code string, client domain.SessionClient) (domain.LoginResult, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	userId, tokenID, expiresAt, err := auth.ParseMFAToken(mfaToken)
+	if err != nil {
+		return domain.LoginResult{}, fmt.Errorf("invalid mfa token")
+	}
+
+	totp, err := s.totpRepo.GetTOTP(ctx, userId)
+	if errors.Is(err, domain.ErrNotFound) || (err == nil && !totp.Enabled()) {
+		return domain.LoginResult{}, fmt.Errorf("two-factor authentication is not enabled")
+	}
+	if err != nil {
+		return domain.LoginResult{}, err
+	}
+	if err := s.verifySecondFactor(ctx, totp, code); err != nil {
+		return domain.LoginResult{}, err
+	}
+	// The token completes a single login, even though it is valid for a few more minutes.
+	if err := s.totpRepo.UseMFAToken(ctx, userId, tokenID, expiresAt); err != nil {
+		if errors.Is(err, domain.ErrMFATokenUsed) {
+			return domain.LoginResult{}, fmt.Errorf("invalid mfa token")
+		}
+		return domain.LoginResult{}, err
+	}
+
+	return s.issueTokens(userId, client)
+}
+
+// verifySecondFactor accepts a TOTP code that was not used yet or an unused recovery
+// code, which is then used up. Wrong codes count towards locking the factor, and the
+// one that locks it is answered with ErrTOTPLocked. The lock is checked here to spare
+// a lookup, but it is the repository that enforces it.
+func (s *AuthService) verifySecondFactor(ctx context.Context, totp domain.TOTP, code string) error {
+	now := time.Now()
+	if totp.LockedUntil != nil && now.Before(*totp.LockedUntil) {
+		return domain.ErrTOTPLocked
+	}
+
+	code = normalizeTOTPCode(code)
+	err := domain.ErrInvalidTOTPCode
+	if len(code) == totpDigits {
+		if step, ok := matchTOTP(totp.Secret, code, now, totp.LastUsedStep); ok {
+			err = s.totpRepo.UseTOTPStep(ctx, totp.UserID, step)
+		}
+	} else if code != "" {
+		err = s.totpRepo.UseRecoveryCode(ctx, totp.UserID, recoveryCodeHash(code), now)
+	}
+
+	if errors.Is(err, domain.ErrInvalidTOTPCode) {
+		locked, recordErr := s.totpRepo.RecordTOTPFailure(ctx, totp.UserID, maxTOTPAttempts, now.Add(totpLockDuration))
+		if recordErr != nil {
+			return recordErr
+		}
+		if locked {
+			return domain.ErrTOTPLocked
+		}
+	}
+	return err
+}
+
+// matchTOTP looks for the step, around the one of now, whose code is the given one. Steps
+// up to lastUsedStep are skipped, as their codes were already used.
+func matchTOTP(secret, code string, now time.Time, lastUsedStep int64) (int64, bool) {
+	key, err := totpEncoding.DecodeString(secret)
+	if err != nil {
+		return 0, false
+	}
+	current := now.Unix() / totpPeriod
+	for step := current - totpSkew; step <= current+totpSkew; step++ {
+		if step <= lastUsedStep {
+			continue
+		}
+		if hmac.Equal([]byte(totpCode(key, step)), []byte(code)) {
+			return step, true
+		}
+	}
+	return 0, false
+}
+
+// totpCode computes the code of a time step as RFC 4226 and RFC 6238 define it.
+func totpCode(key []byte, step int64) string {
+	var counter [8]byte
+	binary.BigEndian.PutUint64(counter[:], uint64(step))
+	mac := hmac.New(sha1.New, key)
+	mac.Write(counter[:])
+	sum := mac.Sum(nil)
+
+	offset := sum[len(sum)-1] & 0x0f
+	value := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+	modulo := uint32(1)
+	for i := 0; i < totpDigits; i++ {
+		modulo *= 10
+	}
+	return fmt.Sprintf("%0*d", totpDigits, value%modulo)
+}
+
+// totpURI is the otpauth URI authenticator apps read from a QR code.
+func totpURI(email, secret string) string {
+	return fmt.Sprintf("otpauth://totp/%s:%s?secret=%s&issuer=%s&algorithm=SHA1&digits=%d&period=%d",
+		otpauthEscape(totpIssuer), otpauthEscape(email), secret, otpauthEscape(totpIssuer), totpDigits, totpPeriod)
+}
+
+// otpauthEscape percent-encodes a label or parameter of an otpauth URI. Spaces become %20
+// rather than "+", which some apps keep as is, and a "+" of an email address is escaped.
+func otpauthEscape(value string) string {
+	return strings.ReplaceAll(url.QueryEscape(value), "+", "%20")
+}
+
+// generateRecoveryCode returns a random code of ten base32 characters, shown as two
+// groups of five.
+func generateRecoveryCode() (string, error) {
+	random := make([]byte, 7)
+	if _, err := rand.Read(random); err != nil {
+		return "", fmt.Errorf("failed to generate recovery code: %w", err)
+	}
+	code := strings.ToLower(totpEncoding.EncodeToString(random))[:10]
+	return code[:5] + "-" + code[5:], nil
+}
+
+func recoveryCodeHash(code string) string {
+	sum := sha256.Sum256([]byte(normalizeTOTPCode(code)))
+	return hex.EncodeToString(sum[:])
+}
+
+// normalizeTOTPCode drops the spaces and dashes users type in codes and lowercases
+// recovery codes.
+func normalizeTOTPCode(code string) string {
+	code = strings.NewReplacer(" ", "", "-", "").Replace(strings.TrimSpace(code))
+	return strings.ToLower(code)
+}
@@ -0,0 +1,251 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"github.com/google/uuid"
+	"github.com/misalima/my-budget-planner-backend/internal/core/domain"
+	"testing"
+	"time"
+)
+
+// fakeTOTPRepository keeps one user's factor in memory, following the same rules as the
+// user_totp and totp_recovery_codes queries.
+type fakeTOTPRepository struct {
+	totp          domain.TOTP
+	recoveryCodes map[string]bool // hash -> used
+	mfaTokens     map[string]bool // used token IDs
+}
+
+func (f *fakeTOTPRepository) locked() bool {
+	return f.totp.LockedUntil != nil && time.Now().Before(*f.totp.LockedUntil)
+}
+
+func (f *fakeTOTPRepository) StoreTOTP(ctx context.Context, totp domain.TOTP) error {
+	f.totp = totp
+	return nil
+}
+
+func (f *fakeTOTPRepository) GetTOTP(ctx context.Context, userID uuid.UUID) (domain.TOTP, error) {
+	return f.totp, nil
+}
+
+func (f *fakeTOTPRepository) ConfirmTOTP(ctx context.Context, userID uuid.UUID, step int64, recoveryCodeHashes []string, confirmedAt time.Time) error {
+	f.totp.ConfirmedAt = &confirmedAt
+	f.totp.LastUsedStep = step
+	return nil
+}
+
+func (f *fakeTOTPRepository) UseTOTPStep(ctx context.Context, userID uuid.UUID, step int64) error {
+	if f.locked() {
+		return domain.ErrTOTPLocked
+	}
+	if step <= f.totp.LastUsedStep {
+		return domain.ErrInvalidTOTPCode
+	}
+	f.totp.LastUsedStep = step
+	f.totp.FailedAttempts = 0
+	return nil
+}
+
+func (f *fakeTOTPRepository) UseRecoveryCode(ctx context.Context, userID uuid.UUID, codeHash string, usedAt time.Time) error {
+	if f.locked() {
+		return domain.ErrTOTPLocked
+	}
+	used, ok := f.recoveryCodes[codeHash]
+	if !ok || used {
+		return domain.ErrInvalidTOTPCode
+	}
+	f.recoveryCodes[codeHash] = true
+	return nil
+}
+
+func (f *fakeTOTPRepository) RecordTOTPFailure(ctx context.Context, userID uuid.UUID, maxAttempts int, lockedUntil time.Time) (bool, error) {
+	f.totp.FailedAttempts++
+	if f.totp.FailedAttempts >= maxAttempts {
+		f.totp.FailedAttempts = 0
+		f.totp.LockedUntil = &lockedUntil
+	}
+	return f.locked(), nil
+}
+
+func (f *fakeTOTPRepository) DeleteTOTP(ctx context.Context, userID uuid.UUID) error {
+	f.totp = domain.TOTP{}
+	return nil
+}
+
+func (f *fakeTOTPRepository) UseMFAToken(ctx context.Context, userID uuid.UUID, tokenID string, expiresAt time.Time) error {
+	if f.mfaTokens[tokenID] {
+		return domain.ErrMFATokenUsed
+	}
+	if f.mfaTokens == nil {
+		f.mfaTokens = make(map[string]bool)
+	}
+	f.mfaTokens[tokenID] = true
+	return nil
+}
+
+// testTOTPSecret is the key of the RFC 6238 test vectors, base32 encoded.
+var testTOTPSecret = totpEncoding.EncodeToString([]byte("12345678901234567890"))
+
+func TestTOTPCode(t *testing.T) {
+	// RFC 6238 appendix B, SHA-1, truncated to six digits.
+	tests := []struct {
+		unix int64
+		want string
+	}{
+		{unix: 59, want: "287082"},
+		{unix: 1111111109, want: "081804"},
+		{unix: 1111111111, want: "050471"},
+		{unix: 1234567890, want: "005924"},
+		{unix: 2000000000, want: "279037"},
+		{unix: 20000000000, want: "353130"},
+	}
+	key := []byte("12345678901234567890")
+	for _, tt := range tests {
+		if got := totpCode(key, tt.unix/totpPeriod); got != tt.want {
+			t.Errorf("totpCode at %d = %s, want %s", tt.unix, got, tt.want)
+		}
+	}
+}
+
+func TestMatchTOTP(t *testing.T) {
+	key := []byte("12345678901234567890")
+	now := time.Unix(1234567890, 0)
+	current := now.Unix() / totpPeriod
+	tests := []struct {
+		name         string
+		secret       string
+		code         string
+		lastUsedStep int64
+		wantStep     int64
+		wantOK       bool
+	}{
+		{name: "current step", secret: testTOTPSecret, code: totpCode(key, current), wantStep: current, wantOK: true},
+		{name: "previous step within skew", secret: testTOTPSecret, code: totpCode(key, current-1), wantStep: current - 1, wantOK: true},
+		{name: "next step within skew", secret: testTOTPSecret, code: totpCode(key, current+1), wantStep: current + 1, wantOK: true},
+		{name: "two steps behind", secret: testTOTPSecret, code: totpCode(key, current-2)},
+		{name: "two steps ahead", secret: testTOTPSecret, code: totpCode(key, current+2)},
+		{name: "replayed step", secret: testTOTPSecret, code: totpCode(key, current), lastUsedStep: current},
+		{name: "step before the last used one", secret: testTOTPSecret, code: totpCode(key, current-1), lastUsedStep: current},
+		{name: "later step after a used one", secret: testTOTPSecret, code: totpCode(key, current+1), lastUsedStep: current, wantStep: current + 1, wantOK: true},
+		{name: "wrong code", secret: testTOTPSecret, code: "000000"},
+		{name: "invalid secret", secret: "not base32!", code: totpCode(key, current)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			step, ok := matchTOTP(tt.secret, tt.code, now, tt.lastUsedStep)
+			if ok != tt.wantOK || step != tt.wantStep {
+				t.Errorf("got step %d, %v, want %d, %v", step, ok, tt.wantStep, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestVerifySecondFactor(t *testing.T) {
+	key := []byte("12345678901234567890")
+	current := time.Now().Unix() / totpPeriod
+	confirmedAt := time.Now().Add(-time.Hour)
+	lockedUntil := time.Now().Add(time.Minute)
+	expiredLock := time.Now().Add(-time.Minute)
+	recoveryCode := "abcde-fghij"
+
+	tests := []struct {
+		name         string
+		totp         domain.TOTP
+		code         string
+		wantErr      error
+		wantFailures int
+		wantLastUsed int64
+	}{
+		{name: "valid code", code: totpCode(key, current), wantLastUsed: current},
+		{name: "valid code with spaces", code: totpCode(key, current)[:3] + " " + totpCode(key, current)[3:], wantLastUsed: current},
+		{name: "replayed code", totp: domain.TOTP{LastUsedStep: current}, code: totpCode(key, current), wantErr: domain.ErrInvalidTOTPCode, wantFailures: 1, wantLastUsed: current},
+		{name: "wrong code", code: "000000", wantErr: domain.ErrInvalidTOTPCode, wantFailures: 1},
+		{name: "empty code", code: "", wantErr: domain.ErrInvalidTOTPCode, wantFailures: 1},
+		{name: "recovery code", code: "ABCDE FGHIJ"},
+		{name: "unknown recovery code", code: "zzzzz-zzzzz", wantErr: domain.ErrInvalidTOTPCode, wantFailures: 1},
+		{name: "locked factor", totp: domain.TOTP{LockedUntil: &lockedUntil}, code: totpCode(key, current), wantErr: domain.ErrTOTPLocked},
+		{name: "expired lock", totp: domain.TOTP{LockedUntil: &expiredLock}, code: totpCode(key, current), wantLastUsed: current},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			totp := tt.totp
+			totp.UserID = uuid.New()
+			totp.Secret = testTOTPSecret
+			totp.ConfirmedAt = &confirmedAt
+			repo := &fakeTOTPRepository{totp: totp, recoveryCodes: map[string]bool{recoveryCodeHash(recoveryCode): false}}
+			s := &AuthService{totpRepo: repo}
+
+			err := s.verifySecondFactor(context.Background(), totp, tt.code)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("got error %v, want %v", err, tt.wantErr)
+			}
+			if repo.totp.FailedAttempts != tt.wantFailures {
+				t.Errorf("got %d failed attempts, want %d", repo.totp.FailedAttempts, tt.wantFailures)
+			}
+			if repo.totp.LastUsedStep != tt.wantLastUsed {
+				t.Errorf("got last used step %d, want %d", repo.totp.LastUsedStep, tt.wantLastUsed)
+			}
+		})
+	}
+}
+
+func TestVerifySecondFactorLockout(t *testing.T) {
+	key := []byte("12345678901234567890")
+	confirmedAt := time.Now().Add(-time.Hour)
+	repo := &fakeTOTPRepository{totp: domain.TOTP{UserID: uuid.New(), Secret: testTOTPSecret, ConfirmedAt: &confirmedAt}}
+	s := &AuthService{totpRepo: repo}
+	ctx := context.Background()
+
+	for attempt := 1; attempt <= maxTOTPAttempts; attempt++ {
+		// The wrong code that locks the factor already tells so.
+		want := domain.ErrInvalidTOTPCode
+		if attempt == maxTOTPAttempts {
+			want = domain.ErrTOTPLocked
+		}
+		if err := s.verifySecondFactor(ctx, repo.totp, "000000"); !errors.Is(err, want) {
+			t.Fatalf("attempt %d: got error %v, want %v", attempt, err, want)
+		}
+	}
+	if repo.totp.LockedUntil == nil {
+		t.Fatalf("factor not locked after %d wrong codes", maxTOTPAttempts)
+	}
+	if wait := time.Until(*repo.totp.LockedUntil); wait <= totpLockDuration-time.Minute || wait > totpLockDuration {
+		t.Errorf("factor locked for %v, want %v", wait, totpLockDuration)
+	}
+
+	// Even the right code is refused while the lock lasts, and it does not count as a failure.
+	code := totpCode(key, time.Now().Unix()/totpPeriod)
+	if err := s.verifySecondFactor(ctx, repo.totp, code); !errors.Is(err, domain.ErrTOTPLocked) {
+		t.Fatalf("got error %v, want ErrTOTPLocked", err)
+	}
+	if repo.totp.FailedAttempts != 0 || repo.totp.LastUsedStep != 0 {
+		t.Errorf("locked attempt changed the factor: %+v", repo.totp)
+	}
+}
+
+func TestVerifySecondFactorStaleLock(t *testing.T) {
+	key := []byte("12345678901234567890")
+	confirmedAt := time.Now().Add(-time.Hour)
+	lockedUntil := time.Now().Add(time.Minute)
+	recoveryCode := "abcde-fghij"
+	code := totpCode(key, time.Now().Unix()/totpPeriod)
+
+	// The factor was read before another request locked it: the repository still refuses
+	// the codes, without counting them as failures or using them up.
+	for _, c := range []string{code, recoveryCode} {
+		stale := domain.TOTP{UserID: uuid.New(), Secret: testTOTPSecret, ConfirmedAt: &confirmedAt}
+		locked := stale
+		locked.LockedUntil = &lockedUntil
+		repo := &fakeTOTPRepository{totp: locked, recoveryCodes: map[string]bool{recoveryCodeHash(recoveryCode): false}}
+		s := &AuthService{totpRepo: repo}
+
+		if err := s.verifySecondFactor(context.Background(), stale, c); !errors.Is(err, domain.ErrTOTPLocked) {
+			t.Fatalf("code %q: got error %v, want ErrTOTPLocked", c, err)
+		}
+		if repo.totp.FailedAttempts != 0 || repo.totp.LastUsedStep != 0 || repo.recoveryCodes[recoveryCodeHash(recoveryCode)] {
+			t.Errorf("code %q: locked attempt changed the factor: %+v", c, repo.totp)
+		}
+	}
+}
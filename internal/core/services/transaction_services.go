@@ -0,0 +1,106 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/misalima/my-budget-planner-backend/internal/core/domain"
+	"github.com/misalima/my-budget-planner-backend/internal/core/interfaces/irepository"
+	"sort"
+	"time"
+)
+
+const (
+	defaultTransactionPageSize = 50
+	maxTransactionPageSize     = 200
+)
+
+type TransactionService struct {
+	repo irepository.TransactionLoader
+}
+
+func NewTransactionService(repo irepository.TransactionLoader) *TransactionService {
+	return &TransactionService{repo: repo}
+}
+
+// ListTransactions merges every expense type and the incomes of the user into a single
+// sorted ledger and returns the requested page of it.
+func (s *TransactionService) ListTransactions(ctx context.Context, userID uuid.UUID, filters irepository.TransactionFilters) (domain.TransactionPage, error) {
+	if err := normalizeTransactionFilters(&filters); err != nil {
+		return domain.TransactionPage{}, err
+	}
+	if len(filters.Types) == 0 {
+		filters.Types = []string{
+			domain.TransactionTypeSimpleExpense,
+			domain.TransactionTypeRecurringExpense,
+			domain.TransactionTypeCreditCardExpense,
+			domain.TransactionTypeIncome,
+		}
+	}
+	// Recurring incomes are listed once per occurrence, never beyond today unless
+	// the range explicitly asks for future dates.
+	filters.RecurringIncomeUntil = time.Now().UTC().Truncate(24 * time.Hour)
+	if filters.EndDate != nil {
+		filters.RecurringIncomeUntil = *filters.EndDate
+	}
+
+	transactions, total, err := s.repo.FindTransactions(ctx, userID, filters)
+	if err != nil {
+		return domain.TransactionPage{}, err
+	}
+	return domain.TransactionPage{Transactions: transactions, Total: total, Limit: *filters.Limit, Offset: *filters.Offset}, nil
+}
+
+func normalizeTransactionFilters(filters *irepository.TransactionFilters) error {
+	for _, t := range filters.Types {
+		if !domain.IsValidTransactionType(t) {
+			return fmt.Errorf("%w: unknown transaction type %q", domain.ErrInvalidInput, t)
+		}
+	}
+	if filters.Currency != nil {
+		currency := domain.NormalizeCurrency(*filters.Currency)
+		if !domain.IsValidCurrency(currency) {
+			return fmt.Errorf("%w: invalid currency %q", domain.ErrInvalidInput, *filters.Currency)
+		}
+		filters.Currency = &currency
+	} else if filters.MinAmount != nil || filters.MaxAmount != nil {
+		return fmt.Errorf("%w: filtering by amount requires a currency, as amounts in different currencies cannot be compared", domain.ErrInvalidInput)
+	}
+	switch filters.SortBy {
+	case "":
+		filters.SortBy = domain.TransactionSortDate
+	case domain.TransactionSortDate, domain.TransactionSortAmount:
+	default:
+		return fmt.Errorf("%w: cannot sort transactions by %q", domain.ErrInvalidInput, filters.SortBy)
+	}
+	limit, offset := defaultTransactionPageSize, 0
+	if filters.Limit != nil && *filters.Limit > 0 {
+		limit = min(*filters.Limit, maxTransactionPageSize)
+	}
+	if filters.Offset != nil && *filters.Offset > 0 {
+		offset = *filters.Offset
+	}
+	filters.Limit, filters.Offset = &limit, &offset
+	return nil
+}
+
+// sortTransactions orders the ledger by the given key, breaking ties by creation time
+// and ID so that pages stay stable between requests.
+func sortTransactions(transactions []domain.Transaction, sortBy string, ascending bool) {
+	sort.SliceStable(transactions, func(i, j int) bool {
+		a, b := transactions[i], transactions[j]
+		if !ascending {
+			a, b = b, a
+		}
+		if sortBy == domain.TransactionSortAmount && a.Amount != b.Amount {
+			return a.Amount < b.Amount
+		}
+		if !a.Date.Equal(b.Date) {
+			return a.Date.Before(b.Date)
+		}
+		if !a.CreatedAt.Equal(b.CreatedAt) {
+			return a.CreatedAt.Before(b.CreatedAt)
+		}
+		return a.ID.String() < b.ID.String()
+	})
+}
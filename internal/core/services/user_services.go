@@ -3,6 +3,7 @@ package services
 import (
 	"context"
 	"fmt"
+	"github.com/google/uuid"
 	"github.com/misalima/my-budget-planner-backend/internal/core/domain"
 	"github.com/misalima/my-budget-planner-backend/internal/core/interfaces/irepository"
 	"golang.org/x/crypto/bcrypt"
@@ -33,6 +34,11 @@ func (s *UserService) RegisterUser(user *domain.User) error {
 	}
 	user.Password = string(hashedPassword)
 
+	user.BaseCurrency = domain.NormalizeCurrency(user.BaseCurrency)
+	if user.BaseCurrency == "" {
+		user.BaseCurrency = domain.DefaultCurrency
+	}
+
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
@@ -47,6 +53,23 @@ func (s *UserService) RegisterUser(user *domain.User) error {
 	return s.repo.CreateUser(ctx, user)
 }
 
+func (s *UserService) ListUserIDs(ctx context.Context) ([]uuid.UUID, error) {
+	return s.repo.ListUserIDs(ctx)
+}
+
+// UpdateBaseCurrency changes the currency summaries and reports of the user are
+// converted into. It returns the normalized currency code.
+func (s *UserService) UpdateBaseCurrency(ctx context.Context, userID uuid.UUID, currency string) (string, error) {
+	currency = domain.NormalizeCurrency(currency)
+	if !domain.IsValidCurrency(currency) {
+		return "", fmt.Errorf("%w: invalid currency %q", domain.ErrInvalidInput, currency)
+	}
+	if err := s.repo.UpdateBaseCurrency(ctx, userID, currency); err != nil {
+		return "", err
+	}
+	return currency, nil
+}
+
 func ValidateUser(user *domain.User) error {
 
 	if len(user.Username) < 3 {
@@ -57,6 +80,10 @@ func ValidateUser(user *domain.User) error {
 		return fmt.Errorf("invalid email address")
 	}
 
+	if user.BaseCurrency != "" && !domain.IsValidCurrency(domain.NormalizeCurrency(user.BaseCurrency)) {
+		return fmt.Errorf("invalid base currency %q", user.BaseCurrency)
+	}
+
 	if len(user.Password) < 8 {
 		return fmt.Errorf("password must be at least 8 characters long")
 	}
@@ -0,0 +1,49 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// AdvisoryLocker uses Postgres session-level advisory locks, keyed by the hash of
+// a name, so that only one application instance holds a given lock at a time.
+type AdvisoryLocker struct {
+	db *pgxpool.Pool
+}
+
+func NewAdvisoryLocker(db *pgxpool.Pool) *AdvisoryLocker {
+	return &AdvisoryLocker{db: db}
+}
+
+func (l *AdvisoryLocker) TryLock(ctx context.Context, name string) (func(), bool, error) {
+	// Session locks belong to a connection, so the same connection must be kept until unlock.
+	conn, err := l.db.Acquire(ctx)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to acquire connection: %w", err)
+	}
+
+	var locked bool
+	if err := conn.QueryRow(ctx, `SELECT pg_try_advisory_lock(hashtext($1))`, name).Scan(&locked); err != nil {
+		conn.Release()
+		return nil, false, fmt.Errorf("failed to try advisory lock: %w", err)
+	}
+	if !locked {
+		conn.Release()
+		return nil, false, nil
+	}
+
+	release := func() {
+		// The job context may already be done, so unlock with a fresh one.
+		var unlocked bool
+		err := conn.QueryRow(context.Background(), `SELECT pg_advisory_unlock(hashtext($1))`, name).Scan(&unlocked)
+		if err != nil || !unlocked {
+			// Closing the connection ends its session, which releases the lock; returning it
+			// to the pool would keep the lock held.
+			_ = conn.Hijack().Close(context.Background())
+			return
+		}
+		conn.Release()
+	}
+	return release, true, nil
+}
@@ -2,27 +2,44 @@ package postgres
 
 import (
 	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/misalima/my-budget-planner-backend/internal/core/domain"
 
 	"time"
 )
 
+// AuthRepository stores refresh tokens as an HMAC-SHA256 keyed with hashKey, so that the
+// rows of refresh_tokens cannot be used as tokens.
 type AuthRepository struct {
-	Conn *pgxpool.Pool
+	Conn    *pgxpool.Pool
+	hashKey []byte
 }
 
-func NewAuthRepository(Conn *pgxpool.Pool) *AuthRepository {
-	return &AuthRepository{Conn: Conn}
+func NewAuthRepository(Conn *pgxpool.Pool, hashKey []byte) *AuthRepository {
+	return &AuthRepository{Conn: Conn, hashKey: hashKey}
 }
 
-func (a *AuthRepository) StoreRefreshToken(ctx context.Context, userId uuid.UUID, refreshToken string) error {
+func (a *AuthRepository) tokenHash(token string) string {
+	mac := hmac.New(sha256.New, a.hashKey)
+	mac.Write([]byte(token))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func (a *AuthRepository) StoreRefreshToken(ctx context.Context, userId uuid.UUID, familyId uuid.UUID, refreshToken string, client domain.SessionClient) error {
 	expirationTime := time.Now().Add(7 * 24 * time.Hour)
 	createdAt := time.Now()
 
-	sql := `INSERT INTO refresh_tokens (user_id, token, expires_at, created_at) VALUES ($1, $2, $3, $4)`
-	_, err := a.Conn.Exec(ctx, sql, userId, refreshToken, expirationTime, createdAt)
+	sql := `INSERT INTO refresh_tokens (user_id, family_id, token_hash, user_agent, ip_address, device_label, last_used_at, expires_at, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $7)`
+	_, err := a.Conn.Exec(ctx, sql, userId, familyId, a.tokenHash(refreshToken),
+		client.UserAgent, client.IPAddress, client.DeviceLabel, createdAt, expirationTime)
 	if err != nil {
 		return err
 	}
@@ -34,21 +51,177 @@ func (a *AuthRepository) GetRefreshToken(ctx context.Context, token string) (dom
 
 	var refreshToken domain.RefreshToken
 
-	sql := `SELECT id, user_id, token, expires_at, created_at FROM refresh_tokens WHERE token = $1`
-	err := a.Conn.QueryRow(ctx, sql, token).Scan(&refreshToken)
+	sql := `SELECT "ID", user_id, family_id, token_hash, user_agent, ip_address, device_label, last_used_at, expires_at, revoked_at, created_at
+		FROM refresh_tokens WHERE token_hash = $1`
+	err := a.Conn.QueryRow(ctx, sql, a.tokenHash(token)).Scan(
+		&refreshToken.ID, &refreshToken.UserID, &refreshToken.FamilyID, &refreshToken.TokenHash,
+		&refreshToken.UserAgent, &refreshToken.IPAddress, &refreshToken.DeviceLabel, &refreshToken.LastUsedAt,
+		&refreshToken.ExpiresAt, &refreshToken.RevokedAt, &refreshToken.CreatedAt,
+	)
 	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return domain.RefreshToken{}, domain.ErrNotFound
+		}
 		return domain.RefreshToken{}, err
 	}
 
 	return refreshToken, nil
 }
 
+// RotateRefreshToken revokes the current token and stores its replacement in the same
+// family, in a single transaction. The replacement records the client that refreshed and
+// keeps the device label of the session. It fails with ErrRefreshTokenReused when the
+// current token was revoked in the meantime, such as by a concurrent refresh.
+func (a *AuthRepository) RotateRefreshToken(ctx context.Context, current domain.RefreshToken, refreshToken string, client domain.SessionClient) error {
+	tx, err := a.Conn.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	now := time.Now()
+	tag, err := tx.Exec(ctx, `UPDATE refresh_tokens SET revoked_at = $2 WHERE "ID" = $1 AND revoked_at IS NULL`, current.ID, now)
+	if err != nil {
+		return fmt.Errorf("failed to revoke refresh token: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return domain.ErrRefreshTokenReused
+	}
+
+	sql := `INSERT INTO refresh_tokens (user_id, family_id, token_hash, user_agent, ip_address, device_label, last_used_at, expires_at, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $7)`
+	if _, err := tx.Exec(ctx, sql, current.UserID, current.FamilyID, a.tokenHash(refreshToken),
+		client.UserAgent, client.IPAddress, current.DeviceLabel, now, now.Add(7*24*time.Hour)); err != nil {
+		return fmt.Errorf("failed to store refresh token: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit refresh token rotation: %w", err)
+	}
+	return nil
+}
+
+// RevokeRefreshTokenFamily revokes every token of the family that is still valid.
+func (a *AuthRepository) RevokeRefreshTokenFamily(ctx context.Context, familyId uuid.UUID) error {
+	sql := `UPDATE refresh_tokens SET revoked_at = $2 WHERE family_id = $1 AND revoked_at IS NULL`
+	_, err := a.Conn.Exec(ctx, sql, familyId, time.Now())
+	if err != nil {
+		return fmt.Errorf("failed to revoke refresh token family: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteRefreshToken deletes the whole family of the token, which ends its session.
 func (a *AuthRepository) DeleteRefreshToken(ctx context.Context, token string) error {
-	sql := `DELETE FROM refresh_tokens WHERE token = $1`
-	_, err := a.Conn.Exec(ctx, sql, token)
+	sql := `DELETE FROM refresh_tokens WHERE family_id IN (SELECT family_id FROM refresh_tokens WHERE token_hash = $1)`
+	_, err := a.Conn.Exec(ctx, sql, a.tokenHash(token))
 	if err != nil {
 		return err
 	}
 
 	return nil
 }
+
+// FetchSessions returns the sessions of the user that can still be refreshed, most
+// recently used first. Each one is described by the current token of its family.
+func (a *AuthRepository) FetchSessions(ctx context.Context, userId uuid.UUID) ([]domain.Session, error) {
+	sql := `SELECT r.family_id, r.device_label, r.user_agent, r.ip_address,
+			(SELECT min(f.created_at) FROM refresh_tokens f WHERE f.family_id = r.family_id),
+			r.last_used_at, r.expires_at
+		FROM refresh_tokens r
+		WHERE r.user_id = $1 AND r.revoked_at IS NULL AND r.expires_at > $2
+		ORDER BY r.last_used_at DESC`
+	rows, err := a.Conn.Query(ctx, sql, userId, time.Now())
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch sessions: %w", err)
+	}
+	defer rows.Close()
+
+	sessions := []domain.Session{}
+	for rows.Next() {
+		var s domain.Session
+		if err := rows.Scan(&s.ID, &s.DeviceLabel, &s.UserAgent, &s.IPAddress, &s.CreatedAt, &s.LastUsedAt, &s.ExpiresAt); err != nil {
+			return nil, fmt.Errorf("failed to scan session: %w", err)
+		}
+		sessions = append(sessions, s)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to fetch sessions: %w", err)
+	}
+	return sessions, nil
+}
+
+// DeleteSession deletes every token of the session, provided it belongs to the user.
+func (a *AuthRepository) DeleteSession(ctx context.Context, userId uuid.UUID, sessionId uuid.UUID) error {
+	tag, err := a.Conn.Exec(ctx, `DELETE FROM refresh_tokens WHERE user_id = $1 AND family_id = $2`, userId, sessionId)
+	if err != nil {
+		return fmt.Errorf("failed to delete session: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
+
+// IsSessionActive reports whether the session of the user still has a refresh token that
+// was neither revoked nor expired.
+func (a *AuthRepository) IsSessionActive(ctx context.Context, userId uuid.UUID, sessionId uuid.UUID) (bool, error) {
+	var active bool
+	sql := `SELECT EXISTS (
+			SELECT 1 FROM refresh_tokens
+			WHERE user_id = $1 AND family_id = $2 AND revoked_at IS NULL AND expires_at > $3
+		)`
+	if err := a.Conn.QueryRow(ctx, sql, userId, sessionId, time.Now()).Scan(&active); err != nil {
+		return false, fmt.Errorf("failed to check session: %w", err)
+	}
+	return active, nil
+}
+
+func (a *AuthRepository) DeleteExpiredRefreshTokens(ctx context.Context, now time.Time) (int64, error) {
+	sql := `DELETE FROM refresh_tokens WHERE expires_at < $1`
+	tag, err := a.Conn.Exec(ctx, sql, now)
+	if err != nil {
+		return 0, err
+	}
+
+	return tag.RowsAffected(), nil
+}
+
+// RevokeUserTokens deletes every refresh token of the user and rejects, from then on,
+// the access tokens issued before validAfter.
+func (a *AuthRepository) RevokeUserTokens(ctx context.Context, userId uuid.UUID, validAfter time.Time) error {
+	tx, err := a.Conn.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	tag, err := tx.Exec(ctx, `UPDATE users SET tokens_valid_after = $2 WHERE "ID" = $1`, userId, validAfter)
+	if err != nil {
+		return fmt.Errorf("failed to revoke access tokens: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return domain.ErrNotFound
+	}
+	if _, err := tx.Exec(ctx, `DELETE FROM refresh_tokens WHERE user_id = $1`, userId); err != nil {
+		return fmt.Errorf("failed to delete refresh tokens: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit token revocation: %w", err)
+	}
+	return nil
+}
+
+func (a *AuthRepository) GetTokensValidAfter(ctx context.Context, userId uuid.UUID) (*time.Time, error) {
+	var validAfter *time.Time
+	err := a.Conn.QueryRow(ctx, `SELECT tokens_valid_after FROM users WHERE "ID" = $1`, userId).Scan(&validAfter)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrNotFound
+		}
+		return nil, err
+	}
+	return validAfter, nil
+}
@@ -0,0 +1,180 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/misalima/my-budget-planner-backend/internal/core/domain"
+	"time"
+)
+
+const budgetSelect = `
+		SELECT b."ID", b.user_id, b.budget_name, b.description, b.amount, b.period, b.rollover,
+		       COALESCE(array_agg(bc.category_id ORDER BY bc.category_id) FILTER (WHERE bc.category_id IS NOT NULL), '{}'),
+		       b.start_date, b.end_date, b.created_at, b.updated_at
+		FROM budgets b
+		LEFT JOIN budget_categories bc ON bc.budget_id = b."ID"`
+
+type BudgetRepository struct {
+	db *pgxpool.Pool
+}
+
+func (b BudgetRepository) InsertBudget(ctx context.Context, budget domain.Budget) (domain.Budget, error) {
+	query := `
+		INSERT INTO budgets (user_id, budget_name, description, amount, period, rollover, start_date, end_date, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		RETURNING "ID"`
+
+	now := time.Now()
+	budget.CreatedAt = now
+	budget.UpdatedAt = now
+
+	tx, err := b.db.Begin(ctx)
+	if err != nil {
+		return domain.Budget{}, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	err = tx.QueryRow(ctx, query,
+		budget.UserID, budget.Name, budget.Description, budget.Amount, budget.Period, budget.Rollover,
+		budget.StartDate, budget.EndDate, budget.CreatedAt, budget.UpdatedAt,
+	).Scan(&budget.ID)
+	if err != nil {
+		return domain.Budget{}, fmt.Errorf("failed to insert budget: %w", err)
+	}
+
+	if err := replaceBudgetCategories(ctx, tx, budget.ID, budget.CategoryIDs); err != nil {
+		return domain.Budget{}, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return domain.Budget{}, fmt.Errorf("failed to commit budget: %w", err)
+	}
+
+	return budget, nil
+}
+
+func (b BudgetRepository) UpdateBudget(ctx context.Context, budget domain.Budget) (domain.Budget, error) {
+	query := `
+		UPDATE budgets
+		SET budget_name = $1, description = $2, amount = $3, period = $4, rollover = $5,
+		    start_date = $6, end_date = $7, updated_at = $8
+		WHERE "ID" = $9 AND user_id = $10
+		RETURNING created_at, updated_at`
+
+	tx, err := b.db.Begin(ctx)
+	if err != nil {
+		return domain.Budget{}, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	err = tx.QueryRow(ctx, query,
+		budget.Name, budget.Description, budget.Amount, budget.Period, budget.Rollover,
+		budget.StartDate, budget.EndDate, time.Now(), budget.ID, budget.UserID,
+	).Scan(&budget.CreatedAt, &budget.UpdatedAt)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return domain.Budget{}, domain.ErrNotFound
+		}
+		return domain.Budget{}, fmt.Errorf("failed to update budget: %w", err)
+	}
+
+	if err := replaceBudgetCategories(ctx, tx, budget.ID, budget.CategoryIDs); err != nil {
+		return domain.Budget{}, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return domain.Budget{}, fmt.Errorf("failed to commit budget: %w", err)
+	}
+
+	return budget, nil
+}
+
+func (b BudgetRepository) DeleteBudget(ctx context.Context, id int) error {
+	query := `DELETE FROM budgets WHERE "ID" = $1`
+
+	result, err := b.db.Exec(ctx, query, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete budget: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return domain.ErrNotFound
+	}
+
+	return nil
+}
+
+func (b BudgetRepository) FindBudgetByID(ctx context.Context, id int) (domain.Budget, error) {
+	query := budgetSelect + `
+		WHERE b."ID" = $1
+		GROUP BY b."ID"`
+
+	budget, err := scanBudget(b.db.QueryRow(ctx, query, id))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return domain.Budget{}, domain.ErrNotFound
+		}
+		return domain.Budget{}, fmt.Errorf("failed to find budget: %w", err)
+	}
+
+	return budget, nil
+}
+
+func (b BudgetRepository) FindBudgetsByUser(ctx context.Context, userID uuid.UUID) ([]domain.Budget, error) {
+	query := budgetSelect + `
+		WHERE b.user_id = $1
+		GROUP BY b."ID"
+		ORDER BY b.start_date DESC, b."ID" DESC`
+
+	rows, err := b.db.Query(ctx, query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find budgets by user: %w", err)
+	}
+	defer rows.Close()
+
+	budgets := []domain.Budget{}
+	for rows.Next() {
+		budget, err := scanBudget(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan budget: %w", err)
+		}
+		budgets = append(budgets, budget)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error during rows iteration: %w", err)
+	}
+
+	return budgets, nil
+}
+
+func scanBudget(row pgx.Row) (domain.Budget, error) {
+	var budget domain.Budget
+	err := row.Scan(
+		&budget.ID, &budget.UserID, &budget.Name, &budget.Description, &budget.Amount, &budget.Period, &budget.Rollover,
+		&budget.CategoryIDs, &budget.StartDate, &budget.EndDate, &budget.CreatedAt, &budget.UpdatedAt,
+	)
+	return budget, err
+}
+
+func replaceBudgetCategories(ctx context.Context, tx pgx.Tx, budgetID int, categoryIDs []int) error {
+	if _, err := tx.Exec(ctx, `DELETE FROM budget_categories WHERE budget_id = $1`, budgetID); err != nil {
+		return fmt.Errorf("failed to clear budget categories: %w", err)
+	}
+	for _, categoryID := range categoryIDs {
+		_, err := tx.Exec(ctx, `INSERT INTO budget_categories (budget_id, category_id) VALUES ($1, $2) ON CONFLICT DO NOTHING`, budgetID, categoryID)
+		if err != nil {
+			return fmt.Errorf("failed to link category %d to budget: %w", categoryID, err)
+		}
+	}
+	return nil
+}
+
+func NewBudgetRepository(db *pgxpool.Pool) *BudgetRepository {
+	return &BudgetRepository{
+		db: db,
+	}
+}
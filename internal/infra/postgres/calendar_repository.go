@@ -0,0 +1,59 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/misalima/my-budget-planner-backend/internal/core/domain"
+)
+
+type CalendarRepository struct {
+	db *pgxpool.Pool
+}
+
+// StoreCalendarFeed saves the feed of the user, replacing the token of a previous one.
+func (c CalendarRepository) StoreCalendarFeed(ctx context.Context, feed domain.CalendarFeed, tokenHash string) error {
+	query := `
+		INSERT INTO calendar_feeds (user_id, token_hash, created_at)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (user_id) DO UPDATE SET token_hash = EXCLUDED.token_hash, created_at = EXCLUDED.created_at`
+
+	if _, err := c.db.Exec(ctx, query, feed.UserID, tokenHash, feed.CreatedAt); err != nil {
+		return fmt.Errorf("failed to store calendar feed: %w", err)
+	}
+	return nil
+}
+
+func (c CalendarRepository) FindCalendarFeedUser(ctx context.Context, tokenHash string) (uuid.UUID, error) {
+	query := `SELECT user_id FROM calendar_feeds WHERE token_hash = $1`
+
+	var userID uuid.UUID
+	if err := c.db.QueryRow(ctx, query, tokenHash).Scan(&userID); err != nil {
+		if err == pgx.ErrNoRows {
+			return uuid.Nil, domain.ErrNotFound
+		}
+		return uuid.Nil, fmt.Errorf("failed to find calendar feed: %w", err)
+	}
+	return userID, nil
+}
+
+func (c CalendarRepository) DeleteCalendarFeed(ctx context.Context, userID uuid.UUID) error {
+	query := `DELETE FROM calendar_feeds WHERE user_id = $1`
+
+	tag, err := c.db.Exec(ctx, query, userID)
+	if err != nil {
+		return fmt.Errorf("failed to delete calendar feed: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
+
+func NewCalendarRepository(db *pgxpool.Pool) *CalendarRepository {
+	return &CalendarRepository{
+		db: db,
+	}
+}
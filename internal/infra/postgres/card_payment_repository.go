@@ -0,0 +1,141 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/misalima/my-budget-planner-backend/internal/core/domain"
+	"time"
+)
+
+type CardPaymentRepository struct {
+	db *pgxpool.Pool
+}
+
+func (c CardPaymentRepository) InsertCardPayment(ctx context.Context, payment domain.CardPayment) (domain.CardPayment, error) {
+	query := `
+		INSERT INTO card_payments (user_id, card_id, statement_month, amount, paid_at, description, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		RETURNING "ID"`
+
+	now := time.Now()
+	payment.CreatedAt = now
+	payment.UpdatedAt = now
+
+	tx, err := c.db.Begin(ctx)
+	if err != nil {
+		return domain.CardPayment{}, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	err = tx.QueryRow(ctx, query,
+		payment.UserID, payment.CardID, payment.StatementMonth, payment.Amount, payment.PaidAt, payment.Description,
+		payment.CreatedAt, payment.UpdatedAt,
+	).Scan(&payment.ID)
+	if err != nil {
+		return domain.CardPayment{}, fmt.Errorf("failed to insert card payment: %w", err)
+	}
+
+	if err := adjustCardLimit(ctx, tx, payment.CardID, payment.Amount); err != nil {
+		return domain.CardPayment{}, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return domain.CardPayment{}, fmt.Errorf("failed to commit card payment: %w", err)
+	}
+
+	return payment, nil
+}
+
+func (c CardPaymentRepository) DeleteCardPayment(ctx context.Context, id uuid.UUID) error {
+	query := `DELETE FROM card_payments WHERE "ID" = $1 RETURNING card_id, amount`
+
+	tx, err := c.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	var cardID uuid.UUID
+	var amount domain.Money
+	err = tx.QueryRow(ctx, query, id).Scan(&cardID, &amount)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return domain.ErrNotFound
+		}
+		return fmt.Errorf("failed to delete card payment: %w", err)
+	}
+
+	if err := adjustCardLimit(ctx, tx, cardID, -amount); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit card payment deletion: %w", err)
+	}
+
+	return nil
+}
+
+func (c CardPaymentRepository) FindCardPaymentByID(ctx context.Context, id uuid.UUID) (domain.CardPayment, error) {
+	query := `
+		SELECT "ID", user_id, card_id, statement_month, amount, paid_at, description, created_at, updated_at
+		FROM card_payments
+		WHERE "ID" = $1`
+
+	payment, err := scanCardPayment(c.db.QueryRow(ctx, query, id))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return domain.CardPayment{}, domain.ErrNotFound
+		}
+		return domain.CardPayment{}, fmt.Errorf("failed to find card payment: %w", err)
+	}
+
+	return payment, nil
+}
+
+func (c CardPaymentRepository) FindCardPaymentsByCard(ctx context.Context, cardID uuid.UUID) ([]domain.CardPayment, error) {
+	query := `
+		SELECT "ID", user_id, card_id, statement_month, amount, paid_at, description, created_at, updated_at
+		FROM card_payments
+		WHERE card_id = $1
+		ORDER BY statement_month, paid_at, created_at`
+
+	rows, err := c.db.Query(ctx, query, cardID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find card payments: %w", err)
+	}
+	defer rows.Close()
+
+	payments := []domain.CardPayment{}
+	for rows.Next() {
+		payment, err := scanCardPayment(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan card payment: %w", err)
+		}
+		payments = append(payments, payment)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error during rows iteration: %w", err)
+	}
+
+	return payments, nil
+}
+
+func scanCardPayment(row pgx.Row) (domain.CardPayment, error) {
+	var payment domain.CardPayment
+	err := row.Scan(
+		&payment.ID, &payment.UserID, &payment.CardID, &payment.StatementMonth, &payment.Amount, &payment.PaidAt,
+		&payment.Description, &payment.CreatedAt, &payment.UpdatedAt,
+	)
+	return payment, err
+}
+
+func NewCardPaymentRepository(db *pgxpool.Pool) *CardPaymentRepository {
+	return &CardPaymentRepository{
+		db: db,
+	}
+}
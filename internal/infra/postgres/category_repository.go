@@ -17,9 +17,9 @@ func NewCategoryRepository(Conn *pgxpool.Pool) *CategoryRepository {
 }
 
 func (c *CategoryRepository) CreateCategory(ctx context.Context, category *domain.Category) error {
-	sql := `INSERT INTO categories (category_name, user_id) VALUES ($1, $2) RETURNING "ID"`
+	sql := `INSERT INTO categories (category_name, kind, user_id) VALUES ($1, $2, $3) RETURNING "ID"`
 
-	err := c.Conn.QueryRow(ctx, sql, category.Name, category.UserID).Scan(&category.ID)
+	err := c.Conn.QueryRow(ctx, sql, category.Name, category.Kind, category.UserID).Scan(&category.ID)
 	if err != nil {
 		return err
 	}
@@ -28,7 +28,7 @@ func (c *CategoryRepository) CreateCategory(ctx context.Context, category *domai
 }
 
 func (c *CategoryRepository) GetCategoryByUserID(ctx context.Context, userId uuid.UUID) ([]domain.Category, error) {
-	sql := `SELECT * FROM categories WHERE user_id = $1 OR user_id IS NULL`
+	sql := `SELECT "ID", category_name, kind, user_id FROM categories WHERE user_id = $1 OR user_id IS NULL`
 
 	rows, err := c.Conn.Query(ctx, sql, userId)
 	if err != nil {
@@ -39,7 +39,7 @@ func (c *CategoryRepository) GetCategoryByUserID(ctx context.Context, userId uui
 	var categories []domain.Category
 	for rows.Next() {
 		var category domain.Category
-		err := rows.Scan(&category.ID, &category.Name, &category.UserID)
+		err := rows.Scan(&category.ID, &category.Name, &category.Kind, &category.UserID)
 		if err != nil {
 			return nil, err
 		}
@@ -18,16 +18,22 @@ type CreditCardExpenseRepository struct {
 
 func (c CreditCardExpenseRepository) InsertCreditCardExpense(ctx context.Context, expense domain.CreditCardExpense) (domain.CreditCardExpense, error) {
 	query := `
-		INSERT INTO credit_card_expense (user_id, category_id, amount, description, date, card_id, installment_amount, installments_quantity, parcel_number, created_at, updated_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+		INSERT INTO credit_card_expense (user_id, category_id, amount, currency, description, date, card_id, installment_amount, installments_quantity, parcel_number, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
 		RETURNING "ID"`
 
 	now := time.Now()
 	expense.CreatedAt = now
 	expense.UpdatedAt = now
 
-	err := c.db.QueryRow(ctx, query,
-		expense.UserID, expense.CategoryID, expense.Amount, expense.Description, expense.Date,
+	tx, err := c.db.Begin(ctx)
+	if err != nil {
+		return domain.CreditCardExpense{}, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	err = tx.QueryRow(ctx, query,
+		expense.UserID, expense.CategoryID, expense.Amount, expense.Currency, expense.Description, expense.Date,
 		expense.CardID, expense.InstallmentAmount, expense.InstallmentsQuantity, expense.ParcelNumber, expense.CreatedAt, expense.UpdatedAt,
 	).Scan(&expense.ID)
 
@@ -35,6 +41,14 @@ func (c CreditCardExpenseRepository) InsertCreditCardExpense(ctx context.Context
 		return domain.CreditCardExpense{}, fmt.Errorf("failed to insert credit card expense: %w", err)
 	}
 
+	if err := adjustCardLimit(ctx, tx, expense.CardID, -expense.InstallmentValue()); err != nil {
+		return domain.CreditCardExpense{}, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return domain.CreditCardExpense{}, fmt.Errorf("failed to commit credit card expense: %w", err)
+	}
+
 	return expense, nil
 }
 
@@ -53,6 +67,11 @@ func (c CreditCardExpenseRepository) UpdateCreditCardExpense(ctx context.Context
 		args = append(args, expense.Amount)
 		argCount++
 	}
+	if expense.Currency != "" {
+		query += "currency = $" + strconv.Itoa(argCount) + ", "
+		args = append(args, expense.Currency)
+		argCount++
+	}
 	if expense.Description != nil {
 		query += "description = $" + strconv.Itoa(argCount) + ", "
 		args = append(args, *expense.Description)
@@ -90,12 +109,23 @@ func (c CreditCardExpenseRepository) UpdateCreditCardExpense(ctx context.Context
 	query += " WHERE \"ID\" = $" + strconv.Itoa(argCount) + " AND user_id = $" + strconv.Itoa(argCount+1)
 	args = append(args, expense.ID, expense.UserID)
 
-	query += " RETURNING \"ID\", user_id, category_id, amount, description, date, card_id, installment_amount, installments_quantity, parcel_number, created_at, updated_at"
+	query += " RETURNING \"ID\", user_id, category_id, amount, currency, description, date, card_id, installment_amount, installments_quantity, parcel_number, created_at, updated_at"
+
+	tx, err := c.db.Begin(ctx)
+	if err != nil {
+		return domain.CreditCardExpense{}, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	previous, err := findCreditCardExpenseForUpdate(ctx, tx, expense.ID, expense.UserID)
+	if err != nil {
+		return domain.CreditCardExpense{}, err
+	}
 
-	row := c.db.QueryRow(ctx, query, args...)
+	row := tx.QueryRow(ctx, query, args...)
 
-	err := row.Scan(
-		&expense.ID, &expense.UserID, &expense.CategoryID, &expense.Amount, &expense.Description,
+	err = row.Scan(
+		&expense.ID, &expense.UserID, &expense.CategoryID, &expense.Amount, &expense.Currency, &expense.Description,
 		&expense.Date, &expense.CardID, &expense.InstallmentAmount, &expense.InstallmentsQuantity, &expense.ParcelNumber,
 		&expense.CreatedAt, &expense.UpdatedAt,
 	)
@@ -107,19 +137,48 @@ func (c CreditCardExpenseRepository) UpdateCreditCardExpense(ctx context.Context
 		return domain.CreditCardExpense{}, fmt.Errorf("failed to update credit card expense: %w", err)
 	}
 
+	// Give the old value back to the old card before charging the new value, the card may have changed.
+	if err := adjustCardLimit(ctx, tx, previous.CardID, previous.InstallmentValue()); err != nil {
+		return domain.CreditCardExpense{}, err
+	}
+	if err := adjustCardLimit(ctx, tx, expense.CardID, -expense.InstallmentValue()); err != nil {
+		return domain.CreditCardExpense{}, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return domain.CreditCardExpense{}, fmt.Errorf("failed to commit credit card expense: %w", err)
+	}
+
 	return expense, nil
 }
 
 func (c CreditCardExpenseRepository) DeleteCreditCardExpense(ctx context.Context, id uuid.UUID) error {
-	query := `DELETE FROM credit_card_expense WHERE "ID" = $1`
+	query := `
+		DELETE FROM credit_card_expense WHERE "ID" = $1
+		RETURNING card_id, COALESCE(NULLIF(installment_amount, 0), amount)`
 
-	result, err := c.db.Exec(ctx, query, id)
+	tx, err := c.db.Begin(ctx)
 	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	var cardID uuid.UUID
+	var value domain.Money
+	err = tx.QueryRow(ctx, query, id).Scan(&cardID, &value)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return fmt.Errorf("credit card expense not found")
+		}
 		return fmt.Errorf("failed to delete credit card expense: %w", err)
 	}
 
-	if result.RowsAffected() == 0 {
-		return fmt.Errorf("credit card expense not found")
+	if err := adjustCardLimit(ctx, tx, cardID, value); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit credit card expense deletion: %w", err)
 	}
 
 	return nil
@@ -127,14 +186,14 @@ func (c CreditCardExpenseRepository) DeleteCreditCardExpense(ctx context.Context
 
 func (c CreditCardExpenseRepository) FindCreditCardExpenseByID(ctx context.Context, id uuid.UUID) (domain.CreditCardExpense, error) {
 	query := `
-		SELECT "ID", user_id, category_id, amount, description, date, card_id, installment_amount, installments_quantity, parcel_number, created_at, updated_at
+		SELECT "ID", user_id, category_id, amount, currency, description, date, card_id, installment_amount, installments_quantity, parcel_number, created_at, updated_at
 		FROM credit_card_expense 
 		WHERE "ID" = $1`
 
 	var expense domain.CreditCardExpense
 
 	err := c.db.QueryRow(ctx, query, id).Scan(
-		&expense.ID, &expense.UserID, &expense.CategoryID, &expense.Amount, &expense.Description,
+		&expense.ID, &expense.UserID, &expense.CategoryID, &expense.Amount, &expense.Currency, &expense.Description,
 		&expense.Date, &expense.CardID, &expense.InstallmentAmount, &expense.InstallmentsQuantity, &expense.ParcelNumber,
 		&expense.CreatedAt, &expense.UpdatedAt,
 	)
@@ -151,7 +210,7 @@ func (c CreditCardExpenseRepository) FindCreditCardExpenseByID(ctx context.Conte
 
 func (c CreditCardExpenseRepository) FindCreditCardExpenses(ctx context.Context, userID uuid.UUID, filters irepository.CreditCardExpenseFilters) ([]domain.CreditCardExpense, error) {
 	query := `
-		SELECT "ID", user_id, category_id, amount, description, date, card_id, installment_amount, installments_quantity, parcel_number, created_at, updated_at
+		SELECT "ID", user_id, category_id, amount, currency, description, date, card_id, installment_amount, installments_quantity, parcel_number, created_at, updated_at
 		FROM credit_card_expense 
 		WHERE user_id = $1`
 
@@ -230,7 +289,7 @@ func (c CreditCardExpenseRepository) FindCreditCardExpenses(ctx context.Context,
 	for rows.Next() {
 		var expense domain.CreditCardExpense
 		err := rows.Scan(
-			&expense.ID, &expense.UserID, &expense.CategoryID, &expense.Amount, &expense.Description,
+			&expense.ID, &expense.UserID, &expense.CategoryID, &expense.Amount, &expense.Currency, &expense.Description,
 			&expense.Date, &expense.CardID, &expense.InstallmentAmount, &expense.InstallmentsQuantity, &expense.ParcelNumber,
 			&expense.CreatedAt, &expense.UpdatedAt,
 		)
@@ -249,7 +308,7 @@ func (c CreditCardExpenseRepository) FindCreditCardExpenses(ctx context.Context,
 
 func (c CreditCardExpenseRepository) FindCreditCardExpensesByUser(ctx context.Context, userID uuid.UUID) ([]domain.CreditCardExpense, error) {
 	query := `
-		SELECT "ID", user_id, category_id, amount, description, date, card_id, installment_amount, installments_quantity, parcel_number, created_at, updated_at
+		SELECT "ID", user_id, category_id, amount, currency, description, date, card_id, installment_amount, installments_quantity, parcel_number, created_at, updated_at
 		FROM credit_card_expense 
 		WHERE user_id = $1
 		ORDER BY date DESC, created_at DESC`
@@ -264,7 +323,7 @@ func (c CreditCardExpenseRepository) FindCreditCardExpensesByUser(ctx context.Co
 	for rows.Next() {
 		var expense domain.CreditCardExpense
 		err := rows.Scan(
-			&expense.ID, &expense.UserID, &expense.CategoryID, &expense.Amount, &expense.Description,
+			&expense.ID, &expense.UserID, &expense.CategoryID, &expense.Amount, &expense.Currency, &expense.Description,
 			&expense.Date, &expense.CardID, &expense.InstallmentAmount, &expense.InstallmentsQuantity, &expense.ParcelNumber,
 			&expense.CreatedAt, &expense.UpdatedAt,
 		)
@@ -283,7 +342,7 @@ func (c CreditCardExpenseRepository) FindCreditCardExpensesByUser(ctx context.Co
 
 func (c CreditCardExpenseRepository) FindCreditCardExpensesByDateRange(ctx context.Context, userID uuid.UUID, startDate, endDate time.Time) ([]domain.CreditCardExpense, error) {
 	query := `
-		SELECT "ID", user_id, category_id, amount, description, date, card_id, installment_amount, installments_quantity, parcel_number, created_at, updated_at
+		SELECT "ID", user_id, category_id, amount, currency, description, date, card_id, installment_amount, installments_quantity, parcel_number, created_at, updated_at
 		FROM credit_card_expense 
 		WHERE user_id = $1 AND date >= $2 AND date <= $3
 		ORDER BY date DESC, created_at DESC`
@@ -298,7 +357,7 @@ func (c CreditCardExpenseRepository) FindCreditCardExpensesByDateRange(ctx conte
 	for rows.Next() {
 		var expense domain.CreditCardExpense
 		err := rows.Scan(
-			&expense.ID, &expense.UserID, &expense.CategoryID, &expense.Amount, &expense.Description,
+			&expense.ID, &expense.UserID, &expense.CategoryID, &expense.Amount, &expense.Currency, &expense.Description,
 			&expense.Date, &expense.CardID, &expense.InstallmentAmount, &expense.InstallmentsQuantity, &expense.ParcelNumber,
 			&expense.CreatedAt, &expense.UpdatedAt,
 		)
@@ -321,32 +380,88 @@ func (c CreditCardExpenseRepository) InsertInstallments(ctx context.Context, ins
 	}
 
 	query := `
-		INSERT INTO credit_card_expense (user_id, category_id, amount, description, date, card_id, installment_amount, installments_quantity, parcel_number, created_at, updated_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)`
+		INSERT INTO credit_card_expense ("ID", user_id, category_id, amount, currency, description, date, card_id, installment_amount, installments_quantity, parcel_number, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)`
+
+	tx, err := c.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
 
 	batch := &pgx.Batch{}
 	now := time.Now()
+	charged := make(map[uuid.UUID]domain.Money)
 
 	for _, installment := range installments {
 		batch.Queue(query,
-			installment.UserID, installment.CategoryID, installment.Amount, installment.Description, installment.Date,
+			installment.ID, installment.UserID, installment.CategoryID, installment.Amount, installment.Currency, installment.Description, installment.Date,
 			installment.CardID, installment.InstallmentAmount, installment.InstallmentsQuantity, installment.ParcelNumber, now, now,
 		)
+		charged[installment.CardID] += installment.InstallmentValue()
 	}
 
-	results := c.db.SendBatch(ctx, batch)
-	defer results.Close()
-
+	results := tx.SendBatch(ctx, batch)
 	for i := 0; i < len(installments); i++ {
 		_, err := results.Exec()
 		if err != nil {
+			results.Close()
 			return fmt.Errorf("failed to insert installment %d: %w", i, err)
 		}
 	}
+	if err := results.Close(); err != nil {
+		return fmt.Errorf("failed to insert installments: %w", err)
+	}
+
+	for cardID, value := range charged {
+		if err := adjustCardLimit(ctx, tx, cardID, -value); err != nil {
+			return err
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit installments: %w", err)
+	}
 
 	return nil
 }
 
+func findCreditCardExpenseForUpdate(ctx context.Context, tx pgx.Tx, id uuid.UUID, userID uuid.UUID) (domain.CreditCardExpense, error) {
+	query := `
+		SELECT "ID", user_id, category_id, amount, currency, description, date, card_id, installment_amount, installments_quantity, parcel_number, created_at, updated_at
+		FROM credit_card_expense
+		WHERE "ID" = $1 AND user_id = $2
+		FOR UPDATE`
+
+	var expense domain.CreditCardExpense
+	err := tx.QueryRow(ctx, query, id, userID).Scan(
+		&expense.ID, &expense.UserID, &expense.CategoryID, &expense.Amount, &expense.Currency, &expense.Description,
+		&expense.Date, &expense.CardID, &expense.InstallmentAmount, &expense.InstallmentsQuantity, &expense.ParcelNumber,
+		&expense.CreatedAt, &expense.UpdatedAt,
+	)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return domain.CreditCardExpense{}, fmt.Errorf("credit card expense not found or access denied")
+		}
+		return domain.CreditCardExpense{}, fmt.Errorf("failed to find credit card expense: %w", err)
+	}
+	return expense, nil
+}
+
+// adjustCardLimit moves the available limit of a card by delta: negative when a
+// purchase is charged, positive when it is removed or paid.
+func adjustCardLimit(ctx context.Context, tx pgx.Tx, cardID uuid.UUID, delta domain.Money) error {
+	query := `
+		UPDATE credit_cards
+		SET current_limit = COALESCE(current_limit, total_limit) + $1, updated_at = now()
+		WHERE "ID" = $2`
+
+	if _, err := tx.Exec(ctx, query, delta, cardID); err != nil {
+		return fmt.Errorf("failed to adjust credit card limit: %w", err)
+	}
+	return nil
+}
+
 func NewCreditCardExpenseRepository(db *pgxpool.Pool) *CreditCardExpenseRepository {
 	return &CreditCardExpenseRepository{
 		db: db,
@@ -2,7 +2,9 @@ package postgres
 
 import (
 	"context"
+	"errors"
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/misalima/my-budget-planner-backend/internal/core/domain"
 )
@@ -16,7 +18,7 @@ func NewCreditCardRepository(db *pgxpool.Pool) *CreditCardRepository {
 }
 
 func (r *CreditCardRepository) FetchAllByUserID(ctx context.Context, userID uuid.UUID) ([]domain.CreditCard, error) {
-	rows, err := r.db.Query(ctx, `SELECT "ID", user_id, card_name, total_limit, current_limit, due_date, created_at, updated_at FROM credit_cards WHERE user_id=$1`, userID)
+	rows, err := r.db.Query(ctx, `SELECT "ID", user_id, card_name, total_limit, current_limit, currency, closing_day, due_date, created_at, updated_at FROM credit_cards WHERE user_id=$1`, userID)
 	if err != nil {
 		return nil, err
 	}
@@ -25,7 +27,7 @@ func (r *CreditCardRepository) FetchAllByUserID(ctx context.Context, userID uuid
 	creditCards := []domain.CreditCard{}
 	for rows.Next() {
 		var cc domain.CreditCard
-		if err := rows.Scan(&cc.ID, &cc.UserID, &cc.CardName, &cc.TotalLimit, &cc.CurrentLimit, &cc.DueDate, &cc.CreatedAt, &cc.UpdatedAt); err != nil {
+		if err := rows.Scan(&cc.ID, &cc.UserID, &cc.CardName, &cc.TotalLimit, &cc.CurrentLimit, &cc.Currency, &cc.ClosingDay, &cc.DueDate, &cc.CreatedAt, &cc.UpdatedAt); err != nil {
 			return nil, err
 		}
 		creditCards = append(creditCards, cc)
@@ -39,18 +41,21 @@ func (r *CreditCardRepository) FetchAllByUserID(ctx context.Context, userID uuid
 }
 
 func (r *CreditCardRepository) FetchOneByID(ctx context.Context, id uuid.UUID) (*domain.CreditCard, error) {
-	row := r.db.QueryRow(ctx, `SELECT "ID", user_id, card_name, total_limit, current_limit, due_date, created_at, updated_at FROM credit_cards WHERE "ID"=$1`, id)
+	row := r.db.QueryRow(ctx, `SELECT "ID", user_id, card_name, total_limit, current_limit, currency, closing_day, due_date, created_at, updated_at FROM credit_cards WHERE "ID"=$1`, id)
 
 	var cc domain.CreditCard
-	if err := row.Scan(&cc.ID, &cc.UserID, &cc.CardName, &cc.TotalLimit, &cc.CurrentLimit, &cc.DueDate, &cc.CreatedAt, &cc.UpdatedAt); err != nil {
+	if err := row.Scan(&cc.ID, &cc.UserID, &cc.CardName, &cc.TotalLimit, &cc.CurrentLimit, &cc.Currency, &cc.ClosingDay, &cc.DueDate, &cc.CreatedAt, &cc.UpdatedAt); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrNotFound
+		}
 		return nil, err
 	}
 	return &cc, nil
 }
 
 func (r *CreditCardRepository) Create(ctx context.Context, cc *domain.CreditCard) error {
-	_, err := r.db.Exec(ctx, `INSERT INTO credit_cards ("ID", user_id, card_name, total_limit, current_limit, due_date, created_at, updated_at) VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`,
-		cc.ID, cc.UserID, cc.CardName, cc.TotalLimit, cc.CurrentLimit, cc.DueDate, cc.CreatedAt, cc.UpdatedAt)
+	_, err := r.db.Exec(ctx, `INSERT INTO credit_cards ("ID", user_id, card_name, total_limit, current_limit, currency, closing_day, due_date, created_at, updated_at) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)`,
+		cc.ID, cc.UserID, cc.CardName, cc.TotalLimit, cc.CurrentLimit, cc.Currency, cc.ClosingDay, cc.DueDate, cc.CreatedAt, cc.UpdatedAt)
 	return err
 }
 
@@ -58,3 +63,35 @@ func (r *CreditCardRepository) Delete(ctx context.Context, id uuid.UUID) error {
 	_, err := r.db.Exec(ctx, `DELETE FROM credit_cards WHERE "ID"=$1`, id)
 	return err
 }
+
+// currentLimitExpression computes the available limit of the card cc from its total
+// limit, every installment charged to it and every payment made towards it.
+const currentLimitExpression = `cc.total_limit - COALESCE((
+		SELECT SUM(COALESCE(NULLIF(e.installment_amount, 0), e.amount))
+		FROM credit_card_expense e
+		WHERE e.card_id = cc."ID"
+	), 0) + COALESCE((
+		SELECT SUM(p.amount)
+		FROM card_payments p
+		WHERE p.card_id = cc."ID"
+	), 0)`
+
+// RecomputeCurrentLimit rebuilds the available limit of a card from its total
+// limit, every installment charged to it and every payment made towards it.
+func (r *CreditCardRepository) RecomputeCurrentLimit(ctx context.Context, id uuid.UUID) (*domain.CreditCard, error) {
+	row := r.db.QueryRow(ctx, `
+		UPDATE credit_cards cc
+		SET current_limit = `+currentLimitExpression+`,
+			updated_at = now()
+		WHERE cc."ID" = $1
+		RETURNING "ID", user_id, card_name, total_limit, current_limit, currency, closing_day, due_date, created_at, updated_at`, id)
+
+	var cc domain.CreditCard
+	if err := row.Scan(&cc.ID, &cc.UserID, &cc.CardName, &cc.TotalLimit, &cc.CurrentLimit, &cc.Currency, &cc.ClosingDay, &cc.DueDate, &cc.CreatedAt, &cc.UpdatedAt); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrNotFound
+		}
+		return nil, err
+	}
+	return &cc, nil
+}
@@ -0,0 +1,164 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/misalima/my-budget-planner-backend/internal/core/domain"
+	"github.com/misalima/my-budget-planner-backend/internal/core/interfaces/irepository"
+	"time"
+)
+
+const exchangeRateColumns = `date, base_currency, quote_currency, rate, created_at, updated_at`
+
+type ExchangeRateRepository struct {
+	db *pgxpool.Pool
+}
+
+// UpsertExchangeRates stores every rate in a single transaction. A rate already
+// stored for the same pair and date is replaced.
+func (e ExchangeRateRepository) UpsertExchangeRates(ctx context.Context, rates []domain.ExchangeRate) error {
+	if len(rates) == 0 {
+		return nil
+	}
+
+	query := `
+		INSERT INTO exchange_rates (date, base_currency, quote_currency, rate, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $5)
+		ON CONFLICT (base_currency, quote_currency, date)
+		DO UPDATE SET rate = EXCLUDED.rate, updated_at = EXCLUDED.updated_at`
+
+	tx, err := e.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	batch := &pgx.Batch{}
+	now := time.Now()
+	for _, rate := range rates {
+		batch.Queue(query, rate.Date, rate.BaseCurrency, rate.QuoteCurrency, rate.Rate, now)
+	}
+
+	results := tx.SendBatch(ctx, batch)
+	for i := 0; i < len(rates); i++ {
+		if _, err := results.Exec(); err != nil {
+			results.Close()
+			return fmt.Errorf("failed to store exchange rate %d: %w", i, err)
+		}
+	}
+	if err := results.Close(); err != nil {
+		return fmt.Errorf("failed to store exchange rates: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit exchange rates: %w", err)
+	}
+
+	return nil
+}
+
+// FindLatestExchangeRate returns the most recent rate on or before date between the
+// two currencies, quoted in either direction. A rate quoted in the requested
+// direction wins over the inverse one of the same date.
+func (e ExchangeRateRepository) FindLatestExchangeRate(ctx context.Context, fromCurrency, toCurrency string, date time.Time) (domain.ExchangeRate, error) {
+	query := `
+		SELECT ` + exchangeRateColumns + `
+		FROM exchange_rates
+		WHERE ((base_currency = $1 AND quote_currency = $2) OR (base_currency = $2 AND quote_currency = $1))
+		  AND date <= $3
+		ORDER BY date DESC, base_currency = $1 DESC
+		LIMIT 1`
+
+	rate, err := scanExchangeRate(e.db.QueryRow(ctx, query, fromCurrency, toCurrency, date))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return domain.ExchangeRate{}, domain.ErrNotFound
+		}
+		return domain.ExchangeRate{}, fmt.Errorf("failed to find exchange rate: %w", err)
+	}
+
+	return rate, nil
+}
+
+func (e ExchangeRateRepository) FindExchangeRates(ctx context.Context, filters irepository.ExchangeRateFilters) ([]domain.ExchangeRate, error) {
+	query := `
+		SELECT ` + exchangeRateColumns + `
+		FROM exchange_rates
+		WHERE true`
+
+	var args []interface{}
+	argCount := 1
+
+	if filters.BaseCurrency != nil {
+		query += fmt.Sprintf(" AND base_currency = $%d", argCount)
+		args = append(args, *filters.BaseCurrency)
+		argCount++
+	}
+
+	if filters.QuoteCurrency != nil {
+		query += fmt.Sprintf(" AND quote_currency = $%d", argCount)
+		args = append(args, *filters.QuoteCurrency)
+		argCount++
+	}
+
+	if filters.StartDate != nil {
+		query += fmt.Sprintf(" AND date >= $%d", argCount)
+		args = append(args, *filters.StartDate)
+		argCount++
+	}
+
+	if filters.EndDate != nil {
+		query += fmt.Sprintf(" AND date <= $%d", argCount)
+		args = append(args, *filters.EndDate)
+		argCount++
+	}
+
+	query += " ORDER BY date DESC, base_currency, quote_currency"
+
+	if filters.Limit != nil {
+		query += fmt.Sprintf(" LIMIT $%d", argCount)
+		args = append(args, *filters.Limit)
+		argCount++
+	}
+
+	if filters.Offset != nil {
+		query += fmt.Sprintf(" OFFSET $%d", argCount)
+		args = append(args, *filters.Offset)
+		argCount++
+	}
+
+	rows, err := e.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find exchange rates: %w", err)
+	}
+	defer rows.Close()
+
+	rates := []domain.ExchangeRate{}
+	for rows.Next() {
+		rate, err := scanExchangeRate(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan exchange rate: %w", err)
+		}
+		rates = append(rates, rate)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error during rows iteration: %w", err)
+	}
+
+	return rates, nil
+}
+
+func scanExchangeRate(row pgx.Row) (domain.ExchangeRate, error) {
+	var rate domain.ExchangeRate
+	err := row.Scan(&rate.Date, &rate.BaseCurrency, &rate.QuoteCurrency, &rate.Rate, &rate.CreatedAt, &rate.UpdatedAt)
+	return rate, err
+}
+
+func NewExchangeRateRepository(db *pgxpool.Pool) *ExchangeRateRepository {
+	return &ExchangeRateRepository{
+		db: db,
+	}
+}
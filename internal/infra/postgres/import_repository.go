@@ -0,0 +1,276 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/misalima/my-budget-planner-backend/internal/core/domain"
+	"time"
+)
+
+type ImportRepository struct {
+	db *pgxpool.Pool
+}
+
+// FindImportedExternalIDs reports which of the external IDs the user already has on
+// a simple expense or an income.
+func (i ImportRepository) FindImportedExternalIDs(ctx context.Context, userID uuid.UUID, externalIDs []string) (map[string]bool, error) {
+	found := make(map[string]bool)
+	if len(externalIDs) == 0 {
+		return found, nil
+	}
+
+	query := `
+		SELECT external_id FROM simple_expense WHERE user_id = $1 AND external_id = ANY($2)
+		UNION
+		SELECT external_id FROM incomes WHERE user_id = $1 AND external_id = ANY($2)`
+
+	rows, err := i.db.Query(ctx, query, userID, externalIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find imported entries: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var externalID string
+		if err := rows.Scan(&externalID); err != nil {
+			return nil, fmt.Errorf("failed to scan imported entry: %w", err)
+		}
+		found[externalID] = true
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error during rows iteration: %w", err)
+	}
+
+	return found, nil
+}
+
+// InsertImportedEntries stores the expenses and incomes of an imported file in a
+// single transaction, so either all of them are created or none is.
+func (i ImportRepository) InsertImportedEntries(ctx context.Context, expenses []domain.SimpleExpense, incomes []domain.Income) ([]domain.SimpleExpense, []domain.Income, error) {
+	if len(expenses) == 0 && len(incomes) == 0 {
+		return expenses, incomes, nil
+	}
+
+	expenseQuery := `
+		INSERT INTO simple_expense (user_id, category_id, amount, currency, description, date, external_id, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		RETURNING "ID"`
+	incomeQuery := `
+		INSERT INTO incomes (user_id, category_id, amount, currency, description, date, frequency, end_date, external_id, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+		RETURNING "ID"`
+
+	tx, err := i.db.Begin(ctx)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	batch := &pgx.Batch{}
+	now := time.Now()
+	createdExpenses := make([]domain.SimpleExpense, len(expenses))
+	for n, expense := range expenses {
+		expense.CreatedAt = now
+		expense.UpdatedAt = now
+		createdExpenses[n] = expense
+		batch.Queue(expenseQuery,
+			expense.UserID, expense.CategoryID, expense.Amount, expense.Currency, expense.Description, expense.Date,
+			expense.ExternalID, expense.CreatedAt, expense.UpdatedAt,
+		)
+	}
+	createdIncomes := make([]domain.Income, len(incomes))
+	for n, income := range incomes {
+		income.CreatedAt = now
+		income.UpdatedAt = now
+		createdIncomes[n] = income
+		batch.Queue(incomeQuery,
+			income.UserID, income.CategoryID, income.Amount, income.Currency, income.Description, income.Date,
+			income.Frequency, income.EndDate, income.ExternalID, income.CreatedAt, income.UpdatedAt,
+		)
+	}
+
+	results := tx.SendBatch(ctx, batch)
+	for n := range createdExpenses {
+		if err := results.QueryRow().Scan(&createdExpenses[n].ID); err != nil {
+			results.Close()
+			return nil, nil, fmt.Errorf("failed to insert imported expense %d: %w", n, err)
+		}
+	}
+	for n := range createdIncomes {
+		if err := results.QueryRow().Scan(&createdIncomes[n].ID); err != nil {
+			results.Close()
+			return nil, nil, fmt.Errorf("failed to insert imported income %d: %w", n, err)
+		}
+	}
+	if err := results.Close(); err != nil {
+		return nil, nil, fmt.Errorf("failed to insert imported entries: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, nil, fmt.Errorf("failed to commit imported entries: %w", err)
+	}
+
+	return createdExpenses, createdIncomes, nil
+}
+
+// RestoreArchive stores a restored archive bundle in a single transaction. The new
+// categories are created first, so that the records can be moved onto their IDs. The
+// limit available on each restored card is computed again from the installments and
+// payments restored with it, rather than trusted from the bundle.
+func (i ImportRepository) RestoreArchive(ctx context.Context, restore domain.ArchiveRestore) error {
+	tx, err := i.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	categoryIDs := make(map[int]int, len(restore.CategoryIDs)+len(restore.NewCategories))
+	for from, to := range restore.CategoryIDs {
+		categoryIDs[from] = to
+	}
+	if len(restore.NewCategories) > 0 {
+		batch := &pgx.Batch{}
+		for _, category := range restore.NewCategories {
+			batch.Queue(`INSERT INTO categories (category_name, kind, user_id) VALUES ($1, $2, $3) RETURNING "ID"`,
+				category.Name, category.Kind, restore.UserID)
+		}
+		results := tx.SendBatch(ctx, batch)
+		for _, category := range restore.NewCategories {
+			var id int
+			if err := results.QueryRow().Scan(&id); err != nil {
+				results.Close()
+				return fmt.Errorf("failed to restore category %d: %w", category.ID, err)
+			}
+			categoryIDs[category.ID] = id
+		}
+		if err := results.Close(); err != nil {
+			return fmt.Errorf("failed to restore categories: %w", err)
+		}
+	}
+
+	cardQuery := `
+		INSERT INTO credit_cards ("ID", user_id, card_name, total_limit, current_limit, currency, closing_day, due_date, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)`
+	simpleQuery := `
+		INSERT INTO simple_expense ("ID", user_id, category_id, amount, currency, description, date, external_id, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)`
+	recurringQuery := `
+		INSERT INTO recurring_expense ("ID", user_id, category_id, amount, currency, description, date, card_id, start_date, end_date, frequency, template_id, generated_through, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15)`
+	cardExpenseQuery := `
+		INSERT INTO credit_card_expense ("ID", user_id, category_id, amount, currency, description, date, card_id, installment_amount, installments_quantity, parcel_number, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)`
+	incomeQuery := `
+		INSERT INTO incomes ("ID", user_id, category_id, amount, currency, description, date, frequency, end_date, external_id, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)`
+	cardPaymentQuery := `
+		INSERT INTO card_payments ("ID", user_id, card_id, statement_month, amount, paid_at, description, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)`
+
+	batch := &pgx.Batch{}
+	for _, cc := range restore.CreditCards {
+		batch.Queue(cardQuery,
+			cc.ID, restore.UserID, cc.CardName, cc.TotalLimit, cc.CurrentLimit, cc.Currency, cc.ClosingDay, cc.DueDate,
+			cc.CreatedAt, cc.UpdatedAt,
+		)
+	}
+	for _, e := range restore.SimpleExpenses {
+		batch.Queue(simpleQuery,
+			e.ID, restore.UserID, categoryIDs[e.CategoryID], e.Amount, e.Currency, e.Description, e.Date, e.ExternalID,
+			e.CreatedAt, e.UpdatedAt,
+		)
+	}
+	for _, e := range restore.RecurringExpenses {
+		batch.Queue(recurringQuery,
+			e.ID, restore.UserID, categoryIDs[e.CategoryID], e.Amount, e.Currency, e.Description, e.Date, e.CardID,
+			e.StartDate, e.EndDate, e.Frequency, e.TemplateID, e.GeneratedThrough, e.CreatedAt, e.UpdatedAt,
+		)
+	}
+	for _, e := range restore.CreditCardExpenses {
+		batch.Queue(cardExpenseQuery,
+			e.ID, restore.UserID, categoryIDs[e.CategoryID], e.Amount, e.Currency, e.Description, e.Date, e.CardID,
+			e.InstallmentAmount, e.InstallmentsQuantity, e.ParcelNumber, e.CreatedAt, e.UpdatedAt,
+		)
+	}
+	for _, income := range restore.Incomes {
+		batch.Queue(incomeQuery,
+			income.ID, restore.UserID, categoryIDs[income.CategoryID], income.Amount, income.Currency, income.Description, income.Date,
+			income.Frequency, income.EndDate, income.ExternalID, income.CreatedAt, income.UpdatedAt,
+		)
+	}
+	for _, p := range restore.CardPayments {
+		batch.Queue(cardPaymentQuery,
+			p.ID, restore.UserID, p.CardID, p.StatementMonth, p.Amount, p.PaidAt, p.Description, p.CreatedAt, p.UpdatedAt,
+		)
+	}
+
+	results := tx.SendBatch(ctx, batch)
+	for n := 0; n < batch.Len(); n++ {
+		if _, err := results.Exec(); err != nil {
+			results.Close()
+			return fmt.Errorf("failed to restore archive record %d: %w", n, err)
+		}
+	}
+	if err := results.Close(); err != nil {
+		return fmt.Errorf("failed to restore archive: %w", err)
+	}
+
+	for _, b := range restore.Budgets {
+		var budgetID int
+		err := tx.QueryRow(ctx, `
+			INSERT INTO budgets (user_id, budget_name, description, amount, period, rollover, start_date, end_date, created_at, updated_at)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+			RETURNING "ID"`,
+			restore.UserID, b.Name, b.Description, b.Amount, b.Period, b.Rollover, b.StartDate, b.EndDate, b.CreatedAt, b.UpdatedAt,
+		).Scan(&budgetID)
+		if err != nil {
+			return fmt.Errorf("failed to restore budget %d: %w", b.ID, err)
+		}
+		budgetCategoryIDs := make([]int, len(b.CategoryIDs))
+		for n, id := range b.CategoryIDs {
+			budgetCategoryIDs[n] = categoryIDs[id]
+		}
+		if err := replaceBudgetCategories(ctx, tx, budgetID, budgetCategoryIDs); err != nil {
+			return err
+		}
+	}
+
+	// Bundles exported before templates recorded how far they were generated restore them
+	// as generated through their last restored occurrence.
+	_, err = tx.Exec(ctx, `
+		UPDATE recurring_expense t
+		SET generated_through = (SELECT max(o.date) FROM recurring_expense o WHERE o.template_id = t."ID")
+		WHERE t.user_id = $1 AND t.template_id IS NULL AND t.generated_through IS NULL`, restore.UserID)
+	if err != nil {
+		return fmt.Errorf("failed to restore recurring expense generation: %w", err)
+	}
+
+	if len(restore.CreditCards) > 0 {
+		cardIDs := make([]uuid.UUID, len(restore.CreditCards))
+		for n, cc := range restore.CreditCards {
+			cardIDs[n] = cc.ID
+		}
+		_, err := tx.Exec(ctx, `
+			UPDATE credit_cards cc
+			SET current_limit = `+currentLimitExpression+`
+			WHERE cc."ID" = ANY($1)`, cardIDs)
+		if err != nil {
+			return fmt.Errorf("failed to recompute restored card limits: %w", err)
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit restored archive: %w", err)
+	}
+	return nil
+}
+
+func NewImportRepository(db *pgxpool.Pool) *ImportRepository {
+	return &ImportRepository{
+		db: db,
+	}
+}
@@ -0,0 +1,202 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/misalima/my-budget-planner-backend/internal/core/domain"
+	"github.com/misalima/my-budget-planner-backend/internal/core/interfaces/irepository"
+	"time"
+)
+
+const incomeColumns = `"ID", user_id, category_id, amount, currency, description, date, frequency, end_date, external_id, created_at, updated_at`
+
+type IncomeRepository struct {
+	db *pgxpool.Pool
+}
+
+func (i IncomeRepository) InsertIncome(ctx context.Context, income domain.Income) (domain.Income, error) {
+	query := `
+		INSERT INTO incomes (user_id, category_id, amount, currency, description, date, frequency, end_date, external_id, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+		RETURNING "ID"`
+
+	now := time.Now()
+	income.CreatedAt = now
+	income.UpdatedAt = now
+
+	err := i.db.QueryRow(ctx, query,
+		income.UserID, income.CategoryID, income.Amount, income.Currency, income.Description, income.Date,
+		income.Frequency, income.EndDate, income.ExternalID, income.CreatedAt, income.UpdatedAt,
+	).Scan(&income.ID)
+	if err != nil {
+		return domain.Income{}, fmt.Errorf("failed to insert income: %w", err)
+	}
+
+	return income, nil
+}
+
+func (i IncomeRepository) UpdateIncome(ctx context.Context, income domain.Income) (domain.Income, error) {
+	query := `
+		UPDATE incomes
+		SET category_id = $1, amount = $2, currency = $3, description = $4, date = $5, frequency = $6, end_date = $7, updated_at = $8
+		WHERE "ID" = $9 AND user_id = $10
+		RETURNING ` + incomeColumns
+
+	updated, err := scanIncome(i.db.QueryRow(ctx, query,
+		income.CategoryID, income.Amount, income.Currency, income.Description, income.Date, income.Frequency, income.EndDate,
+		time.Now(), income.ID, income.UserID,
+	))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return domain.Income{}, domain.ErrNotFound
+		}
+		return domain.Income{}, fmt.Errorf("failed to update income: %w", err)
+	}
+
+	return updated, nil
+}
+
+func (i IncomeRepository) DeleteIncome(ctx context.Context, id uuid.UUID) error {
+	query := `DELETE FROM incomes WHERE "ID" = $1`
+
+	result, err := i.db.Exec(ctx, query, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete income: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return domain.ErrNotFound
+	}
+
+	return nil
+}
+
+func (i IncomeRepository) FindIncomeByID(ctx context.Context, id uuid.UUID) (domain.Income, error) {
+	query := `SELECT ` + incomeColumns + ` FROM incomes WHERE "ID" = $1`
+
+	income, err := scanIncome(i.db.QueryRow(ctx, query, id))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return domain.Income{}, domain.ErrNotFound
+		}
+		return domain.Income{}, fmt.Errorf("failed to find income: %w", err)
+	}
+
+	return income, nil
+}
+
+func (i IncomeRepository) FindIncomes(ctx context.Context, userID uuid.UUID, filters irepository.IncomeFilters) ([]domain.Income, error) {
+	query := `SELECT ` + incomeColumns + ` FROM incomes WHERE user_id = $1`
+
+	var args []interface{}
+	args = append(args, userID)
+	argCount := 2
+
+	if filters.CategoryID != nil {
+		query += fmt.Sprintf(" AND category_id = $%d", argCount)
+		args = append(args, *filters.CategoryID)
+		argCount++
+	}
+
+	if filters.Recurring != nil {
+		if *filters.Recurring {
+			query += " AND frequency IS NOT NULL"
+		} else {
+			query += " AND frequency IS NULL"
+		}
+	}
+
+	if filters.StartDate != nil {
+		query += fmt.Sprintf(" AND date >= $%d", argCount)
+		args = append(args, *filters.StartDate)
+		argCount++
+	}
+
+	if filters.EndDate != nil {
+		query += fmt.Sprintf(" AND date <= $%d", argCount)
+		args = append(args, *filters.EndDate)
+		argCount++
+	}
+
+	if filters.MinAmount != nil {
+		query += fmt.Sprintf(" AND amount >= $%d", argCount)
+		args = append(args, *filters.MinAmount)
+		argCount++
+	}
+
+	if filters.MaxAmount != nil {
+		query += fmt.Sprintf(" AND amount <= $%d", argCount)
+		args = append(args, *filters.MaxAmount)
+		argCount++
+	}
+
+	query += " ORDER BY date DESC, created_at DESC"
+
+	if filters.Limit != nil {
+		query += fmt.Sprintf(" LIMIT $%d", argCount)
+		args = append(args, *filters.Limit)
+		argCount++
+	}
+
+	if filters.Offset != nil {
+		query += fmt.Sprintf(" OFFSET $%d", argCount)
+		args = append(args, *filters.Offset)
+		argCount++
+	}
+
+	return i.queryIncomes(ctx, query, args...)
+}
+
+// FindIncomesByDateRange returns the one-off incomes received inside the range and
+// the recurring incomes active at some point of it.
+func (i IncomeRepository) FindIncomesByDateRange(ctx context.Context, userID uuid.UUID, startDate, endDate time.Time) ([]domain.Income, error) {
+	query := `
+		SELECT ` + incomeColumns + `
+		FROM incomes
+		WHERE user_id = $1 AND date <= $3
+		  AND ((frequency IS NULL AND date >= $2) OR (frequency IS NOT NULL AND (end_date IS NULL OR end_date >= $2)))
+		ORDER BY date DESC, created_at DESC`
+
+	return i.queryIncomes(ctx, query, userID, startDate, endDate)
+}
+
+func (i IncomeRepository) queryIncomes(ctx context.Context, query string, args ...interface{}) ([]domain.Income, error) {
+	rows, err := i.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find incomes: %w", err)
+	}
+	defer rows.Close()
+
+	incomes := []domain.Income{}
+	for rows.Next() {
+		income, err := scanIncome(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan income: %w", err)
+		}
+		incomes = append(incomes, income)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error during rows iteration: %w", err)
+	}
+
+	return incomes, nil
+}
+
+func scanIncome(row pgx.Row) (domain.Income, error) {
+	var income domain.Income
+	err := row.Scan(
+		&income.ID, &income.UserID, &income.CategoryID, &income.Amount, &income.Currency, &income.Description, &income.Date,
+		&income.Frequency, &income.EndDate, &income.ExternalID, &income.CreatedAt, &income.UpdatedAt,
+	)
+	return income, err
+}
+
+func NewIncomeRepository(db *pgxpool.Pool) *IncomeRepository {
+	return &IncomeRepository{
+		db: db,
+	}
+}
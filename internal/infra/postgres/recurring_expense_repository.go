@@ -2,6 +2,7 @@ package postgres
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
@@ -18,8 +19,8 @@ type RecurringExpenseRepository struct {
 
 func (r RecurringExpenseRepository) InsertRecurringExpense(ctx context.Context, expense domain.RecurringExpense) (domain.RecurringExpense, error) {
 	query := `
-		INSERT INTO recurring_expense (user_id, category_id, amount, description, date, card_id, start_date, end_date, frequency, created_at, updated_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+		INSERT INTO recurring_expense (user_id, category_id, amount, currency, description, date, card_id, start_date, end_date, frequency, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
 		RETURNING "ID"`
 
 	now := time.Now()
@@ -30,6 +31,7 @@ func (r RecurringExpenseRepository) InsertRecurringExpense(ctx context.Context,
 		expense.UserID,
 		expense.CategoryID,
 		expense.Amount,
+		expense.Currency,
 		expense.Description,
 		expense.Date,
 		expense.CardID,
@@ -62,6 +64,11 @@ func (r RecurringExpenseRepository) UpdateRecurringExpense(ctx context.Context,
 		args = append(args, expense.Amount)
 		argCount++
 	}
+	if expense.Currency != "" {
+		query += "currency = $" + strconv.Itoa(argCount) + ", "
+		args = append(args, expense.Currency)
+		argCount++
+	}
 	if expense.Description != nil {
 		query += "description = $" + strconv.Itoa(argCount) + ", "
 		args = append(args, *expense.Description)
@@ -99,13 +106,13 @@ func (r RecurringExpenseRepository) UpdateRecurringExpense(ctx context.Context,
 	query += " WHERE \"ID\" = $" + strconv.Itoa(argCount) + " AND user_id = $" + strconv.Itoa(argCount+1)
 	args = append(args, expense.ID, expense.UserID)
 
-	query += " RETURNING \"ID\", user_id, category_id, amount, description, date, card_id, start_date, end_date, frequency, created_at, updated_at"
+	query += " RETURNING \"ID\", user_id, category_id, amount, currency, description, date, card_id, start_date, end_date, frequency, template_id, generated_through, created_at, updated_at"
 
 	row := r.db.QueryRow(ctx, query, args...)
 
 	err := row.Scan(
-		&expense.ID, &expense.UserID, &expense.CategoryID, &expense.Amount, &expense.Description,
-		&expense.Date, &expense.CardID, &expense.StartDate, &expense.EndDate, &expense.Frequency,
+		&expense.ID, &expense.UserID, &expense.CategoryID, &expense.Amount, &expense.Currency, &expense.Description,
+		&expense.Date, &expense.CardID, &expense.StartDate, &expense.EndDate, &expense.Frequency, &expense.TemplateID, &expense.GeneratedThrough,
 		&expense.CreatedAt, &expense.UpdatedAt,
 	)
 
@@ -136,15 +143,15 @@ func (r RecurringExpenseRepository) DeleteRecurringExpense(ctx context.Context,
 
 func (r RecurringExpenseRepository) FindRecurringExpenseByID(ctx context.Context, id uuid.UUID) (domain.RecurringExpense, error) {
 	query := `
-		SELECT "ID", user_id, category_id, amount, description, date, card_id, start_date, end_date, frequency, created_at, updated_at
+		SELECT "ID", user_id, category_id, amount, currency, description, date, card_id, start_date, end_date, frequency, template_id, generated_through, created_at, updated_at
 		FROM recurring_expense 
 		WHERE "ID" = $1`
 
 	var expense domain.RecurringExpense
 
 	err := r.db.QueryRow(ctx, query, id).Scan(
-		&expense.ID, &expense.UserID, &expense.CategoryID, &expense.Amount, &expense.Description,
-		&expense.Date, &expense.CardID, &expense.StartDate, &expense.EndDate, &expense.Frequency,
+		&expense.ID, &expense.UserID, &expense.CategoryID, &expense.Amount, &expense.Currency, &expense.Description,
+		&expense.Date, &expense.CardID, &expense.StartDate, &expense.EndDate, &expense.Frequency, &expense.TemplateID, &expense.GeneratedThrough,
 		&expense.CreatedAt, &expense.UpdatedAt,
 	)
 
@@ -160,7 +167,7 @@ func (r RecurringExpenseRepository) FindRecurringExpenseByID(ctx context.Context
 
 func (r RecurringExpenseRepository) FindRecurringExpenses(ctx context.Context, userID uuid.UUID, filters irepository.RecurringExpenseFilters) ([]domain.RecurringExpense, error) {
 	query := `
-		SELECT "ID", user_id, category_id, amount, description, date, card_id, start_date, end_date, frequency, created_at, updated_at
+		SELECT "ID", user_id, category_id, amount, currency, description, date, card_id, start_date, end_date, frequency, template_id, generated_through, created_at, updated_at
 		FROM recurring_expense 
 		WHERE user_id = $1`
 
@@ -168,6 +175,16 @@ func (r RecurringExpenseRepository) FindRecurringExpenses(ctx context.Context, u
 	args = append(args, userID)
 	argCount := 2
 
+	if filters.TemplateID != nil {
+		query += fmt.Sprintf(" AND template_id = $%d", argCount)
+		args = append(args, *filters.TemplateID)
+		argCount++
+	} else if filters.Generated {
+		query += " AND template_id IS NOT NULL"
+	} else {
+		query += " AND template_id IS NULL"
+	}
+
 	if filters.CategoryID != nil {
 		query += fmt.Sprintf(" AND category_id = $%d", argCount)
 		args = append(args, *filters.CategoryID)
@@ -234,8 +251,8 @@ func (r RecurringExpenseRepository) FindRecurringExpenses(ctx context.Context, u
 	for rows.Next() {
 		var expense domain.RecurringExpense
 		err := rows.Scan(
-			&expense.ID, &expense.UserID, &expense.CategoryID, &expense.Amount, &expense.Description,
-			&expense.Date, &expense.CardID, &expense.StartDate, &expense.EndDate, &expense.Frequency,
+			&expense.ID, &expense.UserID, &expense.CategoryID, &expense.Amount, &expense.Currency, &expense.Description,
+			&expense.Date, &expense.CardID, &expense.StartDate, &expense.EndDate, &expense.Frequency, &expense.TemplateID, &expense.GeneratedThrough,
 			&expense.CreatedAt, &expense.UpdatedAt,
 		)
 		if err != nil {
@@ -253,7 +270,7 @@ func (r RecurringExpenseRepository) FindRecurringExpenses(ctx context.Context, u
 
 func (r RecurringExpenseRepository) FindRecurringExpensesByUser(ctx context.Context, userID uuid.UUID) ([]domain.RecurringExpense, error) {
 	query := `
-		SELECT "ID", user_id, category_id, amount, description, date, card_id, start_date, end_date, frequency, created_at, updated_at
+		SELECT "ID", user_id, category_id, amount, currency, description, date, card_id, start_date, end_date, frequency, template_id, generated_through, created_at, updated_at
 		FROM recurring_expense 
 		WHERE user_id = $1
 		ORDER BY start_date DESC, created_at DESC`
@@ -268,8 +285,8 @@ func (r RecurringExpenseRepository) FindRecurringExpensesByUser(ctx context.Cont
 	for rows.Next() {
 		var expense domain.RecurringExpense
 		err := rows.Scan(
-			&expense.ID, &expense.UserID, &expense.CategoryID, &expense.Amount, &expense.Description,
-			&expense.Date, &expense.CardID, &expense.StartDate, &expense.EndDate, &expense.Frequency,
+			&expense.ID, &expense.UserID, &expense.CategoryID, &expense.Amount, &expense.Currency, &expense.Description,
+			&expense.Date, &expense.CardID, &expense.StartDate, &expense.EndDate, &expense.Frequency, &expense.TemplateID, &expense.GeneratedThrough,
 			&expense.CreatedAt, &expense.UpdatedAt,
 		)
 		if err != nil {
@@ -287,9 +304,9 @@ func (r RecurringExpenseRepository) FindRecurringExpensesByUser(ctx context.Cont
 
 func (r RecurringExpenseRepository) FindRecurringExpensesByDateRange(ctx context.Context, userID uuid.UUID, startDate, endDate time.Time) ([]domain.RecurringExpense, error) {
 	query := `
-		SELECT "ID", user_id, category_id, amount, description, date, card_id, start_date, end_date, frequency, created_at, updated_at
+		SELECT "ID", user_id, category_id, amount, currency, description, date, card_id, start_date, end_date, frequency, template_id, generated_through, created_at, updated_at
 		FROM recurring_expense 
-		WHERE user_id = $1 AND start_date >= $2 AND (end_date IS NULL OR end_date <= $3)
+		WHERE user_id = $1 AND template_id IS NULL AND start_date >= $2 AND (end_date IS NULL OR end_date <= $3)
 		ORDER BY start_date DESC, created_at DESC`
 
 	rows, err := r.db.Query(ctx, query, userID, startDate, endDate)
@@ -302,8 +319,44 @@ func (r RecurringExpenseRepository) FindRecurringExpensesByDateRange(ctx context
 	for rows.Next() {
 		var expense domain.RecurringExpense
 		err := rows.Scan(
-			&expense.ID, &expense.UserID, &expense.CategoryID, &expense.Amount, &expense.Description,
-			&expense.Date, &expense.CardID, &expense.StartDate, &expense.EndDate, &expense.Frequency,
+			&expense.ID, &expense.UserID, &expense.CategoryID, &expense.Amount, &expense.Currency, &expense.Description,
+			&expense.Date, &expense.CardID, &expense.StartDate, &expense.EndDate, &expense.Frequency, &expense.TemplateID, &expense.GeneratedThrough,
+			&expense.CreatedAt, &expense.UpdatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan recurring expense: %w", err)
+		}
+		expenses = append(expenses, expense)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error during rows iteration: %w", err)
+	}
+
+	return expenses, nil
+}
+
+// FindGeneratedRecurringExpensesByDateRange returns the stored occurrences of the user's
+// recurring expenses dated inside [startDate, endDate], as the user left them.
+func (r RecurringExpenseRepository) FindGeneratedRecurringExpensesByDateRange(ctx context.Context, userID uuid.UUID, startDate, endDate time.Time) ([]domain.RecurringExpense, error) {
+	query := `
+		SELECT "ID", user_id, category_id, amount, currency, description, date, card_id, start_date, end_date, frequency, template_id, generated_through, created_at, updated_at
+		FROM recurring_expense
+		WHERE user_id = $1 AND template_id IS NOT NULL AND date >= $2 AND date <= $3
+		ORDER BY date DESC, created_at DESC`
+
+	rows, err := r.db.Query(ctx, query, userID, startDate, endDate)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find generated recurring expenses by date range: %w", err)
+	}
+	defer rows.Close()
+
+	var expenses []domain.RecurringExpense
+	for rows.Next() {
+		var expense domain.RecurringExpense
+		err := rows.Scan(
+			&expense.ID, &expense.UserID, &expense.CategoryID, &expense.Amount, &expense.Currency, &expense.Description,
+			&expense.Date, &expense.CardID, &expense.StartDate, &expense.EndDate, &expense.Frequency, &expense.TemplateID, &expense.GeneratedThrough,
 			&expense.CreatedAt, &expense.UpdatedAt,
 		)
 		if err != nil {
@@ -319,36 +372,110 @@ func (r RecurringExpenseRepository) FindRecurringExpensesByDateRange(ctx context
 	return expenses, nil
 }
 
-func (r RecurringExpenseRepository) InsertGeneratedRecurringExpenses(ctx context.Context, expenses []domain.RecurringExpense) error {
+// StoreGeneratedRecurringExpenses stores occurrences generated from the template and moves
+// its generated_through forward to through, in a single transaction. Occurrences dated on
+// or before the generated_through the template already had are dropped, so a concurrent
+// or repeated run never brings back an occurrence the user deleted or moved. It returns
+// how many rows were inserted.
+func (r RecurringExpenseRepository) StoreGeneratedRecurringExpenses(ctx context.Context, templateID uuid.UUID, expenses []domain.RecurringExpense, through time.Time) (int, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	var generatedThrough *time.Time
+	err = tx.QueryRow(ctx, `SELECT generated_through FROM recurring_expense WHERE "ID" = $1 AND template_id IS NULL FOR UPDATE`, templateID).
+		Scan(&generatedThrough)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return 0, domain.ErrNotFound
+		}
+		return 0, fmt.Errorf("failed to lock recurring expense template: %w", err)
+	}
+	if generatedThrough != nil && !through.After(*generatedThrough) {
+		return 0, nil
+	}
+
+	var pending []domain.RecurringExpense
+	for _, expense := range expenses {
+		if generatedThrough == nil || expense.Date.After(*generatedThrough) {
+			pending = append(pending, expense)
+		}
+	}
+	inserted, err := insertGeneratedRecurringExpenses(ctx, tx, pending)
+	if err != nil {
+		return 0, err
+	}
+	if _, err := tx.Exec(ctx, `UPDATE recurring_expense SET generated_through = $2 WHERE "ID" = $1`, templateID, through); err != nil {
+		return 0, fmt.Errorf("failed to record generated recurring expenses: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return 0, fmt.Errorf("failed to commit generated recurring expenses: %w", err)
+	}
+	return inserted, nil
+}
+
+// ReplaceGeneratedRecurringExpensesFrom deletes the occurrences of the template dated on
+// or after from and stores expenses in their place, in a single transaction.
+func (r RecurringExpenseRepository) ReplaceGeneratedRecurringExpensesFrom(ctx context.Context, templateID uuid.UUID, from time.Time, expenses []domain.RecurringExpense) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err := tx.Exec(ctx, `DELETE FROM recurring_expense WHERE template_id = $1 AND date >= $2`, templateID, from); err != nil {
+		return fmt.Errorf("failed to delete generated recurring expenses: %w", err)
+	}
+	if _, err := insertGeneratedRecurringExpenses(ctx, tx, expenses); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit regenerated recurring expenses: %w", err)
+	}
+	return nil
+}
+
+// insertGeneratedRecurringExpenses stores generated occurrences, skipping the ones that
+// were already generated for the same template and date. It returns how many rows were
+// inserted.
+func insertGeneratedRecurringExpenses(ctx context.Context, tx pgx.Tx, expenses []domain.RecurringExpense) (int, error) {
 	if len(expenses) == 0 {
-		return nil
+		return 0, nil
 	}
 
 	query := `
-		INSERT INTO recurring_expense (user_id, category_id, amount, description, date, card_id, start_date, end_date, frequency, created_at, updated_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)`
+		INSERT INTO recurring_expense (user_id, category_id, amount, currency, description, date, card_id, start_date, end_date, frequency, template_id, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
+		ON CONFLICT (template_id, date) WHERE template_id IS NOT NULL DO NOTHING`
 
 	batch := &pgx.Batch{}
 	now := time.Now()
 
 	for _, expense := range expenses {
 		batch.Queue(query,
-			expense.UserID, expense.CategoryID, expense.Amount, expense.Description, expense.Date,
-			expense.CardID, expense.StartDate, expense.EndDate, expense.Frequency, now, now,
+			expense.UserID, expense.CategoryID, expense.Amount, expense.Currency, expense.Description, expense.Date,
+			expense.CardID, expense.StartDate, expense.EndDate, expense.Frequency, expense.TemplateID, now, now,
 		)
 	}
 
-	results := r.db.SendBatch(ctx, batch)
-	defer results.Close()
-
+	results := tx.SendBatch(ctx, batch)
+	inserted := 0
 	for i := 0; i < len(expenses); i++ {
-		_, err := results.Exec()
+		tag, err := results.Exec()
 		if err != nil {
-			return fmt.Errorf("failed to insert generated recurring expense %d: %w", i, err)
+			results.Close()
+			return 0, fmt.Errorf("failed to insert generated recurring expense %d: %w", i, err)
 		}
+		inserted += int(tag.RowsAffected())
 	}
-
-	return nil
+	if err := results.Close(); err != nil {
+		return 0, fmt.Errorf("failed to insert generated recurring expenses: %w", err)
+	}
+	return inserted, nil
 }
 
 func NewRecurringExpenseRepository(db *pgxpool.Pool) *RecurringExpenseRepository {
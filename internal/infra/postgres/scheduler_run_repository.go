@@ -0,0 +1,46 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"time"
+)
+
+type SchedulerRunRepository struct {
+	db *pgxpool.Pool
+}
+
+// LastCompletedSlot returns the latest slot the job completed, or the zero time when it
+// never completed one.
+func (r SchedulerRunRepository) LastCompletedSlot(ctx context.Context, job string) (time.Time, error) {
+	var slot time.Time
+	err := r.db.QueryRow(ctx, `SELECT last_slot FROM scheduler_runs WHERE job_name = $1`, job).Scan(&slot)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return time.Time{}, nil
+		}
+		return time.Time{}, fmt.Errorf("failed to get last scheduler run: %w", err)
+	}
+	return slot, nil
+}
+
+func (r SchedulerRunRepository) RecordCompletedSlot(ctx context.Context, job string, slot time.Time) error {
+	query := `
+		INSERT INTO scheduler_runs (job_name, last_slot, finished_at)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (job_name) DO UPDATE SET
+			last_slot = GREATEST(scheduler_runs.last_slot, EXCLUDED.last_slot), finished_at = EXCLUDED.finished_at`
+
+	if _, err := r.db.Exec(ctx, query, job, slot, time.Now()); err != nil {
+		return fmt.Errorf("failed to record scheduler run: %w", err)
+	}
+	return nil
+}
+
+func NewSchedulerRunRepository(db *pgxpool.Pool) *SchedulerRunRepository {
+	return &SchedulerRunRepository{
+		db: db,
+	}
+}
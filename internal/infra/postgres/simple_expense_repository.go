@@ -18,8 +18,8 @@ type SimpleExpenseRepository struct {
 
 func (s SimpleExpenseRepository) InsertSimpleExpense(ctx context.Context, expense domain.SimpleExpense) (domain.SimpleExpense, error) {
 	query := `
-		INSERT INTO simple_expense (user_id, category_id, amount, description, date, created_at, updated_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		INSERT INTO simple_expense (user_id, category_id, amount, currency, description, date, external_id, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
 		RETURNING "ID"`
 
 	now := time.Now()
@@ -30,8 +30,10 @@ func (s SimpleExpenseRepository) InsertSimpleExpense(ctx context.Context, expens
 		expense.UserID,
 		expense.CategoryID,
 		expense.Amount,
+		expense.Currency,
 		expense.Description,
 		expense.Date,
+		expense.ExternalID,
 		expense.CreatedAt,
 		expense.UpdatedAt,
 	).Scan(&expense.ID)
@@ -58,6 +60,11 @@ func (s SimpleExpenseRepository) UpdateSimpleExpense(ctx context.Context, expens
 		args = append(args, expense.Amount)
 		argCount++
 	}
+	if expense.Currency != "" {
+		query += "currency = $" + strconv.Itoa(argCount) + ", "
+		args = append(args, expense.Currency)
+		argCount++
+	}
 	if expense.Description != nil {
 		query += "description = $" + strconv.Itoa(argCount) + ", "
 		args = append(args, *expense.Description)
@@ -75,7 +82,7 @@ func (s SimpleExpenseRepository) UpdateSimpleExpense(ctx context.Context, expens
 	query += " WHERE \"ID\" = $" + strconv.Itoa(argCount) + " AND user_id = $" + strconv.Itoa(argCount+1)
 	args = append(args, expense.ID, expense.UserID)
 
-	query += " RETURNING \"ID\", user_id, category_id, amount, description, date, created_at, updated_at"
+	query += " RETURNING \"ID\", user_id, category_id, amount, currency, description, date, external_id, created_at, updated_at"
 
 	row := s.db.QueryRow(ctx, query, args...)
 
@@ -84,8 +91,10 @@ func (s SimpleExpenseRepository) UpdateSimpleExpense(ctx context.Context, expens
 		&expense.UserID,
 		&expense.CategoryID,
 		&expense.Amount,
+		&expense.Currency,
 		&expense.Description,
 		&expense.Date,
+		&expense.ExternalID,
 		&expense.CreatedAt,
 		&expense.UpdatedAt,
 	)
@@ -118,7 +127,7 @@ func (s SimpleExpenseRepository) DeleteSimpleExpense(ctx context.Context, expens
 
 func (s SimpleExpenseRepository) FindSimpleExpenseByID(ctx context.Context, expenseId uuid.UUID) (domain.SimpleExpense, error) {
 	query := `
-		SELECT "ID", user_id, category_id, amount, description, date, created_at, updated_at
+		SELECT "ID", user_id, category_id, amount, currency, description, date, external_id, created_at, updated_at
 		FROM simple_expense 
 		WHERE "ID" = $1`
 
@@ -129,8 +138,10 @@ func (s SimpleExpenseRepository) FindSimpleExpenseByID(ctx context.Context, expe
 		&expense.UserID,
 		&expense.CategoryID,
 		&expense.Amount,
+		&expense.Currency,
 		&expense.Description,
 		&expense.Date,
+		&expense.ExternalID,
 		&expense.CreatedAt,
 		&expense.UpdatedAt,
 	)
@@ -147,7 +158,7 @@ func (s SimpleExpenseRepository) FindSimpleExpenseByID(ctx context.Context, expe
 
 func (s SimpleExpenseRepository) FindSimpleExpenses(ctx context.Context, userId uuid.UUID, filters irepository.SimpleExpenseFilters) ([]domain.SimpleExpense, error) {
 	query := `
-		SELECT "ID", user_id, category_id, amount, description, date, created_at, updated_at
+		SELECT "ID", user_id, category_id, amount, currency, description, date, external_id, created_at, updated_at
 		FROM simple_expense 
 		WHERE user_id = $1`
 
@@ -213,8 +224,10 @@ func (s SimpleExpenseRepository) FindSimpleExpenses(ctx context.Context, userId
 			&expense.UserID,
 			&expense.CategoryID,
 			&expense.Amount,
+			&expense.Currency,
 			&expense.Description,
 			&expense.Date,
+			&expense.ExternalID,
 			&expense.CreatedAt,
 			&expense.UpdatedAt,
 		)
@@ -233,7 +246,7 @@ func (s SimpleExpenseRepository) FindSimpleExpenses(ctx context.Context, userId
 
 func (s SimpleExpenseRepository) FindSimpleExpensesByUser(ctx context.Context, userId uuid.UUID) ([]domain.SimpleExpense, error) {
 	query := `
-		SELECT "ID", user_id, category_id, amount, description, date, created_at, updated_at
+		SELECT "ID", user_id, category_id, amount, currency, description, date, external_id, created_at, updated_at
 		FROM simple_expense 
 		WHERE user_id = $1
 		ORDER BY date DESC, created_at DESC`
@@ -248,8 +261,8 @@ func (s SimpleExpenseRepository) FindSimpleExpensesByUser(ctx context.Context, u
 	for rows.Next() {
 		var expense domain.SimpleExpense
 		err := rows.Scan(
-			&expense.ID, &expense.UserID, &expense.CategoryID, &expense.Amount,
-			&expense.Description, &expense.Date, &expense.CreatedAt, &expense.UpdatedAt,
+			&expense.ID, &expense.UserID, &expense.CategoryID, &expense.Amount, &expense.Currency,
+			&expense.Description, &expense.Date, &expense.ExternalID, &expense.CreatedAt, &expense.UpdatedAt,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan simple expense: %w", err)
@@ -266,7 +279,7 @@ func (s SimpleExpenseRepository) FindSimpleExpensesByUser(ctx context.Context, u
 
 func (s SimpleExpenseRepository) FindSimpleExpensesByDateRange(ctx context.Context, userId uuid.UUID, startDate, endDate time.Time) ([]domain.SimpleExpense, error) {
 	query := `
-		SELECT "ID", user_id, category_id, amount, description, date, created_at, updated_at
+		SELECT "ID", user_id, category_id, amount, currency, description, date, external_id, created_at, updated_at
 		FROM simple_expense 
 		WHERE user_id = $1 AND date >= $2 AND date <= $3
 		ORDER BY date DESC, created_at DESC`
@@ -281,8 +294,8 @@ func (s SimpleExpenseRepository) FindSimpleExpensesByDateRange(ctx context.Conte
 	for rows.Next() {
 		var expense domain.SimpleExpense
 		err := rows.Scan(
-			&expense.ID, &expense.UserID, &expense.CategoryID, &expense.Amount,
-			&expense.Description, &expense.Date, &expense.CreatedAt, &expense.UpdatedAt,
+			&expense.ID, &expense.UserID, &expense.CategoryID, &expense.Amount, &expense.Currency,
+			&expense.Description, &expense.Date, &expense.ExternalID, &expense.CreatedAt, &expense.UpdatedAt,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan simple expense: %w", err)
@@ -0,0 +1,203 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/misalima/my-budget-planner-backend/internal/core/domain"
+	"time"
+)
+
+type TOTPRepository struct {
+	db *pgxpool.Pool
+}
+
+// StoreTOTP starts an enrollment, replacing one that was never confirmed. A confirmed
+// factor is never replaced: ErrTOTPAlreadyEnabled is returned instead.
+func (t TOTPRepository) StoreTOTP(ctx context.Context, totp domain.TOTP) error {
+	query := `
+		INSERT INTO user_totp (user_id, secret, created_at)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (user_id) DO UPDATE SET
+			secret = EXCLUDED.secret, created_at = EXCLUDED.created_at,
+			last_used_step = 0, failed_attempts = 0, locked_until = NULL
+		WHERE user_totp.confirmed_at IS NULL`
+
+	tag, err := t.db.Exec(ctx, query, totp.UserID, totp.Secret, totp.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to store two-factor secret: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return domain.ErrTOTPAlreadyEnabled
+	}
+	return nil
+}
+
+func (t TOTPRepository) GetTOTP(ctx context.Context, userID uuid.UUID) (domain.TOTP, error) {
+	query := `
+		SELECT user_id, secret, confirmed_at, last_used_step, failed_attempts, locked_until, created_at
+		FROM user_totp WHERE user_id = $1`
+
+	var totp domain.TOTP
+	err := t.db.QueryRow(ctx, query, userID).Scan(
+		&totp.UserID, &totp.Secret, &totp.ConfirmedAt, &totp.LastUsedStep,
+		&totp.FailedAttempts, &totp.LockedUntil, &totp.CreatedAt,
+	)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return domain.TOTP{}, domain.ErrNotFound
+		}
+		return domain.TOTP{}, fmt.Errorf("failed to get two-factor secret: %w", err)
+	}
+	return totp, nil
+}
+
+// ConfirmTOTP enables the factor, recording the step of the code that confirmed it, and
+// stores its recovery codes, in a single transaction.
+func (t TOTPRepository) ConfirmTOTP(ctx context.Context, userID uuid.UUID, step int64, recoveryCodeHashes []string, confirmedAt time.Time) error {
+	tx, err := t.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	query := `UPDATE user_totp SET confirmed_at = $2, last_used_step = $3 WHERE user_id = $1 AND confirmed_at IS NULL`
+	tag, err := tx.Exec(ctx, query, userID, confirmedAt, step)
+	if err != nil {
+		return fmt.Errorf("failed to confirm two-factor authentication: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return domain.ErrTOTPAlreadyEnabled
+	}
+
+	if _, err := tx.Exec(ctx, `DELETE FROM totp_recovery_codes WHERE user_id = $1`, userID); err != nil {
+		return fmt.Errorf("failed to delete recovery codes: %w", err)
+	}
+	batch := &pgx.Batch{}
+	for _, codeHash := range recoveryCodeHashes {
+		batch.Queue(`INSERT INTO totp_recovery_codes (user_id, code_hash, created_at) VALUES ($1, $2, $3)`, userID, codeHash, confirmedAt)
+	}
+	if err := tx.SendBatch(ctx, batch).Close(); err != nil {
+		return fmt.Errorf("failed to store recovery codes: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit two-factor confirmation: %w", err)
+	}
+	return nil
+}
+
+// UseTOTPStep records that the code of the step was accepted and clears the failed
+// attempts. It fails with ErrInvalidTOTPCode when a code of that step or a later one was
+// already accepted, so that each code works only once, and with ErrTOTPLocked while the
+// factor is locked, even when the lock was set after the factor was read.
+func (t TOTPRepository) UseTOTPStep(ctx context.Context, userID uuid.UUID, step int64) error {
+	query := `
+		UPDATE user_totp SET last_used_step = $2, failed_attempts = 0
+		WHERE user_id = $1 AND last_used_step < $2 AND (locked_until IS NULL OR locked_until <= now())`
+
+	tag, err := t.db.Exec(ctx, query, userID, step)
+	if err != nil {
+		return fmt.Errorf("failed to record two-factor code: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return t.refusedCodeError(ctx, userID)
+	}
+	return nil
+}
+
+// UseRecoveryCode marks an unused recovery code of the user as used. It fails with
+// ErrTOTPLocked while the factor is locked and with ErrInvalidTOTPCode otherwise.
+func (t TOTPRepository) UseRecoveryCode(ctx context.Context, userID uuid.UUID, codeHash string, usedAt time.Time) error {
+	query := `
+		UPDATE totp_recovery_codes c SET used_at = $3
+		FROM user_totp t
+		WHERE c.user_id = $1 AND c.code_hash = $2 AND c.used_at IS NULL
+			AND t.user_id = c.user_id AND (t.locked_until IS NULL OR t.locked_until <= now())`
+
+	tag, err := t.db.Exec(ctx, query, userID, codeHash, usedAt)
+	if err != nil {
+		return fmt.Errorf("failed to use recovery code: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return t.refusedCodeError(ctx, userID)
+	}
+	return nil
+}
+
+// refusedCodeError tells why a code was refused: ErrTOTPLocked when the factor is locked,
+// ErrInvalidTOTPCode otherwise.
+func (t TOTPRepository) refusedCodeError(ctx context.Context, userID uuid.UUID) error {
+	var locked bool
+	query := `SELECT EXISTS (SELECT 1 FROM user_totp WHERE user_id = $1 AND locked_until > now())`
+	if err := t.db.QueryRow(ctx, query, userID).Scan(&locked); err != nil {
+		return fmt.Errorf("failed to check two-factor lock: %w", err)
+	}
+	if locked {
+		return domain.ErrTOTPLocked
+	}
+	return domain.ErrInvalidTOTPCode
+}
+
+// RecordTOTPFailure counts a wrong code. The maxAttempts-th one locks the factor until
+// lockedUntil and starts the count again. It reports whether the factor is now locked.
+func (t TOTPRepository) RecordTOTPFailure(ctx context.Context, userID uuid.UUID, maxAttempts int, lockedUntil time.Time) (bool, error) {
+	query := `
+		UPDATE user_totp SET
+			failed_attempts = CASE WHEN failed_attempts + 1 >= $2 THEN 0 ELSE failed_attempts + 1 END,
+			locked_until = CASE WHEN failed_attempts + 1 >= $2 THEN $3 ELSE locked_until END
+		WHERE user_id = $1
+		RETURNING COALESCE(locked_until > now(), false)`
+
+	var locked bool
+	if err := t.db.QueryRow(ctx, query, userID, maxAttempts, lockedUntil).Scan(&locked); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to record two-factor failure: %w", err)
+	}
+	return locked, nil
+}
+
+// UseMFAToken records that the MFA token completed a login, so that it cannot complete
+// another one, and fails with ErrMFATokenUsed when it already did. Tokens are kept until
+// they expire; the expired ones of the user are removed on the way.
+func (t TOTPRepository) UseMFAToken(ctx context.Context, userID uuid.UUID, tokenID string, expiresAt time.Time) error {
+	query := `
+		WITH expired AS (
+			DELETE FROM used_mfa_tokens WHERE user_id = $1 AND expires_at <= now()
+		)
+		INSERT INTO used_mfa_tokens (token_id, user_id, expires_at)
+		VALUES ($2, $1, $3)
+		ON CONFLICT (token_id) DO NOTHING`
+
+	tag, err := t.db.Exec(ctx, query, userID, tokenID, expiresAt)
+	if err != nil {
+		return fmt.Errorf("failed to record mfa token: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return domain.ErrMFATokenUsed
+	}
+	return nil
+}
+
+// DeleteTOTP turns two-factor authentication off, deleting the recovery codes with it.
+func (t TOTPRepository) DeleteTOTP(ctx context.Context, userID uuid.UUID) error {
+	tag, err := t.db.Exec(ctx, `DELETE FROM user_totp WHERE user_id = $1`, userID)
+	if err != nil {
+		return fmt.Errorf("failed to delete two-factor secret: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
+
+func NewTOTPRepository(db *pgxpool.Pool) *TOTPRepository {
+	return &TOTPRepository{
+		db: db,
+	}
+}
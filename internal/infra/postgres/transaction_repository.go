@@ -0,0 +1,167 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/misalima/my-budget-planner-backend/internal/core/domain"
+	"github.com/misalima/my-budget-planner-backend/internal/core/interfaces/irepository"
+	"strings"
+)
+
+type TransactionRepository struct {
+	db *pgxpool.Pool
+}
+
+// FindTransactions returns one page of the ledger together with the number of entries
+// matching the filters. The sources are merged, filtered, sorted and paginated in a
+// single query, so the cost of a page does not grow with the history of the account.
+func (t TransactionRepository) FindTransactions(ctx context.Context, userID uuid.UUID, filters irepository.TransactionFilters) ([]domain.Transaction, int, error) {
+	var args []interface{}
+	arg := func(value interface{}) string {
+		args = append(args, value)
+		return fmt.Sprintf("$%d", len(args))
+	}
+	user := arg(userID)
+
+	var sources []string
+	for _, transactionType := range filters.Types {
+		switch transactionType {
+		case domain.TransactionTypeSimpleExpense:
+			sources = append(sources, `
+				SELECT "ID" AS id, "ID"::text AS occurrence_id, 'simple_expense' AS type, category_id, amount, currency,
+					description, date, NULL::uuid AS card_id, NULL::uuid AS template_id,
+					NULL::int AS parcel_number, NULL::int AS installments_quantity, created_at
+				FROM simple_expense WHERE user_id = `+user)
+		case domain.TransactionTypeRecurringExpense:
+			sources = append(sources, `
+				SELECT "ID", "ID"::text, 'recurring_expense', category_id, amount, currency,
+					description, date, card_id, template_id, NULL::int, NULL::int, created_at
+				FROM recurring_expense WHERE user_id = `+user+` AND template_id IS NOT NULL`)
+		case domain.TransactionTypeCreditCardExpense:
+			// Each installment counts for its own value, as in InstallmentValue.
+			sources = append(sources, `
+				SELECT "ID", "ID"::text, 'credit_card_expense', category_id,
+					CASE WHEN installment_amount <> 0 THEN installment_amount ELSE amount END, currency,
+					description, date, card_id, NULL::uuid, parcel_number, installments_quantity, created_at
+				FROM credit_card_expense WHERE user_id = `+user)
+		case domain.TransactionTypeIncome:
+			// Recurring incomes are expanded into one row per occurrence, with the same
+			// rules as domain.Income.OccurrencesBetween: adding whole months to a date
+			// clamps it to the end of shorter months. Occurrences share the income ID,
+			// so occurrence_id adds the date to it.
+			until := arg(filters.RecurringIncomeUntil)
+			sources = append(sources, `
+				SELECT i."ID",
+					CASE WHEN i.frequency IS NULL THEN i."ID"::text ELSE i."ID"::text || ':' || o.date::text END,
+					'income', i.category_id, i.amount, i.currency,
+					i.description, o.date, NULL::uuid, NULL::uuid, NULL::int, NULL::int, i.created_at
+				FROM incomes i
+				CROSS JOIN LATERAL (
+					SELECT i.date AS date WHERE i.frequency IS NULL
+					UNION ALL
+					SELECT (i.date + n * CASE i.frequency
+						WHEN 'daily' THEN interval '1 day'
+						WHEN 'weekly' THEN interval '7 days'
+						WHEN 'biweekly' THEN interval '14 days'
+						WHEN 'monthly' THEN interval '1 month'
+						WHEN 'yearly' THEN interval '1 year' END)::date
+					FROM generate_series(0, GREATEST(`+until+`::date - i.date, 0) / CASE i.frequency
+						WHEN 'daily' THEN 1 WHEN 'weekly' THEN 7 WHEN 'biweekly' THEN 14
+						WHEN 'monthly' THEN 28 ELSE 365 END) AS n
+					WHERE i.frequency IS NOT NULL
+				) o
+				WHERE i.user_id = `+user+` AND o.date <= `+until+`::date
+					AND (i.end_date IS NULL OR o.date <= i.end_date)`)
+		}
+	}
+	if len(sources) == 0 {
+		return []domain.Transaction{}, 0, nil
+	}
+
+	var conditions []string
+	if filters.CategoryID != nil {
+		conditions = append(conditions, "category_id = "+arg(*filters.CategoryID))
+	}
+	if filters.CardID != nil {
+		conditions = append(conditions, "card_id = "+arg(*filters.CardID))
+	}
+	if filters.StartDate != nil {
+		conditions = append(conditions, "date >= "+arg(*filters.StartDate))
+	}
+	if filters.EndDate != nil {
+		conditions = append(conditions, "date <= "+arg(*filters.EndDate))
+	}
+	if filters.Currency != nil {
+		conditions = append(conditions, "currency = "+arg(*filters.Currency))
+	}
+	if filters.MinAmount != nil {
+		conditions = append(conditions, "amount >= "+arg(domain.NewMoneyFromFloat(*filters.MinAmount))+"::numeric")
+	}
+	if filters.MaxAmount != nil {
+		conditions = append(conditions, "amount <= "+arg(domain.NewMoneyFromFloat(*filters.MaxAmount))+"::numeric")
+	}
+	entries := "SELECT * FROM (" + strings.Join(sources, "\nUNION ALL\n") + ") entries"
+	if len(conditions) > 0 {
+		entries += " WHERE " + strings.Join(conditions, " AND ")
+	}
+
+	direction := "DESC"
+	if filters.Ascending {
+		direction = "ASC"
+	}
+	order := fmt.Sprintf("date %[1]s, created_at %[1]s, id %[1]s, occurrence_id %[1]s", direction)
+	if filters.SortBy == domain.TransactionSortAmount {
+		order = fmt.Sprintf("amount %s, %s", direction, order)
+	}
+	filterArgs := len(args)
+	page := ""
+	if filters.Limit != nil {
+		page += " LIMIT " + arg(*filters.Limit)
+	}
+	if filters.Offset != nil {
+		page += " OFFSET " + arg(*filters.Offset)
+	}
+	query := fmt.Sprintf(`
+		SELECT id, occurrence_id, type, category_id, amount, currency, description, date, card_id, template_id,
+			parcel_number, installments_quantity, created_at, count(*) OVER ()
+		FROM (%s) filtered
+		ORDER BY %s%s`, entries, order, page)
+
+	rows, err := t.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to find transactions: %w", err)
+	}
+	defer rows.Close()
+
+	transactions := []domain.Transaction{}
+	total := 0
+	for rows.Next() {
+		var tr domain.Transaction
+		if err := rows.Scan(
+			&tr.ID, &tr.OccurrenceID, &tr.Type, &tr.CategoryID, &tr.Amount, &tr.Currency, &tr.Description, &tr.Date,
+			&tr.CardID, &tr.TemplateID, &tr.ParcelNumber, &tr.InstallmentsQuantity, &tr.CreatedAt, &total,
+		); err != nil {
+			return nil, 0, fmt.Errorf("failed to scan transaction: %w", err)
+		}
+		transactions = append(transactions, tr)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("failed to find transactions: %w", err)
+	}
+
+	// A page past the end has no rows to carry the total, so it is counted apart.
+	if len(transactions) == 0 && filters.Offset != nil && *filters.Offset > 0 {
+		if err := t.db.QueryRow(ctx, "SELECT count(*) FROM ("+entries+") filtered", args[:filterArgs]...).Scan(&total); err != nil {
+			return nil, 0, fmt.Errorf("failed to count transactions: %w", err)
+		}
+	}
+	return transactions, total, nil
+}
+
+func NewTransactionRepository(db *pgxpool.Pool) *TransactionRepository {
+	return &TransactionRepository{
+		db: db,
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/misalima/my-budget-planner-backend/internal/core/domain"
@@ -21,10 +22,10 @@ func NewUserRepository(Conn *pgxpool.Pool) *UserRepository {
 
 func (u UserRepository) CreateUser(ctx context.Context, user *domain.User) error {
 
-	sql := `INSERT INTO users (username, first_name, last_name, email, password_hash) 
-			VALUES ($1, $2, $3, $4, $5)`
+	sql := `INSERT INTO users (username, first_name, last_name, email, password_hash, base_currency) 
+			VALUES ($1, $2, $3, $4, $5, $6)`
 	log.Print("Executing query")
-	tag, err := u.Conn.Exec(ctx, sql, user.Username, user.FirstName, user.LastName, user.Email, user.Password)
+	tag, err := u.Conn.Exec(ctx, sql, user.Username, user.FirstName, user.LastName, user.Email, user.Password, user.BaseCurrency)
 	if err != nil {
 		return err
 	}
@@ -36,11 +37,11 @@ func (u UserRepository) CreateUser(ctx context.Context, user *domain.User) error
 }
 
 func (u UserRepository) GetUserByEmail(ctx context.Context, email string) (*domain.User, error) {
-	sql := `SELECT "ID", username, first_name, last_name, password_hash, email, profile_picture, income, expenditure_limit, created_at, updated_at FROM users WHERE email = $1`
+	sql := `SELECT "ID", username, first_name, last_name, password_hash, email, profile_picture, income, expenditure_limit, base_currency, created_at, updated_at FROM users WHERE email = $1`
 	user := &domain.User{}
 	err := u.Conn.QueryRow(ctx, sql, email).Scan(
 		&user.ID, &user.Username, &user.FirstName, &user.LastName, &user.Password, &user.Email,
-		&user.ProfilePicture, &user.Income, &user.ExpenditureLimit, &user.CreatedAt, &user.UpdatedAt,
+		&user.ProfilePicture, &user.Income, &user.ExpenditureLimit, &user.BaseCurrency, &user.CreatedAt, &user.UpdatedAt,
 	)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
@@ -51,3 +52,52 @@ func (u UserRepository) GetUserByEmail(ctx context.Context, email string) (*doma
 
 	return user, nil
 }
+
+func (u UserRepository) GetUserByID(ctx context.Context, id uuid.UUID) (*domain.User, error) {
+	sql := `SELECT "ID", username, first_name, last_name, password_hash, email, profile_picture, income, expenditure_limit, base_currency, created_at, updated_at FROM users WHERE "ID" = $1`
+	user := &domain.User{}
+	err := u.Conn.QueryRow(ctx, sql, id).Scan(
+		&user.ID, &user.Username, &user.FirstName, &user.LastName, &user.Password, &user.Email,
+		&user.ProfilePicture, &user.Income, &user.ExpenditureLimit, &user.BaseCurrency, &user.CreatedAt, &user.UpdatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrNotFound
+		}
+		return nil, err
+	}
+
+	return user, nil
+}
+
+func (u UserRepository) ListUserIDs(ctx context.Context) ([]uuid.UUID, error) {
+	rows, err := u.Conn.Query(ctx, `SELECT "ID" FROM users ORDER BY created_at`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var ids []uuid.UUID
+	for rows.Next() {
+		var id uuid.UUID
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	if rows.Err() != nil {
+		return nil, rows.Err()
+	}
+	return ids, nil
+}
+
+func (u UserRepository) UpdateBaseCurrency(ctx context.Context, id uuid.UUID, currency string) error {
+	tag, err := u.Conn.Exec(ctx, `UPDATE users SET base_currency = $1, updated_at = now() WHERE "ID" = $2`, currency, id)
+	if err != nil {
+		return fmt.Errorf("failed to update base currency: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
@@ -0,0 +1,83 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/misalima/my-budget-planner-backend/internal/core/interfaces/iservice"
+	"log"
+	"os"
+	"time"
+)
+
+// RecurringExpenseGenerationJob generates, every night, the recurring expense
+// occurrences due up to the current day for every user.
+func RecurringExpenseGenerationJob(users iservice.UserManager, recurring iservice.RecurringExpenseManager) Job {
+	return Job{
+		Name:     "recurring-expense-generation",
+		Schedule: DailyAt(2, 0),
+		Timeout:  30 * time.Minute,
+		Run: func(ctx context.Context) error {
+			userIDs, err := users.ListUserIDs(ctx)
+			if err != nil {
+				return err
+			}
+			now := time.Now().UTC()
+			today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+
+			var errs []error
+			total := 0
+			for _, userID := range userIDs {
+				generated, err := recurring.GenerateRecurringExpenses(ctx, userID, today)
+				if err != nil {
+					errs = append(errs, fmt.Errorf("user %s: %w", userID, err))
+					continue
+				}
+				total += generated
+			}
+			log.Printf("scheduler: generated %d recurring expense occurrences for %d users", total, len(userIDs))
+			return errors.Join(errs...)
+		},
+	}
+}
+
+// RefreshTokenCleanupJob removes expired rows from refresh_tokens every hour.
+func RefreshTokenCleanupJob(auth iservice.AuthManager) Job {
+	return Job{
+		Name:     "refresh-token-cleanup",
+		Schedule: Every(time.Hour),
+		Timeout:  5 * time.Minute,
+		Run: func(ctx context.Context) error {
+			deleted, err := auth.PurgeExpiredRefreshTokens(ctx)
+			if err != nil {
+				return err
+			}
+			log.Printf("scheduler: removed %d expired refresh tokens", deleted)
+			return nil
+		},
+	}
+}
+
+// ExchangeRateReloadJob imports the exchange rate CSV at path again every hour, so
+// rates appended to the file reach the database without restarting the API.
+func ExchangeRateReloadJob(rates iservice.ExchangeRateManager, path string) Job {
+	return Job{
+		Name:     "exchange-rate-reload",
+		Schedule: Every(time.Hour),
+		Timeout:  5 * time.Minute,
+		Run: func(ctx context.Context) error {
+			file, err := os.Open(path)
+			if err != nil {
+				return fmt.Errorf("failed to open exchange rates file: %w", err)
+			}
+			defer file.Close()
+
+			count, err := rates.ImportExchangeRatesCSV(ctx, file)
+			if err != nil {
+				return fmt.Errorf("failed to import exchange rates from %s: %w", path, err)
+			}
+			log.Printf("scheduler: loaded %d exchange rates from %s", count, path)
+			return nil
+		},
+	}
+}
@@ -0,0 +1,142 @@
+package scheduler
+
+import (
+	"context"
+	"log"
+	"time"
+)
+
+// Locker guarantees that a job runs on a single instance at a time when several
+// replicas of the API share the same database.
+type Locker interface {
+	// TryLock returns ok=false without blocking when another instance holds the lock.
+	TryLock(ctx context.Context, name string) (release func(), ok bool, err error)
+}
+
+// RunLog records the schedule slots jobs completed, so that a slot runs once even when
+// the timers of several replicas fire one after the other.
+type RunLog interface {
+	LastCompletedSlot(ctx context.Context, job string) (time.Time, error)
+	RecordCompletedSlot(ctx context.Context, job string, slot time.Time) error
+}
+
+// Schedule tells when a job must run next. Every instance must compute the same slots,
+// so schedules only depend on the wall clock, not on when an instance started.
+type Schedule interface {
+	Next(after time.Time) time.Time
+}
+
+type Job struct {
+	Name     string
+	Schedule Schedule
+	Timeout  time.Duration
+	Run      func(ctx context.Context) error
+}
+
+type Scheduler struct {
+	locker Locker
+	runs   RunLog
+	jobs   []Job
+}
+
+func New(locker Locker, runs RunLog) *Scheduler {
+	return &Scheduler{locker: locker, runs: runs}
+}
+
+func (s *Scheduler) Add(job Job) {
+	s.jobs = append(s.jobs, job)
+}
+
+// Start launches one goroutine per job. They stop when ctx is cancelled.
+func (s *Scheduler) Start(ctx context.Context) {
+	for _, job := range s.jobs {
+		go s.loop(ctx, job)
+	}
+}
+
+func (s *Scheduler) loop(ctx context.Context, job Job) {
+	for {
+		slot := job.Schedule.Next(time.Now())
+		timer := time.NewTimer(time.Until(slot))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+			s.runOnce(ctx, job, slot)
+		}
+	}
+}
+
+// runOnce runs the job for the slot unless another instance is running it or already
+// completed that slot.
+func (s *Scheduler) runOnce(ctx context.Context, job Job, slot time.Time) {
+	if job.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, job.Timeout)
+		defer cancel()
+	}
+
+	release, ok, err := s.locker.TryLock(ctx, job.Name)
+	if err != nil {
+		log.Printf("scheduler: could not lock job %s: %v", job.Name, err)
+		return
+	}
+	if !ok {
+		log.Printf("scheduler: job %s is running on another instance, skipping", job.Name)
+		return
+	}
+	defer release()
+
+	last, err := s.runs.LastCompletedSlot(ctx, job.Name)
+	if err != nil {
+		log.Printf("scheduler: could not read last run of job %s: %v", job.Name, err)
+		return
+	}
+	if !last.Before(slot) {
+		log.Printf("scheduler: job %s already ran for %s on another instance, skipping", job.Name, slot.Format(time.RFC3339))
+		return
+	}
+
+	started := time.Now()
+	if err := job.Run(ctx); err != nil {
+		// The slot is not recorded, so an instance whose timer fires later retries it.
+		log.Printf("scheduler: job %s failed after %s: %v", job.Name, time.Since(started), err)
+		return
+	}
+	if err := s.runs.RecordCompletedSlot(ctx, job.Name, slot); err != nil {
+		log.Printf("scheduler: could not record run of job %s: %v", job.Name, err)
+	}
+	log.Printf("scheduler: job %s finished in %s", job.Name, time.Since(started))
+}
+
+type dailyAt struct {
+	hour, minute int
+}
+
+// DailyAt runs a job once a day at the given UTC time, the time zone of the dates jobs
+// work with.
+func DailyAt(hour, minute int) Schedule {
+	return dailyAt{hour: hour, minute: minute}
+}
+
+func (d dailyAt) Next(after time.Time) time.Time {
+	after = after.UTC()
+	next := time.Date(after.Year(), after.Month(), after.Day(), d.hour, d.minute, 0, 0, time.UTC)
+	if !next.After(after) {
+		next = next.AddDate(0, 0, 1)
+	}
+	return next
+}
+
+type every time.Duration
+
+// Every runs a job at a fixed interval, at the multiples of the interval since the zero
+// time, so that every instance uses the same slots.
+func Every(interval time.Duration) Schedule {
+	return every(interval)
+}
+
+func (e every) Next(after time.Time) time.Time {
+	return after.UTC().Truncate(time.Duration(e)).Add(time.Duration(e))
+}